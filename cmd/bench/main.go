@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/bench"
+)
+
+func main() {
+	config := flag.String("config", "experiments/paper.json", "experiment file (JSON or YAML)")
+	out := flag.String("out", "bench-results", "directory for runs.csv and summary.csv")
+	parallel := flag.Int("parallel", 0, "concurrent runs, overrides the experiment file")
+	alpha := flag.Float64("alpha", 0.05, "significance level of the comparisons")
+	flag.Parse()
+
+	exp, err := bench.LoadExperiment(*config)
+	if err != nil {
+		fail(err)
+	}
+	if *parallel > 0 {
+		exp.Parallel = *parallel
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results, err := bench.Run(ctx, exp)
+	if err != nil {
+		fail(err)
+	}
+	summaries := bench.Summarize(results)
+	if err := bench.WriteFiles(*out, results, summaries); err != nil {
+		fail(err)
+	}
+	if err := bench.PrintSummary(os.Stdout, summaries); err != nil {
+		fail(err)
+	}
+
+	if len(exp.Methods) < 2 {
+		return
+	}
+	comparisons, err := bench.Compare(results, *alpha)
+	if err != nil {
+		fail(err)
+	}
+	ranking, err := bench.Rank(results, *alpha)
+	if err != nil {
+		fail(err)
+	}
+	if err := bench.WriteComparisonFiles(*out, comparisons, ranking); err != nil {
+		fail(err)
+	}
+	fmt.Println()
+	if err := bench.PrintComparisons(os.Stdout, comparisons, ranking); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
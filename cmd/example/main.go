@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -16,13 +17,18 @@ import (
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane/operators/mutation"
 )
 
+const (
+	checkpointPath     = "example-checkpoint.json"
+	checkpointInterval = 100
+)
+
 func main() {
 	vertexes := 200
 	timeLimit := 300 * time.Second
+	seed := uint64(1)
 
-	problem := graphplane.NewPlanarGraphPlaneProblem(vertexes)
-
-	logger := initLogger(problem, "SGA")
+	// the problem is regenerated from its seed, so a checkpoint can be resumed after a crash
+	problem := graphplane.NewPlanarGraphPlaneProblem(problems.NewRand(seed), vertexes)
 
 	params := sga.Params{
 		PopulationSize:       500,
@@ -30,20 +36,63 @@ func main() {
 		MatingPoolPercentile: 0.5,
 		MutationFunc:         mutation.Uniform(),
 		CrossoverFunc:        crossover.Uniform(0.5),
+		Seed:                 seed,
+	}
+	alg := sga.NewAlgorithm(problem, params, math.MaxInt, nil)
+
+	_, err := os.Stat(checkpointPath)
+	resume := !errors.Is(err, os.ErrNotExist)
+	if resume {
+		if err := algos.LoadCheckpoint(checkpointPath, alg); err != nil {
+			panic(err)
+		}
+		fmt.Printf("resumed from generation %d\n", alg.GetSteps())
+	}
+
+	logger, err := initLogger(problem, "SGA", resume, alg.GetSteps())
+	if err != nil {
+		panic(err)
 	}
-	alg := sga.NewAlgorithm(problem, params, math.MaxInt, logger)
+	defer logger.Close()
+	alg.ProgressLoggerProvider = logger
+	alg.AddObserver(algos.ObserverFuncs{Generation: func(s *algos.Snapshot) {
+		if s.Generation%checkpointInterval != 0 {
+			return
+		}
+		// the log must contain every step the checkpoint does
+		if err := logger.Flush(); err != nil {
+			panic(err)
+		}
+		if err := algos.SaveCheckpoint(checkpointPath, alg); err != nil {
+			panic(err)
+		}
+	}})
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeLimit)
+	ctx, cancel := context.WithTimeout(context.Background(), timeLimit-time.Since(alg.StartTimestamp))
+	defer cancel()
 	alg.Run(ctx)
-	cancel()
+	if err := alg.Err(); err != nil {
+		panic(err)
+	}
+	os.Remove(checkpointPath)
 }
 
-func initLogger(problem problems.Problem, method string) algos.ProgressLoggerProvider {
-	os.RemoveAll("logs")
-	os.Mkdir("logs", 0755)
+// initLogger starts a new log, or continues the log of an interrupted run from
+// step, dropping the records written after its last checkpoint.
+func initLogger(problem problems.Problem, method string, resume bool, step int) (algos.ProgressLoggerProvider, error) {
 	logPath := filepath.Join("logs", fmt.Sprintf("%s_%s.jsonl", problem.Name(), method))
 	logger := algos.NewProgressLogger(logPath)
-	logger.InitLogging()
-	logger.LogProblem(problem)
-	return logger
+	if resume {
+		if err := algos.TruncateLog(logPath, step); err != nil {
+			return nil, err
+		}
+		logger.Existing = algos.AppendExisting
+		return logger, logger.InitLogging()
+	}
+	os.RemoveAll("logs")
+	os.Mkdir("logs", 0755)
+	if err := logger.InitLogging(); err != nil {
+		return nil, err
+	}
+	return logger, logger.LogProblem(problem)
 }
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/bench"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/tuning"
+)
+
+func main() {
+	config := flag.String("config", "experiments/tune-sga-fr.json", "tuning file (JSON)")
+	out := flag.String("out", "tuned.json", "file for the tuned method, usable in experiment method_files")
+	parallel := flag.Int("parallel", 0, "concurrent runs, overrides the tuning file")
+	flag.Parse()
+
+	t, err := tuning.Load(*config)
+	if err != nil {
+		fail(err)
+	}
+	if *parallel > 0 {
+		t.Parallel = *parallel
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := tuning.Tune(ctx, t, os.Stdout)
+	if err != nil {
+		fail(err)
+	}
+	if err := bench.WriteMethod(*out, result.Method); err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("\n%d runs, elites:\n", result.Runs)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "id\tmean fitness\tinstances\t")
+	for _, e := range result.Elites {
+		fmt.Fprintf(tw, "#%d\t%.4g\t%d\t\n", e.ID, e.Mean(), len(e.Fitness))
+	}
+	tw.Flush()
+
+	fmt.Printf("\nbest configuration (#%d), written to %s:\n", result.Best.ID, *out)
+	names := make([]string, 0, len(result.Best.Config))
+	for name := range result.Best.Config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %s = %v\n", name, result.Best.Config[name])
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
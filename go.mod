@@ -5,3 +5,5 @@ go 1.23.4
 require github.com/fogleman/delaunay v0.0.0-20180910191513-63f09b4c883d
 
 require gonum.org/v1/gonum v0.16.0
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,263 @@
+package adaptive
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+var (
+	_ algos.MutationOperator = (*Mutation)(nil)
+	_ algos.Observer         = (*Mutation)(nil)
+)
+
+// Mutation applies one of several mutation operators, picked by the credit they
+// earned so far. The credit of a child is its improvement over the fitness of
+// its parent (see algos.FitnessReference), relative to that fitness and divided
+// by the largest improvement of the same generation, so the most successful
+// operator of a generation scores 1. Crediting never evaluates a solution.
+//
+// Mutation is an algos.MutationOperator and an algos.Observer: given as the
+// "mutation" parameter of algos.New it follows the run and logs the operator
+// statistics of every generation (see algos.GAStep.Operators). Children are
+// credited one generation after they are made, when every algorithm has
+// evaluated them; those made before the first generation ends have no
+// reference and are not credited. A Mutation keeps the state of one run and
+// must not be shared by several algorithms; Clone gives another run its own.
+type Mutation struct {
+	names     []string
+	operators []problems.MutationFunc
+	params    Params
+
+	mu          sync.Mutex
+	probability []float64
+	quality     []float64
+	picks       []int // UCB: times each operator was picked
+	credited    []int // UCB: children credited to each operator
+	pending     []trial
+	ripe        []trial
+	reference   *algos.FitnessReference // nil before the first generation
+}
+
+// trial records one application of an operator and the fitness its child has to beat.
+type trial struct {
+	operator  int
+	reference float64
+	child     problems.Solution
+}
+
+// NewMutation creates an adaptive mutation over operators, reported under names.
+func NewMutation(names []string, operators []problems.MutationFunc, params Params) (*Mutation, error) {
+	k := len(operators)
+	if k == 0 {
+		return nil, errors.New("adaptive mutation needs at least one operator")
+	}
+	if len(names) != k {
+		return nil, fmt.Errorf("adaptive mutation has %d operators but %d names", k, len(names))
+	}
+	if params.Strategy != UCB && params.MinProbability*float64(k) >= 1 {
+		return nil, fmt.Errorf("minimum probability %v is too large for %d operators", params.MinProbability, k)
+	}
+	m := &Mutation{
+		names:       names,
+		operators:   operators,
+		params:      params,
+		probability: make([]float64, k),
+		quality:     make([]float64, k),
+		picks:       make([]int, k),
+		credited:    make([]int, k),
+	}
+	for i := range m.probability {
+		m.probability[i] = 1 / float64(k)
+	}
+	return m, nil
+}
+
+// Clone returns a Mutation over the same operators with the state of a new run.
+func (m *Mutation) Clone() any {
+	clone, _ := NewMutation(m.names, m.operators, m.params)
+	return clone
+}
+
+// Mutate applies an operator picked by the selection strategy.
+func (m *Mutation) Mutate(rng *rand.Rand, individual problems.Solution) problems.Solution {
+	m.mu.Lock()
+	i := m.pick(rng)
+	m.mu.Unlock()
+
+	child := m.operators[i](rng, individual)
+
+	m.mu.Lock()
+	if m.reference != nil {
+		m.pending = append(m.pending, trial{operator: i, reference: m.reference.Of(individual), child: child})
+	}
+	m.mu.Unlock()
+	return child
+}
+
+// Stats returns the current probability and quality of every operator.
+func (m *Mutation) Stats() []algos.OperatorStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats(make([]int, len(m.operators)), make([]int, len(m.operators)))
+}
+
+func (m *Mutation) OnStart(s *algos.Snapshot)       {}
+func (m *Mutation) OnImprovement(s *algos.Snapshot) {}
+func (m *Mutation) OnFinish(s *algos.Snapshot)      {}
+
+// OnGeneration credits the children of the previous generation, logs how each
+// operator fared with them and takes the population the next parents come from.
+func (m *Mutation) OnGeneration(s *algos.Snapshot) {
+	reference := s.FitnessReference()
+
+	m.mu.Lock()
+	trials := m.ripe
+	m.ripe, m.pending = m.pending, nil
+	m.reference = reference
+	var stats []algos.OperatorStats
+	if len(trials) > 0 {
+		uses, successes := m.credit(trials)
+		stats = m.stats(uses, successes)
+	}
+	m.mu.Unlock()
+
+	if stats != nil {
+		s.LogOperators(stats)
+	}
+}
+
+func (m *Mutation) pick(rng *rand.Rand) int {
+	if m.params.Strategy == UCB {
+		total := 0
+		for _, n := range m.picks {
+			total += n
+		}
+		best, bestBound := 0, math.Inf(-1)
+		for i, n := range m.picks {
+			if n == 0 {
+				best = i
+				break
+			}
+			bound := m.quality[i] + m.params.Exploration*math.Sqrt(2*math.Log(float64(total))/float64(n))
+			if bound > bestBound {
+				best, bestBound = i, bound
+			}
+		}
+		m.picks[best]++
+		return best
+	}
+
+	r := rng.Float64()
+	for i, p := range m.probability {
+		if r < p {
+			return i
+		}
+		r -= p
+	}
+	return len(m.probability) - 1
+}
+
+// credit updates the quality and probabilities of the operators with the
+// trials of one generation and returns their uses and successes.
+func (m *Mutation) credit(trials []trial) (uses, successes []int) {
+	k := len(m.operators)
+	uses, successes = make([]int, k), make([]int, k)
+	rewards := make([]float64, len(trials))
+	best := 0.0
+	for t, tr := range trials {
+		rewards[t] = improvement(tr.reference, tr.child.Fitness())
+		best = max(best, rewards[t])
+	}
+	sum := make([]float64, k)
+	for t, tr := range trials {
+		uses[tr.operator]++
+		if rewards[t] > 0 {
+			successes[tr.operator]++
+			sum[tr.operator] += rewards[t] / best
+		}
+	}
+
+	for i := range k {
+		if uses[i] == 0 {
+			continue
+		}
+		if m.params.Strategy == UCB {
+			m.credited[i] += uses[i]
+			m.quality[i] += (sum[i] - float64(uses[i])*m.quality[i]) / float64(m.credited[i])
+			continue
+		}
+		m.quality[i] += m.params.Adaptation * (sum[i]/float64(uses[i]) - m.quality[i])
+	}
+
+	pMin := m.params.MinProbability
+	switch m.params.Strategy {
+	case ProbabilityMatching:
+		total := 0.0
+		for _, q := range m.quality {
+			total += q
+		}
+		for i, q := range m.quality {
+			if total > 0 {
+				m.probability[i] = pMin + (1-float64(k)*pMin)*q/total
+			} else {
+				m.probability[i] = 1 / float64(k)
+			}
+		}
+	case AdaptivePursuit:
+		leader := 0
+		for i, q := range m.quality {
+			if q > m.quality[leader] {
+				leader = i
+			}
+		}
+		pMax := 1 - float64(k-1)*pMin
+		for i := range m.probability {
+			target := pMin
+			if i == leader {
+				target = pMax
+			}
+			m.probability[i] += m.params.Learning * (target - m.probability[i])
+		}
+	case UCB:
+		total := 0
+		for _, n := range m.picks {
+			total += n
+		}
+		for i, n := range m.picks {
+			m.probability[i] = float64(n) / float64(total)
+		}
+	}
+	return uses, successes
+}
+
+func (m *Mutation) stats(uses, successes []int) []algos.OperatorStats {
+	stats := make([]algos.OperatorStats, len(m.operators))
+	for i := range stats {
+		stats[i] = algos.OperatorStats{
+			Name:        m.names[i],
+			Uses:        uses[i],
+			Successes:   successes[i],
+			Probability: m.probability[i],
+			Quality:     m.quality[i],
+		}
+	}
+	return stats
+}
+
+// improvement is the decrease of fitness from before to after relative to
+// before, or 0 if after is not better.
+func improvement(before, after float64) float64 {
+	if !(after < before) || math.IsInf(before, 0) {
+		return 0
+	}
+	if before == 0 {
+		return before - after
+	}
+	return (before - after) / math.Abs(before)
+}
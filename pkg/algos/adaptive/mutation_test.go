@@ -0,0 +1,192 @@
+package adaptive
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// scale returns an operator multiplying the point by factor.
+func scale(factor float64) problems.MutationFunc {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
+		x := individual.(*testutil.SphereSolution).X
+		child := make([]float64, len(x))
+		for i, v := range x {
+			child[i] = v * factor
+		}
+		return &testutil.SphereSolution{X: child}
+	}
+}
+
+// run feeds m the generations of a population of points, each generation
+// mutating every member once.
+type run struct {
+	ga  algos.GeneticAlgorithm
+	pop []problems.Solution
+	rng *rand.Rand
+}
+
+func newRun(m *Mutation, size int) *run {
+	r := &run{rng: rand.New(rand.NewPCG(1, 1))}
+	for i := range size {
+		r.pop = append(r.pop, &testutil.SphereSolution{X: []float64{float64(i + 1)}})
+	}
+	r.ga.Solution = r.pop[0]
+	r.ga.ObservePopulation(func() []problems.Solution { return r.pop })
+	r.ga.AddObserver(m)
+	r.ga.NotifyObservers()
+	return r
+}
+
+func (r *run) generation(m *Mutation) {
+	for _, sol := range r.pop {
+		m.Mutate(r.rng, sol)
+	}
+	r.ga.NotifyObservers()
+}
+
+func TestImprovement(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after float64
+		want          float64
+	}{
+		{"relative decrease", 4, 1, 0.75},
+		{"negative fitness", -2, -3, 0.5},
+		{"from zero", 0, -0.5, 0.5},
+		{"worse", 1, 2, 0},
+		{"equal", 1, 1, 0},
+		{"infinite reference", math.Inf(1), 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := improvement(tt.before, tt.after); got != tt.want {
+				t.Errorf("improvement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbabilityUpdates(t *testing.T) {
+	operators := []problems.MutationFunc{scale(0.5), scale(2)}
+	tests := []struct {
+		name   string
+		params Params
+		// probability of the improving operator after one and after ten credited generations
+		first, last float64
+	}{
+		// qualities 0.3 and 0 give the improving operator 1 - p_min at once
+		{"probability matching", Params{Strategy: ProbabilityMatching, MinProbability: 0.05, Adaptation: 0.3}, 0.95, 0.95},
+		// 0.5 + 0.3·(0.95 - 0.5), then on towards 0.95
+		{"adaptive pursuit", Params{Strategy: AdaptivePursuit, MinProbability: 0.05, Adaptation: 0.3, Learning: 0.3}, 0.635, 0.95 - 0.45*math.Pow(0.7, 10)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMutation([]string{"shrink", "grow"}, operators, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := newRun(m, 20)
+			r.generation(m) // made, not yet credited
+			if p := m.Stats()[0].Probability; p != 0.5 {
+				t.Fatalf("probability %v before any credit, want 0.5", p)
+			}
+			r.generation(m)
+			stats := m.Stats()
+			if math.Abs(stats[0].Probability-tt.first) > 1e-12 || math.Abs(stats[0].Probability+stats[1].Probability-1) > 1e-12 {
+				t.Errorf("probabilities %v and %v after one generation, want %v for the improving operator",
+					stats[0].Probability, stats[1].Probability, tt.first)
+			}
+			if stats[1].Quality != 0 {
+				t.Errorf("quality %v of an operator that never improved", stats[1].Quality)
+			}
+			for range 9 {
+				r.generation(m)
+			}
+			if p := m.Stats()[0].Probability; math.Abs(p-tt.last) > 1e-12 {
+				t.Errorf("probability %v after ten generations, want %v", p, tt.last)
+			}
+		})
+	}
+}
+
+func TestUCB(t *testing.T) {
+	m, err := NewMutation([]string{"shrink", "grow"}, []problems.MutationFunc{scale(0.5), scale(2)}, Params{Strategy: UCB, Exploration: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newRun(m, 20)
+	for range 10 {
+		r.generation(m)
+	}
+	stats := m.Stats()
+	if stats[0].Quality != 1 || stats[1].Quality != 0 {
+		t.Errorf("mean credits %v and %v, want 1 and 0", stats[0].Quality, stats[1].Quality)
+	}
+	if stats[1].Probability > 0.15 || math.Abs(stats[0].Probability+stats[1].Probability-1) > 1e-12 {
+		t.Errorf("shares of picks %v and %v, want the improving operator to dominate", stats[0].Probability, stats[1].Probability)
+	}
+}
+
+// counted is a point that counts its evaluations.
+type counted struct {
+	*testutil.SphereSolution
+	evaluations *int
+}
+
+func (c counted) Fitness() float64 {
+	*c.evaluations++
+	return c.SphereSolution.Fitness()
+}
+
+func TestCreditWithoutEvaluation(t *testing.T) {
+	// the child of a parent from outside the population, e.g. of crossover,
+	// is credited against the median fitness of the population: 1, 4 and 9
+	var evaluations int
+	shrink := func(rng *rand.Rand, individual problems.Solution) problems.Solution {
+		return &testutil.SphereSolution{X: []float64{1.5}}
+	}
+	m, err := NewMutation([]string{"shrink"}, []problems.MutationFunc{shrink}, Params{Adaptation: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newRun(m, 3)
+	parent := counted{&testutil.SphereSolution{X: []float64{100}}, &evaluations}
+	m.Mutate(r.rng, parent)
+	m.Mutate(r.rng, r.pop[0])
+	r.ga.NotifyObservers()
+	r.ga.NotifyObservers()
+	if evaluations != 0 {
+		t.Errorf("the parent from outside the population was evaluated %d times", evaluations)
+	}
+	// 2.25 beats the median 4 but not the member parent 1
+	if q := m.Stats()[0].Quality; q != 0.5 {
+		t.Errorf("quality %v, want 0.5", q)
+	}
+}
+
+func TestClone(t *testing.T) {
+	m, err := NewMutation([]string{"shrink", "grow"}, []problems.MutationFunc{scale(0.5), scale(2)}, Params{Strategy: ProbabilityMatching, MinProbability: 0.05, Adaptation: 0.3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := newRun(m, 20)
+	r.generation(m)
+	r.generation(m)
+	clone, ok := m.Clone().(*Mutation)
+	if !ok {
+		t.Fatalf("Clone() returned %T", m.Clone())
+	}
+	for i, s := range clone.Stats() {
+		if s.Probability != 0.5 || s.Quality != 0 || s.Name != m.names[i] {
+			t.Errorf("clone starts with %+v", s)
+		}
+	}
+	if m.Stats()[0].Probability == 0.5 {
+		t.Error("the original lost its state")
+	}
+}
@@ -0,0 +1,73 @@
+package adaptive
+
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Strategy is the rule turning the credit of the operators into the way the
+// next one is picked.
+type Strategy int
+
+const (
+	// ProbabilityMatching picks operators with probabilities proportional to
+	// their quality, but never below MinProbability.
+	ProbabilityMatching Strategy = iota
+	// AdaptivePursuit moves the probability of the best operator towards
+	// 1 - (K-1)·MinProbability and those of the others towards MinProbability.
+	AdaptivePursuit
+	// UCB picks the operator with the highest upper confidence bound of its
+	// mean credit (the UCB1 multi-armed bandit).
+	UCB
+)
+
+// StrategyByName resolves "probability-matching", "adaptive-pursuit" and "ucb".
+func StrategyByName(name string) (Strategy, error) {
+	switch name {
+	case "probability-matching":
+		return ProbabilityMatching, nil
+	case "adaptive-pursuit":
+		return AdaptivePursuit, nil
+	case "ucb":
+		return UCB, nil
+	}
+	return 0, fmt.Errorf("unknown operator selection strategy %q", name)
+}
+
+// Params holds configurable parameters for adaptive operator selection.
+type Params struct {
+	Strategy Strategy
+	// MinProbability keeps every operator in use (probability matching and
+	// adaptive pursuit); it must be below 1/K for K operators.
+	MinProbability float64
+	// Adaptation is the rate at which the quality follows the latest credit
+	// (probability matching and adaptive pursuit).
+	Adaptation float64
+	// Learning is the rate at which adaptive pursuit moves the probabilities.
+	Learning float64
+	// Exploration scales the confidence term of UCB.
+	Exploration float64
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	strategy, err := m.String("strategy", "adaptive-pursuit")
+	if err != nil {
+		return
+	}
+	if params.Strategy, err = StrategyByName(strategy); err != nil {
+		return
+	}
+	if params.MinProbability, err = m.Float("p_min", 0.05); err != nil {
+		return
+	}
+	if params.Adaptation, err = m.Float("alpha", 0.3); err != nil {
+		return
+	}
+	if params.Learning, err = m.Float("beta", 0.3); err != nil {
+		return
+	}
+	params.Exploration, err = m.Float("c", 0.5)
+	return
+}
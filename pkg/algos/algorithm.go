@@ -0,0 +1,32 @@
+package algos
+
+import (
+	"context"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Algorithm is the method set shared by every genetic algorithm in the library,
+// so harness code can drive any of them without knowing the concrete type.
+type Algorithm interface {
+	// Run evolves the population until the generation limit is reached or ctx is done.
+	Run(ctx context.Context)
+	// Step performs a single generation, initializing the population first if needed.
+	Step()
+	// Seed fills the population with mutants of seedSolution (keeping the seed itself).
+	Seed(seedSolution problems.Solution)
+	// SetPopulation replaces the current population.
+	SetPopulation(pop []problems.Solution)
+	// GetPopulation returns a copy of the current population.
+	GetPopulation() []problems.Solution
+	GetSolution() problems.Solution
+	GetSteps() int
+	// SetTermination installs a criterion that can stop Run before the generation limit.
+	SetTermination(criterion Criterion)
+	// SetIndicators makes every logged step record quality indicators of the Pareto front.
+	SetIndicators(indicators IndicatorFunc)
+	// AddObserver subscribes o to the run progress.
+	AddObserver(o Observer)
+	// Err returns the first error met while logging; such an error stops the run.
+	Err() error
+}
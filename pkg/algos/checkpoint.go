@@ -0,0 +1,154 @@
+package algos
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Checkpoint is the serialized state of an algorithm, enough to resume its run
+// exactly as long as everything else about the run is stateless. It does not
+// hold the state of stateful operators (adaptive.Mutation, the operators of
+// package schedule), termination criteria or other observers: they start
+// afresh on resume, so an adaptive run continues with uniform operator
+// probabilities and a stagnation criterion counts its window again.
+type Checkpoint struct {
+	Algorithm   string            `json:"algorithm"`
+	Problem     string            `json:"problem"`
+	Generation  int               `json:"generation"`
+	Evaluations int               `json:"evaluations"`
+	Elapsed     time.Duration     `json:"elapsed"`
+	Seed        uint64            `json:"seed"`
+	RandState   []byte            `json:"rand_state"`
+	Solution    json.RawMessage   `json:"solution"`
+	Population  []json.RawMessage `json:"population"`
+	// State holds algorithm specific data (ranks, archive, ...).
+	State json.RawMessage `json:"state,omitempty"`
+}
+
+// Checkpointer is implemented by algorithms that can save and restore their state.
+type Checkpointer interface {
+	Checkpoint() (*Checkpoint, error)
+	Restore(cp *Checkpoint) error
+}
+
+// SaveCheckpoint writes the state of alg to path.
+// The file is replaced atomically, so a crash while saving keeps the previous checkpoint.
+func SaveCheckpoint(path string, alg Checkpointer) error {
+	cp, err := alg.Checkpoint()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return fmt.Errorf("encoding checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadCheckpoint restores alg from the checkpoint stored at path.
+func LoadCheckpoint(path string, alg Checkpointer) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return fmt.Errorf("decoding checkpoint: %w", err)
+	}
+	return alg.Restore(cp)
+}
+
+// NewCheckpoint captures the state shared by all algorithms: counters, RNG, elapsed time, best solution and population.
+func (ga *GeneticAlgorithm) NewCheckpoint(algorithm string, population []problems.Solution) (*Checkpoint, error) {
+	randState, err := ga.source.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	cp := &Checkpoint{
+		Algorithm:   algorithm,
+		Problem:     ga.Problem.Name(),
+		Generation:  ga.Generation,
+		Evaluations: ga.Evaluations,
+		Elapsed:     time.Since(ga.StartTimestamp),
+		Seed:        ga.RandSeed,
+		RandState:   randState,
+		Population:  make([]json.RawMessage, len(population)),
+	}
+	if cp.Solution, err = json.Marshal(ga.Solution); err != nil {
+		return nil, fmt.Errorf("encoding best solution: %w", err)
+	}
+	for i, sol := range population {
+		if cp.Population[i], err = json.Marshal(sol); err != nil {
+			return nil, fmt.Errorf("encoding individual %d: %w", i, err)
+		}
+	}
+	return cp, nil
+}
+
+// RestoreCheckpoint restores the shared state from cp and returns the decoded population.
+// The problem must implement problems.SolutionDecoder.
+func (ga *GeneticAlgorithm) RestoreCheckpoint(algorithm string, cp *Checkpoint) ([]problems.Solution, error) {
+	if cp.Algorithm != algorithm {
+		return nil, fmt.Errorf("checkpoint was made by %s, not %s", cp.Algorithm, algorithm)
+	}
+	if cp.Problem != ga.Problem.Name() {
+		return nil, fmt.Errorf("checkpoint is for problem %s, not %s", cp.Problem, ga.Problem.Name())
+	}
+	best, err := ga.DecodeSolution(cp.Solution)
+	if err != nil {
+		return nil, fmt.Errorf("decoding best solution: %w", err)
+	}
+	population, err := ga.DecodeSolutions(cp.Population)
+	if err != nil {
+		return nil, err
+	}
+	if err := ga.source.UnmarshalBinary(cp.RandState); err != nil {
+		return nil, fmt.Errorf("restoring RNG: %w", err)
+	}
+	ga.RandSeed = cp.Seed
+	ga.Generation = cp.Generation
+	ga.Evaluations = cp.Evaluations
+	ga.Solution = best
+	ga.StartTimestamp = time.Now().Add(-cp.Elapsed)
+	return population, nil
+}
+
+// DecodeSolution rehydrates a serialized solution of the algorithm's problem.
+func (ga *GeneticAlgorithm) DecodeSolution(data []byte) (problems.Solution, error) {
+	decoder, ok := ga.Problem.(problems.SolutionDecoder)
+	if !ok {
+		return nil, fmt.Errorf("problem %s cannot decode solutions", ga.Problem.Name())
+	}
+	return decoder.DecodeSolution(data)
+}
+
+// DecodeSolutions rehydrates a list of serialized solutions.
+func (ga *GeneticAlgorithm) DecodeSolutions(data []json.RawMessage) ([]problems.Solution, error) {
+	sols := make([]problems.Solution, len(data))
+	for i, raw := range data {
+		sol, err := ga.DecodeSolution(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decoding individual %d: %w", i, err)
+		}
+		sols[i] = sol
+	}
+	return sols, nil
+}
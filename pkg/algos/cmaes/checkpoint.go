@@ -0,0 +1,97 @@
+package cmaes
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	Lambda        int         `json:"lambda"`
+	Sigma0        float64     `json:"sigma0"`
+	Small         bool        `json:"small,omitempty"`
+	Mean          []float64   `json:"mean"`
+	Sigma         float64     `json:"sigma"`
+	Covariance    [][]float64 `json:"covariance"`
+	PC            []float64   `json:"pc"`
+	PS            []float64   `json:"ps"`
+	RunGeneration int         `json:"run_generation"`
+	History       []float64   `json:"history"`
+	Restarts      int         `json:"restarts"`
+	LargeLambda   int         `json:"large_lambda"`
+	EvalsLarge    int         `json:"evals_large"`
+	EvalsSmall    int         `json:"evals_small"`
+	LoggedFitness float64     `json:"logged_fitness"`
+}
+
+// Checkpoint captures the full state of the run: the search distribution, the
+// restart bookkeeping and the latest generation of samples.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("cmaes", alg.population)
+	if err != nil {
+		return nil, err
+	}
+	r := alg.run
+	state := checkpointState{
+		Lambda: r.lambda, Sigma0: r.sigma0, Small: r.small, Mean: r.mean, Sigma: r.sigma,
+		PC: r.pc, PS: r.ps, RunGeneration: r.generation, History: r.history,
+		Restarts: alg.restarts, LargeLambda: alg.largeLambda,
+		EvalsLarge: alg.evalsLarge, EvalsSmall: alg.evalsSmall, LoggedFitness: alg.loggedFitness,
+	}
+	if r.cov != nil {
+		n := r.cov.SymmetricDim()
+		state.Covariance = make([][]float64, n)
+		for i := range n {
+			state.Covariance[i] = make([]float64, n)
+			for j := range n {
+				state.Covariance[i][j] = r.cov.At(i, j)
+			}
+		}
+	}
+	cp.State, err = json.Marshal(state)
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("cmaes", cp)
+	if err != nil {
+		return err
+	}
+	var state checkpointState
+	if err := json.Unmarshal(cp.State, &state); err != nil {
+		return fmt.Errorf("decoding cmaes state: %w", err)
+	}
+	alg.population = pop
+	alg.restarts = state.Restarts
+	alg.largeLambda = state.LargeLambda
+	alg.evalsLarge, alg.evalsSmall = state.EvalsLarge, state.EvalsSmall
+	alg.loggedFitness = state.LoggedFitness
+	if state.Mean == nil {
+		// saved before the first step
+		alg.run = run{}
+		return nil
+	}
+
+	n := len(alg.lower)
+	if len(state.Mean) != n || len(state.PC) != n || len(state.PS) != n || len(state.Covariance) != n {
+		return fmt.Errorf("checkpoint distribution does not have %d dimensions", n)
+	}
+	cov := mat.NewSymDense(n, nil)
+	for i, row := range state.Covariance {
+		if len(row) != n {
+			return fmt.Errorf("checkpoint covariance row %d has %d entries, expected %d", i, len(row), n)
+		}
+		for j := i; j < n; j++ {
+			cov.SetSym(i, j, row[j])
+		}
+	}
+	alg.run = run{
+		lambda: state.Lambda, sigma0: state.Sigma0, small: state.Small, mean: state.Mean, sigma: state.Sigma,
+		cov: cov, pc: state.PC, ps: state.PS, generation: state.RunGeneration, history: state.History,
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+package cmaes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("cmaes", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := problem.RandomSolution(problems.NewRand(1)).(problems.RealVector); !ok {
+			return nil, fmt.Errorf("cmaes needs real vector solutions, %s does not have them", problem.Name())
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements the covariance matrix adaptation evolution strategy
+// (Hansen's (μ/μ_w, λ)-CMA-ES) for single-objective problems, minimizing Fitness.
+// It searches in coordinates scaled so that the bounds of every variable map to
+// [0, 1]; samples outside the bounds are clipped.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params        Params
+	template      problems.RealVector
+	lower, width  []float64
+	defaultLambda int
+	run           run
+	restarts      int
+	// BIPOP bookkeeping: the population size of the large regime and the
+	// evaluations spent in each regime
+	largeLambda            int
+	evalsLarge, evalsSmall int
+	population             []problems.Solution
+	loggedFitness          float64
+}
+
+// run is the state of one (re)start.
+type run struct {
+	lambda     int
+	sigma0     float64
+	small      bool // a small-population BIPOP run
+	mean       []float64
+	sigma      float64
+	cov        *mat.SymDense
+	pc, ps     []float64
+	generation int
+	history    []float64 // best fitness of the latest generations
+}
+
+// NewAlgorithm creates a new CMA-ES instance. The problem's solutions must
+// implement problems.RealVector.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	template, ok := alg.Solution.(problems.RealVector)
+	if !ok {
+		panic("cmaes: solutions of " + problem.Name() + " are not real vectors")
+	}
+	alg.template = template
+	lower, upper := template.Bounds()
+	alg.lower = lower
+	alg.width = make([]float64, len(lower))
+	for i := range lower {
+		alg.width[i] = upper[i] - lower[i]
+	}
+	n := float64(len(lower))
+	alg.defaultLambda = params.PopulationSize
+	if alg.defaultLambda <= 0 {
+		alg.defaultLambda = 4 + int(3*math.Log(n))
+	}
+	alg.largeLambda = alg.defaultLambda
+	if alg.params.Sigma <= 0 {
+		alg.params.Sigma = 0.3
+	}
+	if alg.params.IncPopSize <= 1 {
+		alg.params.IncPopSize = 2
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Seed centres the search distribution on seedSolution.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.start(alg.defaultLambda, alg.params.Sigma, alg.normalized(seedSolution), false)
+	alg.population = []problems.Solution{seedSolution}
+	alg.Solution = seedSolution
+}
+
+// SetPopulation centres the search distribution on the better half of pop,
+// which may have any size.
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) == 0 {
+		panic("Wrong population size")
+	}
+	alg.Evaluator.Evaluate(pop)
+	sorted := append([]problems.Solution(nil), pop...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Fitness() < sorted[j].Fitness() })
+	best := sorted[:(len(sorted)+1)/2]
+	mean := make([]float64, len(alg.lower))
+	for _, sol := range best {
+		for i, v := range alg.normalized(sol) {
+			mean[i] += v / float64(len(best))
+		}
+	}
+	alg.start(alg.defaultLambda, alg.params.Sigma, mean, false)
+	alg.population = append([]problems.Solution(nil), pop...)
+	if algos.Better(sorted[0], alg.Solution) {
+		alg.Solution = sorted[0]
+	}
+}
+
+// GetPopulation returns the latest generation of samples.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes CMA-ES until timeout, or until it converges without restarts.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step samples and evaluates one generation, updates the search distribution and
+// restarts it if it has converged. The best solution is logged when it changes.
+func (alg *Algorithm) Step() {
+	if alg.run.mean == nil {
+		alg.start(alg.defaultLambda, alg.params.Sigma, alg.randomMean(), false)
+	}
+	alg.Generation++
+	alg.run.generation++
+
+	b, d := alg.eigen()
+	n := len(alg.lower)
+	samples := make([][]float64, alg.run.lambda)
+	alg.population = make([]problems.Solution, alg.run.lambda)
+	for k := range samples {
+		z := make([]float64, n)
+		for i := range z {
+			z[i] = d[i] * alg.Rand.NormFloat64()
+		}
+		x := make([]float64, n)
+		for i := range x {
+			y := 0.0
+			for j := range z {
+				y += b.At(i, j) * z[j]
+			}
+			x[i] = min(max(alg.run.mean[i]+alg.run.sigma*y, 0), 1)
+		}
+		samples[k] = x
+		alg.population[k] = alg.denormalized(x)
+	}
+	alg.Evaluator.Evaluate(alg.population)
+	alg.Evaluations += len(samples)
+	if alg.run.small {
+		alg.evalsSmall += len(samples)
+	} else {
+		alg.evalsLarge += len(samples)
+	}
+
+	order := make([]int, len(samples))
+	for k := range order {
+		order[k] = k
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return alg.population[order[i]].Fitness() < alg.population[order[j]].Fitness()
+	})
+	if best := alg.population[order[0]]; algos.Better(best, alg.Solution) {
+		alg.Solution = best
+	}
+
+	alg.update(samples, order, b, d)
+	if reason := alg.converged(order); reason != "" {
+		alg.restart(reason)
+	}
+
+	if bestFitness := alg.Solution.Fitness(); alg.loggedFitness != bestFitness {
+		alg.loggedFitness = bestFitness
+		alg.LogProgress(algos.GAStep{
+			Elapsed: time.Since(alg.StartTimestamp), Seed: alg.RandSeed, Solution: alg.Solution, Step: alg.Generation,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// strategy holds the constants of the update for a population size.
+type strategy struct {
+	weights                   []float64
+	mueff                     float64
+	cs, ds, cc, c1, cmu, chiN float64
+}
+
+func newStrategy(lambda, dimensions int) strategy {
+	n := float64(dimensions)
+	mu := lambda / 2
+	s := strategy{weights: make([]float64, mu)}
+	sum, sumSq := 0.0, 0.0
+	for i := range s.weights {
+		s.weights[i] = math.Log(float64(mu)+0.5) - math.Log(float64(i+1))
+		sum += s.weights[i]
+	}
+	for i := range s.weights {
+		s.weights[i] /= sum
+		sumSq += s.weights[i] * s.weights[i]
+	}
+	s.mueff = 1 / sumSq
+	s.cs = (s.mueff + 2) / (n + s.mueff + 5)
+	s.ds = 1 + 2*max(0, math.Sqrt((s.mueff-1)/(n+1))-1) + s.cs
+	s.cc = (4 + s.mueff/n) / (n + 4 + 2*s.mueff/n)
+	s.c1 = 2 / ((n+1.3)*(n+1.3) + s.mueff)
+	s.cmu = min(1-s.c1, 2*(s.mueff-2+1/s.mueff)/((n+2)*(n+2)+s.mueff))
+	s.chiN = math.Sqrt(n) * (1 - 1/(4*n) + 1/(21*n*n))
+	return s
+}
+
+// update moves the mean towards the best samples and adapts the evolution
+// paths, the covariance matrix and the step size.
+func (alg *Algorithm) update(samples [][]float64, order []int, b *mat.Dense, d []float64) {
+	r := &alg.run
+	n := len(r.mean)
+	s := newStrategy(r.lambda, n)
+
+	old := append([]float64(nil), r.mean...)
+	ys := make([][]float64, len(s.weights))
+	yw := make([]float64, n)
+	for k, w := range s.weights {
+		x := samples[order[k]]
+		ys[k] = make([]float64, n)
+		for i := range x {
+			ys[k][i] = (x[i] - old[i]) / r.sigma
+			yw[i] += w * ys[k][i]
+		}
+	}
+	for i := range r.mean {
+		r.mean[i] = old[i] + r.sigma*yw[i]
+	}
+
+	// C^(-1/2) yw = B D^-1 Bᵀ yw
+	invSqrt := make([]float64, n)
+	for j := range n {
+		proj := 0.0
+		for i := range n {
+			proj += b.At(i, j) * yw[i]
+		}
+		proj /= d[j]
+		for i := range n {
+			invSqrt[i] += b.At(i, j) * proj
+		}
+	}
+	psNorm := 0.0
+	for i := range r.ps {
+		r.ps[i] = (1-s.cs)*r.ps[i] + math.Sqrt(s.cs*(2-s.cs)*s.mueff)*invSqrt[i]
+		psNorm += r.ps[i] * r.ps[i]
+	}
+	psNorm = math.Sqrt(psNorm)
+	hsig := 0.0
+	if psNorm/math.Sqrt(1-math.Pow(1-s.cs, 2*float64(r.generation))) < (1.4+2/(float64(n)+1))*s.chiN {
+		hsig = 1
+	}
+	for i := range r.pc {
+		r.pc[i] = (1-s.cc)*r.pc[i] + hsig*math.Sqrt(s.cc*(2-s.cc)*s.mueff)*yw[i]
+	}
+
+	for i := range n {
+		for j := i; j < n; j++ {
+			rankMu := 0.0
+			for k, w := range s.weights {
+				rankMu += w * ys[k][i] * ys[k][j]
+			}
+			c := r.cov.At(i, j)
+			c = (1-s.c1-s.cmu)*c +
+				s.c1*(r.pc[i]*r.pc[j]+(1-hsig)*s.cc*(2-s.cc)*c) +
+				s.cmu*rankMu
+			r.cov.SetSym(i, j, c)
+		}
+	}
+	r.sigma *= math.Exp(s.cs / s.ds * (psNorm/s.chiN - 1))
+}
+
+// converged returns why the current run cannot make further progress, if it cannot.
+func (alg *Algorithm) converged(order []int) string {
+	r := &alg.run
+	r.history = append(r.history, alg.population[order[0]].Fitness())
+	window := 10 + int(math.Ceil(30*float64(len(r.mean))/float64(r.lambda)))
+	if len(r.history) > window {
+		r.history = r.history[len(r.history)-window:]
+	}
+
+	if math.IsNaN(r.sigma) || math.IsInf(r.sigma, 0) || r.sigma <= 0 {
+		return "step size degenerated"
+	}
+	_, d := alg.eigen()
+	minD, maxD := math.Inf(1), 0.0
+	for _, v := range d {
+		minD, maxD = min(minD, v), max(maxD, v)
+	}
+	if maxD*maxD > 1e14*minD*minD {
+		return "covariance matrix ill-conditioned"
+	}
+	spread := 0.0
+	for i := range r.mean {
+		spread = max(spread, math.Sqrt(r.cov.At(i, i)), math.Abs(r.pc[i]))
+	}
+	if r.sigma*spread < 1e-12*r.sigma0 {
+		return "step size below tolerance"
+	}
+	if len(r.history) == window {
+		lo, hi := fitnessRange(r.history)
+		genLo := alg.population[order[0]].Fitness()
+		genHi := alg.population[order[len(order)-1]].Fitness()
+		if hi-lo < 1e-12 && genHi-genLo < 1e-12 {
+			return "fitness stagnated"
+		}
+	}
+	return ""
+}
+
+// restart begins a new run according to the restart strategy, or stops the algorithm.
+func (alg *Algorithm) restart(reason string) {
+	switch alg.params.Restarts {
+	case NoRestarts:
+		alg.RequestStop("converged: " + reason)
+		return
+	case IPOP:
+		alg.largeLambda = int(math.Round(float64(alg.largeLambda) * alg.params.IncPopSize))
+		alg.start(alg.largeLambda, alg.params.Sigma, alg.randomMean(), false)
+	case BIPOP:
+		if alg.evalsSmall < alg.evalsLarge {
+			u := alg.Rand.Float64()
+			ratio := 0.5 * float64(alg.largeLambda) / float64(alg.defaultLambda)
+			lambda := max(int(float64(alg.defaultLambda)*math.Pow(ratio, u*u)), 2)
+			alg.start(lambda, alg.params.Sigma*math.Pow(10, -2*u), alg.randomMean(), true)
+		} else {
+			alg.largeLambda = int(math.Round(float64(alg.largeLambda) * alg.params.IncPopSize))
+			alg.start(alg.largeLambda, alg.params.Sigma, alg.randomMean(), false)
+		}
+	}
+	alg.restarts++
+}
+
+// Restarts returns the number of restarts so far.
+func (alg *Algorithm) Restarts() int {
+	return alg.restarts
+}
+
+func (alg *Algorithm) start(lambda int, sigma float64, mean []float64, small bool) {
+	n := len(alg.lower)
+	cov := mat.NewSymDense(n, nil)
+	for i := range n {
+		cov.SetSym(i, i, 1)
+	}
+	alg.run = run{
+		lambda: max(lambda, 2),
+		sigma0: sigma,
+		small:  small,
+		mean:   mean,
+		sigma:  sigma,
+		cov:    cov,
+		pc:     make([]float64, n),
+		ps:     make([]float64, n),
+	}
+}
+
+// eigen decomposes the covariance matrix into B diag(d)² Bᵀ.
+func (alg *Algorithm) eigen() (*mat.Dense, []float64) {
+	var es mat.EigenSym
+	n := len(alg.lower)
+	b := mat.NewDense(n, n, nil)
+	d := make([]float64, n)
+	if !es.Factorize(alg.run.cov, true) {
+		for i := range n {
+			b.Set(i, i, 1)
+			d[i] = 1
+		}
+		return b, d
+	}
+	es.VectorsTo(b)
+	for i, v := range es.Values(nil) {
+		d[i] = math.Sqrt(max(v, 1e-30))
+	}
+	return b, d
+}
+
+func (alg *Algorithm) randomMean() []float64 {
+	mean := make([]float64, len(alg.lower))
+	for i := range mean {
+		mean[i] = alg.Rand.Float64()
+	}
+	return mean
+}
+
+// normalized maps the variables of sol to [0, 1].
+func (alg *Algorithm) normalized(sol problems.Solution) []float64 {
+	v, ok := sol.(problems.RealVector)
+	if !ok {
+		panic("cmaes: solution is not a real vector")
+	}
+	x := make([]float64, len(alg.lower))
+	for i, value := range v.Vector() {
+		if alg.width[i] > 0 {
+			x[i] = (value - alg.lower[i]) / alg.width[i]
+		}
+	}
+	return x
+}
+
+func (alg *Algorithm) denormalized(x []float64) problems.Solution {
+	v := make([]float64, len(x))
+	for i := range x {
+		v[i] = alg.lower[i] + x[i]*alg.width[i]
+	}
+	return alg.template.WithVector(v)
+}
+
+func fitnessRange(values []float64) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi
+}
@@ -0,0 +1,69 @@
+package cmaes
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+)
+
+func TestSphere(t *testing.T) {
+	tests := []struct {
+		name        string
+		dimensions  int
+		restarts    RestartStrategy
+		generations int
+	}{
+		{"2 variables", 2, NoRestarts, 1500},
+		{"10 variables", 10, NoRestarts, 1500},
+		{"5 variables, IPOP", 5, IPOP, 500},
+		{"5 variables, BIPOP", 5, BIPOP, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alg := NewAlgorithm(testutil.Sphere{Dimensions: tt.dimensions}, Params{Restarts: tt.restarts, Seed: 7}, tt.generations, nil)
+			alg.Run(context.Background())
+			if best := alg.GetSolution().Fitness(); best > 1e-10 {
+				t.Errorf("best fitness %v after %d generations, want below 1e-10", best, alg.GetSteps())
+			}
+			if tt.restarts == NoRestarts && !strings.HasPrefix(alg.StopReason, "converged") {
+				t.Errorf("stopped with %q, want convergence", alg.StopReason)
+			}
+			if tt.restarts != NoRestarts && alg.Restarts() == 0 {
+				t.Errorf("no restart in %d generations", alg.GetSteps())
+			}
+		})
+	}
+}
+
+func TestSeedCentresTheSearch(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 3}, Params{Seed: 1}, 1, nil)
+	alg.Seed(&testutil.SphereSolution{X: []float64{-5, 0, 5}})
+	want := []float64{0, 0.5, 1}
+	for i, v := range alg.run.mean {
+		if math.Abs(v-want[i]) > 1e-12 {
+			t.Fatalf("mean %v, want %v", alg.run.mean, want)
+		}
+	}
+}
+
+func TestStrategy(t *testing.T) {
+	for _, lambda := range []int{6, 10, 40} {
+		s := newStrategy(lambda, 5)
+		sum := 0.0
+		for i, w := range s.weights {
+			sum += w
+			if i > 0 && w >= s.weights[i-1] {
+				t.Errorf("λ = %d: weights %v are not decreasing", lambda, s.weights)
+			}
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("λ = %d: weights sum to %v", lambda, sum)
+		}
+		if s.mueff < 1 || s.mueff > float64(len(s.weights)) {
+			t.Errorf("λ = %d: μ_eff = %v outside [1, %d]", lambda, s.mueff, len(s.weights))
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package cmaes
+
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// RestartStrategy decides what happens when a run of CMA-ES converges.
+type RestartStrategy int
+
+const (
+	// NoRestarts stops the algorithm once it has converged.
+	NoRestarts RestartStrategy = iota
+	// IPOP restarts from a random point with the population size multiplied by IncPopSize.
+	IPOP
+	// BIPOP alternates IPOP restarts with restarts using small populations and
+	// step sizes, giving both regimes a similar share of the evaluations.
+	BIPOP
+)
+
+// RestartStrategyByName resolves "none", "ipop" and "bipop".
+func RestartStrategyByName(name string) (RestartStrategy, error) {
+	switch name {
+	case "none":
+		return NoRestarts, nil
+	case "ipop":
+		return IPOP, nil
+	case "bipop":
+		return BIPOP, nil
+	}
+	return 0, fmt.Errorf("unknown restart strategy %q", name)
+}
+
+// Params holds configurable parameters for CMA-ES.
+type Params struct {
+	// PopulationSize (λ) of 0 picks the default 4 + ⌊3 ln n⌋ for n variables.
+	PopulationSize int
+	// Sigma is the initial step size as a fraction of the width of the bounds.
+	Sigma      float64
+	Restarts   RestartStrategy
+	IncPopSize float64 // population growth factor of IPOP and BIPOP restarts
+	Seed       uint64  // seed of the run RNG; 0 picks a random seed
+	Workers    int     // goroutines evaluating offspring; 0 or 1 evaluates serially
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 0); err != nil {
+		return
+	}
+	if params.Sigma, err = m.Float("sigma", 0.3); err != nil {
+		return
+	}
+	restarts, err := m.String("restarts", "none")
+	if err != nil {
+		return
+	}
+	if params.Restarts, err = RestartStrategyByName(restarts); err != nil {
+		return
+	}
+	if params.IncPopSize, err = m.Float("inc_pop_size", 2); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
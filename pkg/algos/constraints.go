@@ -0,0 +1,215 @@
+package algos
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// ConstraintHandling selects how an algorithm ranks solutions that may violate
+// constraints (see problems.ConstrainedSolution). Unconstrained solutions are
+// always feasible, and every method then reduces to the plain comparisons.
+type ConstraintHandling int
+
+const (
+	// ConstrainedDomination applies Deb's feasibility rules: a feasible solution
+	// beats an infeasible one, infeasible solutions are ranked by their total
+	// violation and feasible ones by fitness or Pareto dominance.
+	ConstrainedDomination ConstraintHandling = iota
+	// Penalty adds the total violation times PenaltyWeight to the fitness and to
+	// every objective.
+	Penalty
+	// StochasticRanking (Runarsson and Yao, 2000) compares a pair with an
+	// infeasible solution on fitness with probability RankingProbability and on
+	// violation otherwise.
+	StochasticRanking
+)
+
+// ConstraintHandlingByName resolves "domination", "penalty" and "stochastic-ranking".
+func ConstraintHandlingByName(name string) (ConstraintHandling, error) {
+	switch name {
+	case "domination":
+		return ConstrainedDomination, nil
+	case "penalty":
+		return Penalty, nil
+	case "stochastic-ranking":
+		return StochasticRanking, nil
+	}
+	return 0, fmt.Errorf("unknown constraint handling %q", name)
+}
+
+// Constraints compares solutions under a constraint handling method. The zero
+// value applies constrained domination.
+type Constraints struct {
+	Handling           ConstraintHandling
+	PenaltyWeight      float64
+	RankingProbability float64
+	// RankingSweeps bounds the bubble-sort sweeps of stochastic ranking; 0 allows
+	// as many as there are solutions, the bound of Runarsson and Yao.
+	RankingSweeps int
+}
+
+// ConstraintsFromMap reads "constraints" (default "domination"), "penalty_weight"
+// (default 1), "ranking_probability" (default 0.45) and "ranking_sweeps"
+// (default 0) from a registry parameter map.
+func ConstraintsFromMap(m ParamMap) (c Constraints, err error) {
+	handling, err := m.String("constraints", "domination")
+	if err != nil {
+		return
+	}
+	if c.Handling, err = ConstraintHandlingByName(handling); err != nil {
+		return
+	}
+	if c.PenaltyWeight, err = m.Float("penalty_weight", 1); err != nil {
+		return
+	}
+	if c.RankingProbability, err = m.Float("ranking_probability", 0.45); err != nil {
+		return
+	}
+	if c.RankingSweeps, err = m.Int("ranking_sweeps", 0); err != nil {
+		return
+	}
+	return
+}
+
+// Fitness returns the fitness of sol, penalized under the Penalty method.
+func (c Constraints) Fitness(sol problems.Solution) float64 {
+	if c.Handling == Penalty {
+		return sol.Fitness() + c.PenaltyWeight*problems.Violation(sol)
+	}
+	return sol.Fitness()
+}
+
+// Objectives returns the objectives of sol, penalized under the Penalty method.
+func (c Constraints) Objectives(sol problems.Solution) []float64 {
+	objectives := sol.Objectives()
+	if c.Handling != Penalty {
+		return objectives
+	}
+	penalty := c.PenaltyWeight * problems.Violation(sol)
+	if penalty == 0 {
+		return objectives
+	}
+	penalized := make([]float64, len(objectives))
+	for i, f := range objectives {
+		penalized[i] = f + penalty
+	}
+	return penalized
+}
+
+// Less reports whether a ranks before b in a single-objective comparison.
+// Stochastic ranking draws from rng when one of them is infeasible.
+func (c Constraints) Less(rng *rand.Rand, a, b problems.Solution) bool {
+	switch c.Handling {
+	case Penalty:
+		return c.Fitness(a) < c.Fitness(b)
+	case StochasticRanking:
+		va, vb := problems.Violation(a), problems.Violation(b)
+		if (va == 0 && vb == 0) || rng.Float64() < c.RankingProbability {
+			return a.Fitness() < b.Fitness()
+		}
+		return va < vb
+	}
+	return Better(a, b)
+}
+
+// Sort orders pop from the best to the worst solution. Stochastic ranking runs
+// the bubble-sort sweeps of Runarsson and Yao, comparing adjacent solutions with
+// Less until a sweep swaps nothing or RankingSweeps sweeps are done, which costs
+// O(n²) comparisons at worst, so algorithms rank once per generation. Without
+// infeasible solutions the comparisons are deterministic and the sweeps reduce
+// to a stable sort by fitness; the other methods sort deterministically as well.
+func (c Constraints) Sort(rng *rand.Rand, pop []problems.Solution) {
+	if c.Handling != StochasticRanking {
+		sort.Slice(pop, func(i, j int) bool {
+			return c.Less(nil, pop[i], pop[j])
+		})
+		return
+	}
+	if !slices.ContainsFunc(pop, func(sol problems.Solution) bool { return problems.Violation(sol) > 0 }) {
+		sort.SliceStable(pop, func(i, j int) bool {
+			return pop[i].Fitness() < pop[j].Fitness()
+		})
+		return
+	}
+	sweeps := c.RankingSweeps
+	if sweeps <= 0 {
+		sweeps = len(pop)
+	}
+	for range sweeps {
+		swapped := false
+		for j := 0; j+1 < len(pop); j++ {
+			if c.Less(rng, pop[j+1], pop[j]) {
+				pop[j], pop[j+1] = pop[j+1], pop[j]
+				swapped = true
+			}
+		}
+		if !swapped {
+			break
+		}
+	}
+}
+
+// Dominance returns the dominance relation of one generation of a
+// multi-objective algorithm. A relation must stay a strict partial order while
+// solutions are sorted into fronts, so stochastic ranking draws once per
+// generation: with probability RankingProbability constraints are ignored and
+// solutions compared by Pareto dominance, otherwise constrained domination applies.
+func (c Constraints) Dominance(rng *rand.Rand) func(a, b problems.Solution) bool {
+	switch c.Handling {
+	case Penalty:
+		return func(a, b problems.Solution) bool {
+			return paretoDominates(c.Objectives(a), c.Objectives(b))
+		}
+	case StochasticRanking:
+		if rng.Float64() < c.RankingProbability {
+			return func(a, b problems.Solution) bool {
+				return paretoDominates(a.Objectives(), b.Objectives())
+			}
+		}
+	}
+	return ConstrainedDominates
+}
+
+// Better reports whether a is better than b by Deb's feasibility rules on
+// fitness. Algorithms use it to keep track of the best solution whatever the
+// constraint handling, so that a feasible solution is always reported if found.
+func Better(a, b problems.Solution) bool {
+	va, vb := problems.Violation(a), problems.Violation(b)
+	if va != vb {
+		return va < vb
+	}
+	return a.Fitness() < b.Fitness()
+}
+
+// ConstrainedDominates reports whether a constrained-dominates b: a is feasible
+// and b is not, both are infeasible and a violates the constraints less, or
+// both are feasible and a Pareto-dominates b.
+func ConstrainedDominates(a, b problems.Solution) bool {
+	va, vb := problems.Violation(a), problems.Violation(b)
+	if va != vb {
+		return va < vb
+	}
+	if va > 0 {
+		return false
+	}
+	return paretoDominates(a.Objectives(), b.Objectives())
+}
+
+// paretoDominates reports whether a is no worse than b in every objective and
+// better in at least one (minimization).
+func paretoDominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			better = true
+		}
+	}
+	return better
+}
@@ -0,0 +1,177 @@
+package algos
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// solution returns a point with the given objectives violating its constraint by v.
+func solution(v float64, objectives ...float64) testutil.Constrained {
+	return testutil.Constrained{Point: objectives, Violation: v}
+}
+
+func TestBetter(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b problems.Solution
+		want bool
+	}{
+		{"fitter feasible", solution(0, 1), solution(0, 2), true},
+		{"less fit feasible", solution(0, 2), solution(0, 1), false},
+		{"feasible beats fitter infeasible", solution(0, 2), solution(1, 1), true},
+		{"infeasible loses to feasible", solution(1, 1), solution(0, 2), false},
+		{"smaller violation", solution(1, 5), solution(2, 1), true},
+		{"equal violation, fitter", solution(2, 1), solution(2, 5), true},
+		{"unconstrained", testutil.Point{1}, testutil.Point{2}, true},
+		{"equal", solution(0, 1), solution(0, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Better(tt.a, tt.b); got != tt.want {
+				t.Errorf("Better() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstrainedDominates(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b problems.Solution
+		want bool
+	}{
+		{"feasible, dominating", solution(0, 1, 1), solution(0, 2, 2), true},
+		{"feasible, incomparable", solution(0, 1, 2), solution(0, 2, 1), false},
+		{"feasible beats dominating infeasible", solution(0, 5, 5), solution(1, 1, 1), true},
+		{"smaller violation", solution(1, 5, 5), solution(2, 1, 1), true},
+		{"equal violation ignores objectives", solution(1, 1, 1), solution(1, 2, 2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConstrainedDominates(tt.a, tt.b); got != tt.want {
+				t.Errorf("ConstrainedDominates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPenalty(t *testing.T) {
+	c := Constraints{Handling: Penalty, PenaltyWeight: 10}
+	if got := c.Fitness(solution(0.5, 1, 2)); got != 6 {
+		t.Errorf("Fitness() = %v, want 6", got)
+	}
+	if got := c.Objectives(solution(0.5, 1, 2)); !slices.Equal(got, []float64{6, 7}) {
+		t.Errorf("Objectives() = %v, want [6 7]", got)
+	}
+	if got := c.Objectives(solution(0, 1, 2)); !slices.Equal(got, []float64{1, 2}) {
+		t.Errorf("Objectives() of a feasible solution = %v, want [1 2]", got)
+	}
+	// 1 + 10·0.05 against 2
+	if !c.Less(nil, solution(0.05, 1), solution(0, 2)) {
+		t.Error("a slightly infeasible, much fitter solution should rank first")
+	}
+	if c.Less(nil, solution(0.5, 1), solution(0, 2)) {
+		t.Error("a heavily penalized solution should rank last")
+	}
+	dominates := c.Dominance(nil)
+	if dominates(solution(1, 1, 1), solution(0, 2, 2)) {
+		t.Error("penalized (11, 11) should not dominate (2, 2)")
+	}
+	if !dominates(solution(0, 2, 2), solution(1, 1, 1)) {
+		t.Error("(2, 2) should dominate penalized (11, 11)")
+	}
+}
+
+func TestStochasticRanking(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	fitter, feasible := solution(1, 1, 1), solution(0, 2, 2)
+	tests := []struct {
+		name        string
+		probability float64
+		want        bool // whether fitter ranks first
+	}{
+		{"always on fitness", 1, true},
+		{"always on violation", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Constraints{Handling: StochasticRanking, RankingProbability: tt.probability}
+			if got := c.Less(rng, fitter, feasible); got != tt.want {
+				t.Errorf("Less() = %v, want %v", got, tt.want)
+			}
+			if got := c.Dominance(rng)(fitter, feasible); got != tt.want {
+				t.Errorf("Dominance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// among feasible solutions the draw never matters
+	c := Constraints{Handling: StochasticRanking, RankingProbability: 0.45}
+	for range 20 {
+		if !c.Less(rng, solution(0, 1), solution(0, 2)) {
+			t.Fatal("feasible solutions were not compared on fitness")
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	pop := func() []problems.Solution {
+		return []problems.Solution{solution(0, 3), solution(1.5, 0), solution(0, 1), solution(1, 5), solution(0, 2)}
+	}
+	fitness := func(pop []problems.Solution) []float64 {
+		values := make([]float64, len(pop))
+		for i, sol := range pop {
+			values[i] = sol.Fitness()
+		}
+		return values
+	}
+	tests := []struct {
+		name string
+		c    Constraints
+		want []float64
+	}{
+		{"domination", Constraints{}, []float64{1, 2, 3, 5, 0}},
+		// penalized fitness 3, 1.5, 1, 6 and 2
+		{"penalty", Constraints{Handling: Penalty, PenaltyWeight: 1}, []float64{1, 0, 2, 3, 5}},
+		{"stochastic ranking on violation", Constraints{Handling: StochasticRanking}, []float64{1, 2, 3, 5, 0}},
+		{"stochastic ranking on fitness", Constraints{Handling: StochasticRanking, RankingProbability: 1}, []float64{0, 1, 2, 3, 5}},
+		// one bubble-sort sweep only carries the worst solution to the end
+		{"one sweep", Constraints{Handling: StochasticRanking, RankingSweeps: 1}, []float64{3, 1, 5, 2, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pop()
+			tt.c.Sort(rand.New(rand.NewPCG(1, 1)), got)
+			if !slices.Equal(fitness(got), tt.want) {
+				t.Errorf("Sort() gives fitness %v, want %v", fitness(got), tt.want)
+			}
+		})
+	}
+
+	feasible := []problems.Solution{solution(0, 2), testutil.Point{1}, solution(0, 2), solution(0, 0)}
+	Constraints{Handling: StochasticRanking}.Sort(nil, feasible)
+	if !slices.Equal(fitness(feasible), []float64{0, 1, 2, 2}) {
+		t.Errorf("Sort() of feasible solutions gives fitness %v", fitness(feasible))
+	}
+}
+
+func TestConstraintsFromMap(t *testing.T) {
+	c, err := ConstraintsFromMap(ParamMap{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Constraints{PenaltyWeight: 1, RankingProbability: 0.45}); c != want {
+		t.Errorf("defaults %+v, want %+v", c, want)
+	}
+	c, err = ConstraintsFromMap(ParamMap{"constraints": "stochastic-ranking", "ranking_sweeps": 3})
+	if err != nil || c.Handling != StochasticRanking || c.RankingSweeps != 3 {
+		t.Errorf("ConstraintsFromMap() = %+v, %v", c, err)
+	}
+	if _, err := ConstraintsFromMap(ParamMap{"constraints": "death"}); err == nil {
+		t.Error("ConstraintsFromMap() accepted an unknown method")
+	}
+}
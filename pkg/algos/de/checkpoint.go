@@ -0,0 +1,60 @@
+package de
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	LoggedFitness float64 `json:"logged_fitness"`
+}
+
+// Checkpoint captures the full state of the run.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("de", alg.population)
+	if err != nil {
+		return nil, err
+	}
+	cp.State, err = json.Marshal(checkpointState{LoggedFitness: alg.loggedFitness})
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("de", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding de state: %w", err)
+		}
+	}
+	alg.population = pop
+	alg.loggedFitness = state.LoggedFitness
+	return nil
+}
+
+// Checkpoint captures the full state of the run.
+func (alg *GDE3) Checkpoint() (*algos.Checkpoint, error) {
+	return alg.NewCheckpoint("gde3", alg.population)
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *GDE3) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("gde3", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	alg.population = pop
+	return nil
+}
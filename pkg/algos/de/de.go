@@ -0,0 +1,134 @@
+package de
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("de", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkProblem("de", problem, params); err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// checkProblem reports whether the algorithm called name can run on problem.
+func checkProblem(name string, problem problems.Problem, params Params) error {
+	if _, ok := problem.RandomSolution(problems.NewRand(1)).(problems.RealVector); !ok {
+		return fmt.Errorf("%s needs real vector solutions, %s does not have them", name, problem.Name())
+	}
+	if params.PopulationSize < 4 {
+		return fmt.Errorf("%s needs a population of at least 4, got %d", name, params.PopulationSize)
+	}
+	return nil
+}
+
+// Algorithm implements differential evolution (Storn and Price, 1997) with
+// binomial crossover for single-objective problems, minimizing Fitness. Every
+// target is challenged by its trial vector and replaced when the trial is no worse.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params        Params
+	population    []problems.Solution
+	loggedFitness float64
+}
+
+// NewAlgorithm creates a new differential evolution instance. The problem's
+// solutions must implement problems.RealVector.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Seed starts the population from seedSolution and copies of it scattered
+// within the bounds.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = scatter(alg.Rand, seedSolution, alg.params.PopulationSize)
+	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = append([]problems.Solution(nil), pop...)
+}
+
+// GetPopulation returns the solutions of the current population.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes the differential evolution process until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step performs one generation: a trial vector is built and evaluated for every
+// target, and the targets lose their place to trials with lower or equal fitness.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+	alg.Evaluator.Evaluate(alg.population)
+
+	alg.Generation++
+
+	best := bestIndex(alg.population)
+	trials := make([]problems.Solution, len(alg.population))
+	for i, target := range alg.population {
+		trials[i] = realVector(target).WithVector(trialVector(alg.Rand, alg.params, alg.population, i, best))
+	}
+	alg.Evaluator.Evaluate(trials)
+	alg.Evaluations += len(trials)
+
+	for i, trial := range trials {
+		if trial.Fitness() <= alg.population[i].Fitness() {
+			alg.population[i] = trial
+		}
+		if algos.Better(alg.population[i], alg.Solution) {
+			alg.Solution = alg.population[i]
+		}
+	}
+
+	if alg.Solution.Fitness() != alg.loggedFitness {
+		alg.loggedFitness = alg.Solution.Fitness()
+		alg.LogProgress(algos.GAStep{
+			Elapsed:  time.Since(alg.StartTimestamp),
+			Seed:     alg.RandSeed,
+			Step:     alg.Generation,
+			Solution: alg.Solution,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// initPopulation creates the initial population randomly.
+func (alg *Algorithm) initPopulation() {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.population {
+		alg.population[i] = alg.Problem.RandomSolution(alg.Rand)
+	}
+	alg.Evaluations += alg.params.PopulationSize
+}
@@ -0,0 +1,115 @@
+package de
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestSphere(t *testing.T) {
+	for _, strategy := range []Strategy{RandOne, CurrentToBestOne} {
+		params := Params{PopulationSize: 20, F: 0.5, CR: 0.9, Strategy: strategy, Seed: 3}
+		alg := NewAlgorithm(testutil.Sphere{Dimensions: 5}, params, 500, nil)
+		alg.Run(context.Background())
+		if best := alg.GetSolution().Fitness(); best > 1e-8 {
+			t.Errorf("strategy %d: best fitness %v, want below 1e-8", strategy, best)
+		}
+	}
+}
+
+func TestTrialVector(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	pop := make([]problems.Solution, 6)
+	for i := range pop {
+		pop[i] = testutil.Sphere{Dimensions: 4}.RandomSolution(rng)
+	}
+	for _, params := range []Params{
+		{F: 2, CR: 1, Strategy: RandOne},
+		{F: 2, CR: 1, Strategy: CurrentToBestOne},
+		{F: 0.5, CR: 0, Strategy: RandOne},
+	} {
+		for i, target := range pop {
+			u := trialVector(rng, params, pop, i, 0)
+			changed := 0
+			for j, v := range u {
+				if v < -5 || v > 5 {
+					t.Fatalf("trial %v leaves the bounds", u)
+				}
+				if v != realVector(target).Vector()[j] {
+					changed++
+				}
+			}
+			if params.CR == 0 && changed > 1 {
+				t.Errorf("CR 0 changed %d variables, want at most 1", changed)
+			}
+		}
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	for range 100 {
+		r := distinct(rng, 4, 2)
+		if slices.Contains(r[:], 2) || r[0] == r[1] || r[0] == r[2] || r[1] == r[2] {
+			t.Fatalf("distinct() = %v", r)
+		}
+	}
+}
+
+func TestReduce(t *testing.T) {
+	pop := []problems.Solution{testutil.Point{0, 4}, testutil.Point{1, 3}, testutil.Point{1.2, 2.8}, testutil.Point{3, 1}, testutil.Point{4, 0}, testutil.Point{5, 5}}
+	tests := []struct {
+		name string
+		size int
+		want []problems.Solution
+	}{
+		{"whole front", 5, []problems.Solution{testutil.Point{0, 4}, testutil.Point{1, 3}, testutil.Point{1.2, 2.8}, testutil.Point{3, 1}, testutil.Point{4, 0}}},
+		// crowding distances 0.6, 1 and 1.4 for the inner points
+		{"most crowded first", 4, []problems.Solution{testutil.Point{0, 4}, testutil.Point{1.2, 2.8}, testutil.Point{3, 1}, testutil.Point{4, 0}}},
+		{"extremes last", 2, []problems.Solution{testutil.Point{0, 4}, testutil.Point{4, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reduce(pop, tt.size)
+			if len(got) != len(tt.want) {
+				t.Fatalf("reduce() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !slices.Equal(got[i].Objectives(), tt.want[i].Objectives()) {
+					t.Fatalf("reduce() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestCrowdingDistance(t *testing.T) {
+	got := crowdingDistance([]problems.Solution{testutil.Point{0, 4}, testutil.Point{1, 3}, testutil.Point{3, 1}, testutil.Point{4, 0}})
+	// the inner points span 3/4 of both objective ranges
+	want := []float64{math.Inf(1), 1.5, 1.5, math.Inf(1)}
+	if !slices.Equal(got, want) {
+		t.Errorf("crowdingDistance() = %v, want %v", got, want)
+	}
+}
+
+func TestWeaklyDominates(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want bool
+	}{
+		{[]float64{1, 1}, []float64{1, 1}, true},
+		{[]float64{1, 1}, []float64{1, 2}, true},
+		{[]float64{1, 2}, []float64{2, 1}, false},
+		{[]float64{2, 2}, []float64{1, 1}, false},
+	}
+	for _, tt := range tests {
+		if got := weaklyDominates(tt.a, tt.b); got != tt.want {
+			t.Errorf("weaklyDominates(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
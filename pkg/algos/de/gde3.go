@@ -0,0 +1,248 @@
+package de
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("gde3", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkProblem("gde3", problem, params); err != nil {
+			return nil, err
+		}
+		return NewGDE3(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*GDE3)(nil)
+	_ algos.Checkpointer = (*GDE3)(nil)
+)
+
+// GDE3 implements the third version of generalized differential evolution
+// (Kukkonen and Lampinen, 2005) for multi-objective problems. A trial replaces
+// its target when it weakly dominates it, is dropped when the target dominates
+// it, and otherwise joins the population; the population is then cut back with
+// non-dominated sorting and crowding distance.
+type GDE3 struct {
+	algos.GeneticAlgorithm
+	params     Params
+	population []problems.Solution
+}
+
+// NewGDE3 creates a new GDE3 instance. The problem's solutions must implement
+// problems.RealVector.
+func NewGDE3(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *GDE3 {
+	alg := &GDE3{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Seed starts the population from seedSolution and copies of it scattered
+// within the bounds.
+func (alg *GDE3) Seed(seedSolution problems.Solution) {
+	alg.population = scatter(alg.Rand, seedSolution, alg.params.PopulationSize)
+	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *GDE3) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = append([]problems.Solution(nil), pop...)
+}
+
+// GetPopulation returns the solutions of the current population.
+func (alg *GDE3) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes the GDE3 process until timeout.
+func (alg *GDE3) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step performs one GDE3 generation.
+func (alg *GDE3) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+	alg.Evaluator.Evaluate(alg.population)
+
+	alg.Generation++
+
+	best := bestIndex(alg.population)
+	trials := make([]problems.Solution, len(alg.population))
+	for i, target := range alg.population {
+		trials[i] = realVector(target).WithVector(trialVector(alg.Rand, alg.params, alg.population, i, best))
+	}
+	alg.Evaluator.Evaluate(trials)
+	alg.Evaluations += len(trials)
+
+	next := make([]problems.Solution, 0, 2*len(alg.population))
+	for i, trial := range trials {
+		target := alg.population[i]
+		switch {
+		case weaklyDominates(trial.Objectives(), target.Objectives()):
+			next = append(next, trial)
+		case metrics.Dominates(target.Objectives(), trial.Objectives()):
+			next = append(next, target)
+		default:
+			next = append(next, target, trial)
+		}
+	}
+	alg.population = reduce(next, alg.params.PopulationSize)
+
+	var pareto [][]float64
+	for _, sol := range alg.population {
+		if algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+	}
+	for _, i := range nonDominatedSort(alg.population)[0] {
+		pareto = append(pareto, alg.population[i].Objectives())
+	}
+	alg.ParetoFront = pareto
+	if !alg.params.Verbose {
+		pareto = nil
+	}
+	alg.LogProgress(algos.GAStep{
+		Elapsed:     time.Since(alg.StartTimestamp),
+		Seed:        alg.RandSeed,
+		Step:        alg.Generation,
+		ParetoFront: pareto,
+		Solution:    alg.Solution,
+	})
+	alg.NotifyObservers()
+}
+
+// initPopulation creates the initial population randomly.
+func (alg *GDE3) initPopulation() {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.population {
+		alg.population[i] = alg.Problem.RandomSolution(alg.Rand)
+	}
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+// reduce keeps size solutions of pop: whole non-dominated fronts while they fit,
+// then the most isolated members of the front that does not, dropping the most
+// crowded one at a time and recomputing the crowding distance after each removal.
+func reduce(pop []problems.Solution, size int) []problems.Solution {
+	if len(pop) <= size {
+		return pop
+	}
+	kept := make([]problems.Solution, 0, size)
+	for _, front := range nonDominatedSort(pop) {
+		if len(kept)+len(front) <= size {
+			for _, i := range front {
+				kept = append(kept, pop[i])
+			}
+			continue
+		}
+		last := make([]problems.Solution, len(front))
+		for k, i := range front {
+			last[k] = pop[i]
+		}
+		for len(kept)+len(last) > size {
+			distance := crowdingDistance(last)
+			worst := 0
+			for k := range last {
+				if distance[k] < distance[worst] {
+					worst = k
+				}
+			}
+			last = append(last[:worst], last[worst+1:]...)
+		}
+		kept = append(kept, last...)
+		break
+	}
+	return kept
+}
+
+// nonDominatedSort splits pop into fronts of indexes, the first one being non-dominated.
+func nonDominatedSort(pop []problems.Solution) [][]int {
+	n := len(pop)
+	domCount := make([]int, n)
+	dominatedSet := make([][]int, n)
+	var current []int
+	for i := range n {
+		for j := range n {
+			if i == j {
+				continue
+			}
+			if metrics.Dominates(pop[i].Objectives(), pop[j].Objectives()) {
+				dominatedSet[i] = append(dominatedSet[i], j)
+			} else if metrics.Dominates(pop[j].Objectives(), pop[i].Objectives()) {
+				domCount[i]++
+			}
+		}
+		if domCount[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	var fronts [][]int
+	for len(current) > 0 {
+		fronts = append(fronts, current)
+		var next []int
+		for _, i := range current {
+			for _, j := range dominatedSet[i] {
+				domCount[j]--
+				if domCount[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		current = next
+	}
+	return fronts
+}
+
+// crowdingDistance returns the crowding distance of every solution of front.
+func crowdingDistance(front []problems.Solution) []float64 {
+	distance := make([]float64, len(front))
+	order := make([]int, len(front))
+	for m := range front[0].Objectives() {
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return front[order[a]].Objectives()[m] < front[order[b]].Objectives()[m]
+		})
+		lo, hi := front[order[0]].Objectives()[m], front[order[len(order)-1]].Objectives()[m]
+		distance[order[0]] = math.Inf(1)
+		distance[order[len(order)-1]] = math.Inf(1)
+		if hi == lo {
+			continue
+		}
+		for k := 1; k < len(order)-1; k++ {
+			distance[order[k]] += (front[order[k+1]].Objectives()[m] - front[order[k-1]].Objectives()[m]) / (hi - lo)
+		}
+	}
+	return distance
+}
+
+// weaklyDominates reports whether a is no worse than b in every objective.
+func weaklyDominates(a, b []float64) bool {
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package de
+
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Strategy is the differential mutation building the donor vector.
+type Strategy int
+
+const (
+	// RandOne is rand/1: a random base vector plus one scaled difference.
+	RandOne Strategy = iota
+	// CurrentToBestOne is current-to-best/1: the target moved towards the best
+	// individual plus one scaled difference.
+	CurrentToBestOne
+)
+
+// StrategyByName resolves "rand/1/bin" and "current-to-best/1" (also "current-to-best/1/bin").
+func StrategyByName(name string) (Strategy, error) {
+	switch name {
+	case "rand/1/bin", "rand/1":
+		return RandOne, nil
+	case "current-to-best/1", "current-to-best/1/bin":
+		return CurrentToBestOne, nil
+	}
+	return 0, fmt.Errorf("unknown differential evolution strategy %q", name)
+}
+
+// Params holds configurable parameters for differential evolution and GDE3.
+type Params struct {
+	PopulationSize int
+	F              float64 // scale factor of the difference vectors
+	CR             float64 // binomial crossover rate
+	Strategy       Strategy
+	Seed           uint64 // seed of the run RNG; 0 picks a random seed
+	Workers        int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+	Verbose        bool   // log the Pareto front (GDE3 only)
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 50); err != nil {
+		return
+	}
+	if params.F, err = m.Float("f", 0.5); err != nil {
+		return
+	}
+	if params.CR, err = m.Float("cr", 0.9); err != nil {
+		return
+	}
+	strategy, err := m.String("strategy", "rand/1/bin")
+	if err != nil {
+		return
+	}
+	if params.Strategy, err = StrategyByName(strategy); err != nil {
+		return
+	}
+	if params.Verbose, err = m.Bool("verbose", false); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
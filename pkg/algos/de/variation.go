@@ -0,0 +1,101 @@
+package de
+
+import (
+	"math/rand/v2"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// realVector returns sol as a real vector, or panics: the algorithms of this
+// package only accept problems whose solutions are real vectors.
+func realVector(sol problems.Solution) problems.RealVector {
+	v, ok := sol.(problems.RealVector)
+	if !ok {
+		panic("de: solution is not a real vector")
+	}
+	return v
+}
+
+// trialVector applies differential mutation and binomial crossover to the target
+// pop[i]. best is the index of the best individual, used by current-to-best/1.
+// Components leaving the bounds are set halfway between the target and the bound.
+func trialVector(rng *rand.Rand, params Params, pop []problems.Solution, i, best int) []float64 {
+	target := realVector(pop[i])
+	lower, upper := target.Bounds()
+	x := target.Vector()
+	r := distinct(rng, len(pop), i)
+	a, b, c := realVector(pop[r[0]]).Vector(), realVector(pop[r[1]]).Vector(), realVector(pop[r[2]]).Vector()
+	bestX := realVector(pop[best]).Vector()
+
+	u := make([]float64, len(x))
+	jrand := rng.IntN(len(x))
+	for j := range x {
+		if j != jrand && rng.Float64() >= params.CR {
+			u[j] = x[j]
+			continue
+		}
+		var v float64
+		switch params.Strategy {
+		case CurrentToBestOne:
+			v = x[j] + params.F*(bestX[j]-x[j]) + params.F*(b[j]-c[j])
+		default:
+			v = a[j] + params.F*(b[j]-c[j])
+		}
+		if v < lower[j] {
+			v = (lower[j] + x[j]) / 2
+		} else if v > upper[j] {
+			v = (upper[j] + x[j]) / 2
+		}
+		u[j] = v
+	}
+	return u
+}
+
+// distinct draws three different indexes below n, all different from i.
+func distinct(rng *rand.Rand, n, i int) [3]int {
+	var r [3]int
+	for k := range r {
+	draw:
+		for {
+			r[k] = rng.IntN(n)
+			if r[k] == i {
+				continue
+			}
+			for _, prev := range r[:k] {
+				if prev == r[k] {
+					continue draw
+				}
+			}
+			break
+		}
+	}
+	return r
+}
+
+// scatter returns size solutions around seed: the seed itself and copies with
+// every variable perturbed by a tenth of its range, kept within the bounds.
+func scatter(rng *rand.Rand, seed problems.Solution, size int) []problems.Solution {
+	v := realVector(seed)
+	lower, upper := v.Bounds()
+	pop := make([]problems.Solution, size)
+	pop[0] = seed
+	for k := 1; k < size; k++ {
+		x := make([]float64, len(lower))
+		for j, value := range v.Vector() {
+			x[j] = min(max(value+rng.NormFloat64()*0.1*(upper[j]-lower[j]), lower[j]), upper[j])
+		}
+		pop[k] = v.WithVector(x)
+	}
+	return pop
+}
+
+// bestIndex returns the index of the individual with the lowest fitness.
+func bestIndex(pop []problems.Solution) int {
+	best := 0
+	for i, sol := range pop {
+		if sol.Fitness() < pop[best].Fitness() {
+			best = i
+		}
+	}
+	return best
+}
@@ -0,0 +1,68 @@
+package algos
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Evaluator computes objectives of freshly created solutions on a pool of goroutines.
+// Solutions cache their objectives, so once evaluated the algorithm reads them for free.
+// Evaluation never touches the run RNG, so results are identical to serial evaluation.
+type Evaluator struct {
+	Workers int // number of goroutines; 0 or 1 evaluates serially
+}
+
+func NewEvaluator(workers int) Evaluator {
+	return Evaluator{Workers: workers}
+}
+
+// Evaluate computes Objectives and Fitness of every solution in sols.
+// With more than one worker, a pointer appearing twice is evaluated only once.
+// Solutions of other kinds are evaluated once per occurrence, so copies must
+// not share a cache.
+func (e Evaluator) Evaluate(sols []problems.Solution) {
+	if e.Workers <= 1 || len(sols) < 2 {
+		for _, s := range sols {
+			evaluate(s)
+		}
+		return
+	}
+
+	unique := make([]problems.Solution, 0, len(sols))
+	seen := make(map[problems.Solution]struct{}, len(sols))
+	for _, s := range sols {
+		// only pointers identify an individual; other kinds may not even be hashable
+		if reflect.ValueOf(s).Kind() == reflect.Pointer {
+			if _, ok := seen[s]; ok {
+				continue
+			}
+			seen[s] = struct{}{}
+		}
+		unique = append(unique, s)
+	}
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(e.Workers, len(unique)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(unique) {
+					return
+				}
+				evaluate(unique[i])
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func evaluate(s problems.Solution) {
+	s.Objectives()
+	s.Fitness()
+}
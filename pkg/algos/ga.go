@@ -1,6 +1,8 @@
 package algos
 
 import (
+	"context"
+	"math/rand/v2"
 	"time"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
@@ -12,29 +14,181 @@ type GeneticAlgorithm struct {
 	GenerationLimit int
 	Problem         problems.Problem
 	Solution        problems.Solution
+	// Generation counts performed steps, Evaluations counts created (and evaluated) solutions.
+	Generation  int
+	Evaluations int
+	// ParetoFront holds the objectives of the current non-dominated set (multi-objective algorithms only).
+	ParetoFront [][]float64
+	// Termination optionally stops the run before the generation limit.
+	Termination Criterion
+	// StopReason explains why the last Run returned.
+	StopReason string
+	// Observers are notified about the run progress, see AddObserver.
+	Observers []Observer
+	// Indicators optionally evaluates the Pareto front of every logged step.
+	Indicators IndicatorFunc
+	// RandSeed is the seed Rand was created from; it is recorded in every logged step.
+	RandSeed uint64
+	// Rand is the only source of randomness of a run: it is passed to the
+	// problem and to every operator, so equal seeds give equal runs.
+	Rand      *rand.Rand
+	Evaluator Evaluator
+	source    *rand.PCG
+
+	populationFunc func() []problems.Solution
+	logErr         error
+	stopRequest    string
+	observedBest   bool
+	bestFitness    float64
 }
 
 type GAStep struct {
-	Elapsed     time.Duration     `json:"elapsed"`
-	Step        int               `json:"step"`
-	Solution    problems.Solution `json:"solution"`
-	ParetoFront [][]float64       `json:"pareto_front"`
+	Elapsed     time.Duration      `json:"elapsed"`
+	Step        int                `json:"step"`
+	Seed        uint64             `json:"seed,omitempty"`
+	Solution    problems.Solution  `json:"solution"`
+	ParetoFront [][]float64        `json:"pareto_front"`
+	StopReason  string             `json:"stop_reason,omitempty"`
+	Indicators  map[string]float64 `json:"indicators,omitempty"`
+	// Operators reports the operator usage of an adaptive operator, see Snapshot.LogOperators.
+	Operators []OperatorStats `json:"operators,omitempty"`
+}
+
+// OperatorStats describes how an adaptive operator used one of its operators
+// during a generation.
+type OperatorStats struct {
+	Name string `json:"name"`
+	// Uses counts the applications in the generation, Successes those that gave
+	// a child better than its parent.
+	Uses      int `json:"uses"`
+	Successes int `json:"successes"`
+	// Probability is the chance of picking the operator in the next generation;
+	// for a bandit strategy it is the share of all picks so far.
+	Probability float64 `json:"probability"`
+	// Quality is the credit the operator has earned so far.
+	Quality float64 `json:"quality"`
 }
 
+// IndicatorFunc computes quality indicators of a Pareto front, e.g. metrics.Indicators.
+type IndicatorFunc func(front [][]float64) map[string]float64
+
+// NewGeneticAlgorithm prepares the common state of an algorithm.
+// A zero seed is replaced with a random one, which is then recorded in RandSeed.
 func NewGeneticAlgorithm(
 	problem problems.Problem,
 	generationLimit int,
+	seed uint64,
 	logger ProgressLoggerProvider,
 ) *GeneticAlgorithm {
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	source := problems.NewSource(seed)
+	rng := rand.New(source)
 	return &GeneticAlgorithm{
 		Problem:                problem,
-		Solution:               problem.RandomSolution(),
+		Solution:               problem.RandomSolution(rng),
 		StartTimestamp:         time.Now(),
 		GenerationLimit:        generationLimit,
 		ProgressLoggerProvider: logger,
+		RandSeed:               seed,
+		Rand:                   rng,
+		source:                 source,
 	}
 }
 
 func (ga *GeneticAlgorithm) GetSolution() problems.Solution {
 	return ga.Solution
 }
+
+func (ga *GeneticAlgorithm) GetSteps() int {
+	return ga.Generation
+}
+
+func (ga *GeneticAlgorithm) SetTermination(criterion Criterion) {
+	ga.Termination = criterion
+}
+
+func (ga *GeneticAlgorithm) SetIndicators(indicators IndicatorFunc) {
+	ga.Indicators = indicators
+}
+
+// State returns a snapshot of the run progress for termination criteria.
+func (ga *GeneticAlgorithm) State() RunState {
+	return RunState{
+		Generation:  ga.Generation,
+		Evaluations: ga.Evaluations,
+		Elapsed:     time.Since(ga.StartTimestamp),
+		Best:        ga.Solution,
+		ParetoFront: ga.ParetoFront,
+	}
+}
+
+// Loop calls step until the generation limit is reached, ctx is done or the
+// termination criterion fires, then logs a final record with the stop reason.
+func (ga *GeneticAlgorithm) Loop(ctx context.Context, step func()) {
+	ga.StopReason, ga.stopRequest = "", ""
+	ga.notifyStart()
+	for ga.StopReason == "" {
+		ga.StopReason = ga.stopReason(ctx)
+		if ga.StopReason == "" {
+			step()
+		}
+	}
+	ga.notifyFinish()
+
+	ga.LogProgress(GAStep{
+		Elapsed:    time.Since(ga.StartTimestamp),
+		Step:       ga.Generation,
+		Seed:       ga.RandSeed,
+		Solution:   ga.Solution,
+		StopReason: ga.StopReason,
+	})
+	if ga.ProgressLoggerProvider != nil && ga.logErr == nil {
+		ga.logErr = ga.Flush()
+	}
+}
+
+// LogProgress logs step if the algorithm has a logger. The first logging error
+// is kept (see Err) and stops the run, as a run without its log is wasted.
+func (ga *GeneticAlgorithm) LogProgress(step GAStep) {
+	if ga.ProgressLoggerProvider == nil || ga.logErr != nil {
+		return
+	}
+	if ga.Indicators != nil && ga.ParetoFront != nil {
+		step.Indicators = ga.Indicators(ga.ParetoFront)
+	}
+	if err := ga.LogStep(step); err != nil {
+		ga.logErr = err
+		ga.stopRequest = "logging failed: " + err.Error()
+	}
+}
+
+// RequestStop ends Run before the next step, reporting reason. Algorithms call
+// it when they cannot make further progress, e.g. once they have converged.
+func (ga *GeneticAlgorithm) RequestStop(reason string) {
+	ga.stopRequest = reason
+}
+
+// Err returns the first logging error of the run, if any.
+func (ga *GeneticAlgorithm) Err() error {
+	return ga.logErr
+}
+
+func (ga *GeneticAlgorithm) stopReason(ctx context.Context) string {
+	if ga.stopRequest != "" {
+		return ga.stopRequest
+	}
+	if ga.Generation >= ga.GenerationLimit {
+		return "generation limit reached"
+	}
+	if err := ctx.Err(); err != nil {
+		return err.Error()
+	}
+	if ga.Termination != nil {
+		if done, reason := ga.Termination.Done(ga.State()); done {
+			return reason
+		}
+	}
+	return ""
+}
@@ -0,0 +1,39 @@
+package hillclimb
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	LoggedFitness float64 `json:"logged_fitness"`
+}
+
+// Checkpoint captures the full state of the run. The current solution is the
+// best one, which every checkpoint stores anyway.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("hillclimb", nil)
+	if err != nil {
+		return nil, err
+	}
+	cp.State, err = json.Marshal(checkpointState{LoggedFitness: alg.loggedFitness})
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	if _, err := alg.RestoreCheckpoint("hillclimb", cp); err != nil {
+		return err
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding hillclimb state: %w", err)
+		}
+	}
+	alg.started = cp.Evaluations > 0
+	alg.loggedFitness = state.LoggedFitness
+	return nil
+}
@@ -0,0 +1,127 @@
+package hillclimb
+
+import (
+	"context"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("hillclimb", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements stochastic hill climbing on Fitness, using the mutation
+// operator as the neighborhood: every step samples up to Neighbors neighbors of
+// the current solution and moves only if one of them is better.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params        Params
+	loggedFitness float64
+	started       bool
+}
+
+// NewAlgorithm creates a new hill climbing instance.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Seed starts the climb from seedSolution.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.Solution = seedSolution
+	alg.started = true
+	alg.Evaluations++
+}
+
+// SetPopulation starts the climb from the fittest solution of pop.
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) == 0 {
+		panic("Wrong population size")
+	}
+	alg.Solution = pop[0]
+	for _, sol := range pop[1:] {
+		if algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+	}
+	alg.started = true
+}
+
+// GetPopulation returns the current solution, which is always the best one found.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	if !alg.started {
+		return nil
+	}
+	return []problems.Solution{alg.Solution}
+}
+
+// Run executes hill climbing until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step samples the neighborhood once and logs the solution if it improved.
+func (alg *Algorithm) Step() {
+	if !alg.started {
+		alg.Solution = alg.Problem.RandomSolution(alg.Rand)
+		alg.started = true
+		alg.Evaluations++
+	}
+
+	alg.Generation++
+
+	switch alg.params.Strategy {
+	case BestImprovement:
+		neighbors := make([]problems.Solution, alg.params.Neighbors)
+		for i := range neighbors {
+			neighbors[i] = alg.params.MutationFunc(alg.Rand, alg.Solution)
+		}
+		alg.Evaluator.Evaluate(neighbors)
+		alg.Evaluations += len(neighbors)
+		best := alg.Solution
+		for _, neighbor := range neighbors {
+			if algos.Better(neighbor, best) {
+				best = neighbor
+			}
+		}
+		alg.Solution = best
+	default:
+		for range alg.params.Neighbors {
+			neighbor := alg.params.MutationFunc(alg.Rand, alg.Solution)
+			alg.Evaluations++
+			if algos.Better(neighbor, alg.Solution) {
+				alg.Solution = neighbor
+				break
+			}
+		}
+	}
+
+	if alg.Solution.Fitness() != alg.loggedFitness {
+		alg.loggedFitness = alg.Solution.Fitness()
+		alg.LogProgress(algos.GAStep{
+			Elapsed:  time.Since(alg.StartTimestamp),
+			Seed:     alg.RandSeed,
+			Step:     alg.Generation,
+			Solution: alg.Solution,
+		})
+	}
+	alg.NotifyObservers()
+}
@@ -0,0 +1,91 @@
+package hillclimb
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// sequence draws the given neighbors in turn, whatever the current solution.
+func sequence(neighbors ...problems.Solution) problems.MutationFunc {
+	next := 0
+	return func(_ *rand.Rand, _ problems.Solution) problems.Solution {
+		neighbor := neighbors[next%len(neighbors)]
+		next++
+		return neighbor
+	}
+}
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		name            string
+		strategy        Strategy
+		neighbors       []problems.Solution
+		want            float64
+		wantEvaluations int
+	}{
+		{"first", FirstImprovement, []problems.Solution{testutil.Point{5}, testutil.Point{3}, testutil.Point{1}}, 3, 2},
+		{"best", BestImprovement, []problems.Solution{testutil.Point{5}, testutil.Point{3}, testutil.Point{1}}, 1, 3},
+		{"first without improvement", FirstImprovement, []problems.Solution{testutil.Point{5}, testutil.Point{4}, testutil.Point{6}}, 4, 3},
+		{"best without improvement", BestImprovement, []problems.Solution{testutil.Point{5}, testutil.Point{4}, testutil.Point{6}}, 4, 3},
+		{"infeasible", BestImprovement, []problems.Solution{testutil.Constrained{Point: testutil.Point{0}, Violation: 1}}, 4, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := Params{MutationFunc: sequence(tt.neighbors...), Neighbors: 3, Strategy: tt.strategy}
+			alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, params, 0, nil)
+			alg.SetPopulation([]problems.Solution{testutil.Point{4}})
+			alg.Step()
+			if got := alg.GetSolution().Fitness(); got != tt.want {
+				t.Errorf("moved to fitness %v, want %v", got, tt.want)
+			}
+			if alg.Evaluations != tt.wantEvaluations {
+				t.Errorf("%d evaluations, want %d", alg.Evaluations, tt.wantEvaluations)
+			}
+		})
+	}
+}
+
+func TestSetPopulation(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{Neighbors: 1}, 0, nil)
+	alg.SetPopulation([]problems.Solution{
+		testutil.Constrained{Point: testutil.Point{1}, Violation: 1},
+		testutil.Constrained{Point: testutil.Point{3}},
+		testutil.Constrained{Point: testutil.Point{2}},
+	})
+	if got := alg.GetSolution().Fitness(); got != 2 {
+		t.Errorf("climb starts from fitness %v, want the best feasible 2", got)
+	}
+}
+
+func TestSphere(t *testing.T) {
+	for _, strategy := range []Strategy{FirstImprovement, BestImprovement} {
+		params := Params{MutationFunc: testutil.Nudge, Neighbors: 10, Strategy: strategy, Seed: 1}
+		alg := NewAlgorithm(testutil.Sphere{Dimensions: 2}, params, 0, nil)
+		alg.Seed(&testutil.SphereSolution{X: []float64{4, 4}})
+		previous := alg.GetSolution().Fitness()
+		for range 500 {
+			alg.Step()
+			if got := alg.GetSolution().Fitness(); got > previous {
+				t.Fatalf("strategy %d: fitness rose from %v to %v", strategy, previous, got)
+			}
+			previous = alg.GetSolution().Fitness()
+		}
+		if previous > 0.01 {
+			t.Errorf("strategy %d: best fitness %v after 500 steps from 32", strategy, previous)
+		}
+	}
+}
+
+func TestStrategyByName(t *testing.T) {
+	for name, want := range map[string]Strategy{"first": FirstImprovement, "best": BestImprovement} {
+		if got, err := StrategyByName(name); err != nil || got != want {
+			t.Errorf("StrategyByName(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := StrategyByName("random"); err == nil {
+		t.Error("StrategyByName() accepted an unknown name")
+	}
+}
@@ -0,0 +1,67 @@
+package hillclimb
+
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Strategy decides which improving neighbor hill climbing moves to.
+type Strategy int
+
+const (
+	// FirstImprovement moves to the first sampled neighbor that is better.
+	FirstImprovement Strategy = iota
+	// BestImprovement samples all Neighbors and moves to the best one if it is better.
+	BestImprovement
+)
+
+// StrategyByName resolves "first" and "best".
+func StrategyByName(name string) (Strategy, error) {
+	switch name {
+	case "first":
+		return FirstImprovement, nil
+	case "best":
+		return BestImprovement, nil
+	}
+	return 0, fmt.Errorf("unknown hill climbing strategy %q", name)
+}
+
+// Params holds configurable parameters for hill climbing.
+type Params struct {
+	// MutationFunc draws a neighbor of the current solution.
+	MutationFunc problems.MutationFunc
+	// Neighbors is the number of neighbors sampled per step.
+	Neighbors int
+	Strategy  Strategy
+	Seed      uint64 // seed of the run RNG; 0 picks a random seed
+	Workers   int    // goroutines evaluating neighbors (best improvement only); 0 or 1 evaluates serially
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.Neighbors, err = m.Int("neighbors", 20); err != nil {
+		return
+	}
+	if params.Neighbors < 1 {
+		err = fmt.Errorf("parameter %q: need at least one neighbor, got %d", "neighbors", params.Neighbors)
+		return
+	}
+	strategy, err := m.String("strategy", "first")
+	if err != nil {
+		return
+	}
+	if params.Strategy, err = StrategyByName(strategy); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
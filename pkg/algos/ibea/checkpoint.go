@@ -0,0 +1,47 @@
+package ibea
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	Fitness []float64 `json:"fitness"`
+}
+
+// Checkpoint captures the full state of the run, including the indicator fitness
+// mating selection uses. It depends on the individuals removed in the last
+// environmental selection, so it cannot be recomputed from the population.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("ibea", alg.population)
+	if err != nil {
+		return nil, err
+	}
+	cp.State, err = json.Marshal(checkpointState{Fitness: alg.fitness})
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("ibea", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding ibea state: %w", err)
+		}
+	}
+	if len(state.Fitness) != 0 && len(state.Fitness) != len(pop) {
+		return fmt.Errorf("checkpoint has fitness for %d of %d individuals", len(state.Fitness), len(pop))
+	}
+	alg.population = pop
+	alg.fitness = state.Fitness
+	return nil
+}
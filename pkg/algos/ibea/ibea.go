@@ -0,0 +1,296 @@
+package ibea
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("ibea", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements IBEA (Zitzler and Künzli, 2004) with the additive epsilon
+// indicator: the fitness of an individual measures how much the others would have
+// to be shifted to dominate it, and the worst individual is removed one at a time.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params     Params
+	population []problems.Solution
+	// fitness holds the indicator fitness of population, higher is better.
+	fitness []float64
+}
+
+// NewAlgorithm creates a new IBEA instance.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = alg.params.MutationFunc(alg.Rand, seedSolution)
+	}
+	alg.population[0] = seedSolution
+	alg.Solution = seedSolution
+	alg.fitness = nil
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = append([]problems.Solution(nil), pop...)
+	alg.fitness = nil
+}
+
+// GetPopulation returns the solutions of the current population.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes the IBEA process until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step performs one IBEA generation: binary tournaments on the indicator fitness
+// produce PopulationSize children, and environmental selection cuts parents and
+// children back to PopulationSize.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+	if len(alg.fitness) != len(alg.population) {
+		alg.Evaluator.Evaluate(alg.population)
+		_, alg.fitness = environmentalSelection(objectives(alg.population), len(alg.population), alg.params.Kappa)
+	}
+
+	alg.Generation++
+
+	offspring := make([]problems.Solution, 0, alg.params.PopulationSize)
+	pick := alg.selector()
+	for len(offspring) < alg.params.PopulationSize {
+		parent1, parent2 := pick(alg.Rand), pick(alg.Rand)
+		for _, child := range alg.params.CrossoverFunc(alg.Rand, parent1, parent2) {
+			offspring = append(offspring, alg.params.MutationFunc(alg.Rand, child))
+			if len(offspring) >= alg.params.PopulationSize {
+				break
+			}
+		}
+	}
+	alg.Evaluator.Evaluate(offspring)
+	alg.Evaluations += len(offspring)
+	for _, child := range offspring {
+		if algos.Better(child, alg.Solution) {
+			alg.Solution = child
+		}
+	}
+
+	combined := append(alg.population, offspring...)
+	kept, fitness := environmentalSelection(objectives(combined), alg.params.PopulationSize, alg.params.Kappa)
+	alg.population = make([]problems.Solution, len(kept))
+	for k, i := range kept {
+		alg.population[k] = combined[i]
+	}
+	alg.fitness = fitness
+
+	pareto := nonDominated(alg.population)
+	alg.ParetoFront = pareto
+	if !alg.params.Verbose {
+		pareto = nil
+	}
+	alg.LogProgress(algos.GAStep{
+		Elapsed:     time.Since(alg.StartTimestamp),
+		Seed:        alg.RandSeed,
+		Step:        alg.Generation,
+		ParetoFront: pareto,
+		Solution:    alg.Solution,
+	})
+	alg.NotifyObservers()
+}
+
+// selector returns the parent selection of a generation: binary tournaments,
+// unless Params.Selection is set.
+func (alg *Algorithm) selector() func(rng *rand.Rand) problems.Solution {
+	if alg.params.Selection == nil {
+		return func(*rand.Rand) problems.Solution { return alg.tournament() }
+	}
+	pick := alg.params.Selection.Prepare(indicatorPopulation{alg.population, alg.fitness})
+	return func(rng *rand.Rand) problems.Solution { return alg.population[pick(rng)] }
+}
+
+// indicatorPopulation is the view of the population for selection. Its
+// fitness is the negated indicator fitness, so that lower is better.
+type indicatorPopulation struct {
+	solutions []problems.Solution
+	fitness   []float64
+}
+
+func (p indicatorPopulation) Len() int                   { return len(p.solutions) }
+func (p indicatorPopulation) Better(i, j int) bool       { return p.fitness[i] > p.fitness[j] }
+func (p indicatorPopulation) Fitness(i int) float64      { return -p.fitness[i] }
+func (p indicatorPopulation) Objectives(i int) []float64 { return p.solutions[i].Objectives() }
+
+// tournament picks the fitter of two random individuals.
+func (alg *Algorithm) tournament() problems.Solution {
+	i := alg.Rand.IntN(len(alg.population))
+	j := alg.Rand.IntN(len(alg.population))
+	if alg.fitness[j] > alg.fitness[i] {
+		return alg.population[j]
+	}
+	return alg.population[i]
+}
+
+// initPopulation creates the initial population randomly.
+func (alg *Algorithm) initPopulation() {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.population {
+		alg.population[i] = alg.Problem.RandomSolution(alg.Rand)
+	}
+	alg.fitness = nil
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+// environmentalSelection assigns the epsilon indicator fitness to the points and
+// removes the worst one until size remain, updating the fitness of the others
+// after every removal. It returns the indexes of the survivors and their fitness.
+func environmentalSelection(objs [][]float64, size int, kappa float64) ([]int, []float64) {
+	n := len(objs)
+	normalized := normalize(objs)
+	indicator := make([][]float64, n)
+	scale := 0.0
+	for a := range n {
+		indicator[a] = make([]float64, n)
+		for b := range n {
+			indicator[a][b] = epsilon(normalized[a], normalized[b])
+			scale = max(scale, math.Abs(indicator[a][b]))
+		}
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	scale *= kappa
+
+	fitness := make([]float64, n)
+	for x := range n {
+		for y := range n {
+			if x != y {
+				fitness[x] -= math.Exp(-indicator[y][x] / scale)
+			}
+		}
+	}
+
+	alive := make([]bool, n)
+	for i := range alive {
+		alive[i] = true
+	}
+	for count := n; count > size; count-- {
+		worst := -1
+		for i := range n {
+			if alive[i] && (worst < 0 || fitness[i] < fitness[worst]) {
+				worst = i
+			}
+		}
+		alive[worst] = false
+		for i := range n {
+			if alive[i] {
+				fitness[i] += math.Exp(-indicator[worst][i] / scale)
+			}
+		}
+	}
+
+	var kept []int
+	var keptFitness []float64
+	for i := range n {
+		if alive[i] {
+			kept = append(kept, i)
+			keptFitness = append(keptFitness, fitness[i])
+		}
+	}
+	return kept, keptFitness
+}
+
+// epsilon is the additive epsilon indicator: the smallest shift of a that makes
+// it weakly dominate b.
+func epsilon(a, b []float64) float64 {
+	eps := math.Inf(-1)
+	for i := range a {
+		eps = max(eps, a[i]-b[i])
+	}
+	return eps
+}
+
+// normalize scales every objective to [0, 1] over the given points.
+func normalize(objs [][]float64) [][]float64 {
+	m := len(objs[0])
+	lo := make([]float64, m)
+	hi := make([]float64, m)
+	for i := range m {
+		lo[i], hi[i] = math.Inf(1), math.Inf(-1)
+	}
+	for _, f := range objs {
+		for i, v := range f {
+			lo[i], hi[i] = min(lo[i], v), max(hi[i], v)
+		}
+	}
+	normalized := make([][]float64, len(objs))
+	for k, f := range objs {
+		normalized[k] = make([]float64, m)
+		for i, v := range f {
+			if hi[i] > lo[i] {
+				normalized[k][i] = (v - lo[i]) / (hi[i] - lo[i])
+			}
+		}
+	}
+	return normalized
+}
+
+func objectives(pop []problems.Solution) [][]float64 {
+	objs := make([][]float64, len(pop))
+	for i, sol := range pop {
+		objs[i] = sol.Objectives()
+	}
+	return objs
+}
+
+// nonDominated returns the objectives of the non-dominated solutions of pop.
+func nonDominated(pop []problems.Solution) [][]float64 {
+	var front [][]float64
+	for i, a := range pop {
+		dominated := false
+		for j, b := range pop {
+			if i != j && metrics.Dominates(b.Objectives(), a.Objectives()) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, a.Objectives())
+		}
+	}
+	return front
+}
@@ -0,0 +1,69 @@
+package ibea
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestEpsilon(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want float64
+	}{
+		{[]float64{1, 2}, []float64{2, 1}, 1},
+		{[]float64{0, 0}, []float64{1, 1}, -1},
+		{[]float64{1, 1}, []float64{1, 1}, 0},
+		{[]float64{3, 1, 2}, []float64{1, 1, 1}, 2},
+	}
+	for _, tt := range tests {
+		if got := epsilon(tt.a, tt.b); got != tt.want {
+			t.Errorf("epsilon(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	got := normalize([][]float64{{0, 10, 7}, {5, 20, 7}, {10, 30, 7}})
+	want := [][]float64{{0, 0, 0}, {0.5, 0.5, 0}, {1, 1, 0}}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("normalize() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEnvironmentalSelection(t *testing.T) {
+	tests := []struct {
+		name string
+		objs [][]float64
+		size int
+		want []int
+	}{
+		{"keeps everyone", [][]float64{{0, 1}, {1, 0}}, 2, []int{0, 1}},
+		{"drops the dominated point", [][]float64{{0, 1}, {1, 1}, {1, 0}}, 2, []int{0, 2}},
+		{"drops dominated points first", [][]float64{{0, 1}, {2, 2}, {1, 0}, {1, 1}}, 2, []int{0, 2}},
+		{"drops the crowded duplicate", [][]float64{{0, 1}, {0.5, 0.5}, {0.5, 0.5}, {1, 0}}, 3, []int{0, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := environmentalSelection(tt.objs, tt.size, 0.05); !slices.Equal(got, tt.want) {
+				t.Errorf("environmentalSelection() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvironmentalSelectionFitness(t *testing.T) {
+	// (0, 1) and (1, 0) need a shift of 1 to dominate each other, and the largest
+	// indicator value is 1, so each survivor's fitness is -exp(-1/κ) once (1, 1) is gone
+	kappa := 0.05
+	_, fitness := environmentalSelection([][]float64{{0, 1}, {1, 1}, {1, 0}}, 2, kappa)
+	want := -math.Exp(-1 / kappa)
+	for _, f := range fitness {
+		if math.Abs(f-want) > 1e-15 {
+			t.Errorf("fitness = %v, want %v", fitness, want)
+			break
+		}
+	}
+}
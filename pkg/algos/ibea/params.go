@@ -0,0 +1,49 @@
+package ibea
+
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Params holds configurable parameters for the IBEA algorithm.
+type Params struct {
+	PopulationSize int
+	// Kappa scales the indicator values in the fitness; smaller values make the
+	// fitness depend more on the closest competitors.
+	Kappa         float64
+	MutationFunc  problems.MutationFunc
+	CrossoverFunc problems.CrossoverFunc
+	Selection     selection.Selector // picks parents; nil runs binary tournaments on the indicator fitness
+	Seed          uint64             // seed of the run RNG; 0 picks a random seed
+	Workers       int                // goroutines evaluating offspring; 0 or 1 evaluates serially
+	Verbose       bool
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 100); err != nil {
+		return
+	}
+	if params.Kappa, err = m.Float("kappa", 0.05); err != nil {
+		return
+	}
+	if params.Verbose, err = m.Bool("verbose", false); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	if params.Selection, err = selection.FromMap(m, "selection"); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
@@ -0,0 +1,290 @@
+package island
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("island", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		if params.Seed == 0 {
+			// islands derive their seeds from the model seed, which is logged
+			params.Seed = rand.Uint64()
+		}
+		islands, err := IslandsFromMap(problem, m, params.Seed, generationLimit)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, islands, params, generationLimit, logger), nil
+	})
+}
+
+var _ algos.Algorithm = (*Algorithm)(nil)
+
+// Island is one sub-population of the model.
+type Island struct {
+	Algorithm algos.Algorithm
+	// StepsPerGeneration is the number of Algorithm steps in one model generation (at least 1).
+	// A steady-state island replaces a single pair per step, so it usually needs many more
+	// steps than a generational one to make comparable progress.
+	StepsPerGeneration int
+}
+
+// Algorithm runs several algorithms side by side, each on its own goroutine,
+// and periodically exchanges individuals between them.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params     Params
+	islands    []Island
+	loggedBest problems.Solution
+}
+
+// NewAlgorithm wraps islands into an island model. The islands should be built without
+// a logger and with distinct seeds; the model logs the best solution across all of them.
+func NewAlgorithm(
+	problem problems.Problem,
+	islands []Island,
+	params Params,
+	generationLimit int,
+	logger algos.ProgressLoggerProvider,
+) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+		islands:          islands,
+	}
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// modelKeys are the parameters of the model itself; those of the islands go in "island_params".
+var modelKeys = []string{
+	"islands", "island_algorithm", "island_params", "steps_per_generation",
+	"migration_interval", "migrants", "topology", "emigration", "immigration", "seed",
+}
+
+// IslandsFromMap builds homogeneous islands for the registry: "islands" instances of
+// the registered "island_algorithm", each getting the parameters in "island_params"
+// and its own seed derived from seed. Other keys that the model does not know are rejected.
+func IslandsFromMap(problem problems.Problem, m algos.ParamMap, seed uint64, generationLimit int) ([]Island, error) {
+	for key := range m {
+		if !slices.Contains(modelKeys, key) {
+			return nil, fmt.Errorf("unknown island model parameter %q (parameters of the islands go in \"island_params\")", key)
+		}
+	}
+	params, err := m.Params("island_params")
+	if err != nil {
+		return nil, err
+	}
+	count, err := m.Int("islands", 4)
+	if err != nil {
+		return nil, err
+	}
+	name, err := m.String("island_algorithm", "nsga2")
+	if err != nil {
+		return nil, err
+	}
+	steps, err := m.Int("steps_per_generation", 1)
+	if err != nil {
+		return nil, err
+	}
+	rng := problems.NewRand(seed)
+
+	islands := make([]Island, count)
+	for i := range islands {
+		islandParams := make(algos.ParamMap, len(params)+1)
+		for k, v := range params {
+			// every island follows its own run, so stateful parameters are not shared
+			if c, ok := v.(algos.Cloner); ok {
+				v = c.Clone()
+			} else if _, ok := v.(algos.Observer); ok && count > 1 {
+				return nil, fmt.Errorf("island parameter %q holds %T, which follows a single run and cannot be shared by %d islands", k, v, count)
+			}
+			islandParams[k] = v
+		}
+		islandParams["seed"] = int(rng.Uint64() >> 12)
+		alg, err := algos.New(name, problem, islandParams, generationLimit*steps, nil)
+		if err != nil {
+			return nil, err
+		}
+		islands[i] = Island{Algorithm: alg, StepsPerGeneration: steps}
+	}
+	return islands, nil
+}
+
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step advances every island by one generation in parallel and migrates
+// individuals every MigrationInterval generations.
+func (alg *Algorithm) Step() {
+	var wg sync.WaitGroup
+	for _, isl := range alg.islands {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range max(isl.StepsPerGeneration, 1) {
+				isl.Algorithm.Step()
+			}
+		}()
+	}
+	wg.Wait()
+	alg.Generation++
+
+	if alg.params.MigrationInterval > 0 && alg.Generation%alg.params.MigrationInterval == 0 {
+		alg.migrate()
+	}
+	alg.collect()
+
+	if alg.Solution != alg.loggedBest || alg.ParetoFront != nil {
+		alg.loggedBest = alg.Solution
+		alg.LogProgress(algos.GAStep{
+			Elapsed:     time.Since(alg.StartTimestamp),
+			Step:        alg.Generation,
+			Seed:        alg.RandSeed,
+			Solution:    alg.Solution,
+			ParetoFront: alg.ParetoFront,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// migrate selects emigrants of all islands first and only then inserts them,
+// so the outcome does not depend on the island order.
+func (alg *Algorithm) migrate() {
+	n := len(alg.islands)
+	incoming := make([][]problems.Solution, n)
+	for from, isl := range alg.islands {
+		emigrants := alg.params.Emigration(alg.Rand, isl.Algorithm.GetPopulation(), alg.params.Migrants)
+		// emigrants end up in several islands which evaluate concurrently,
+		// so their objectives must already be cached
+		alg.Evaluator.Evaluate(emigrants)
+		for _, to := range alg.params.Topology(from, n) {
+			incoming[to] = append(incoming[to], emigrants...)
+		}
+	}
+	for to, immigrants := range incoming {
+		if len(immigrants) == 0 {
+			continue
+		}
+		isl := alg.islands[to].Algorithm
+		isl.SetPopulation(alg.params.Immigration(alg.Rand, isl.GetPopulation(), immigrants))
+	}
+}
+
+// collect updates the best solution, the merged Pareto front and the evaluation count.
+func (alg *Algorithm) collect() {
+	alg.Evaluations = 0
+	var fronts [][]float64
+	for _, isl := range alg.islands {
+		if sol := isl.Algorithm.GetSolution(); algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+		if s, ok := isl.Algorithm.(interface{ State() algos.RunState }); ok {
+			state := s.State()
+			alg.Evaluations += state.Evaluations
+			fronts = append(fronts, state.ParetoFront...)
+		}
+	}
+	if fronts != nil {
+		alg.ParetoFront = nonDominated(fronts)
+	}
+}
+
+// Seed seeds every island with seedSolution.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	for _, isl := range alg.islands {
+		isl.Algorithm.Seed(seedSolution)
+	}
+	alg.Solution = seedSolution
+}
+
+// SetPopulation splits pop between the islands in the order of GetPopulation.
+// Before the first step islands receive equal shares.
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	sizes := make([]int, len(alg.islands))
+	total := 0
+	for i, isl := range alg.islands {
+		sizes[i] = len(isl.Algorithm.GetPopulation())
+		total += sizes[i]
+	}
+	if total == 0 && len(alg.islands) > 0 && len(pop)%len(alg.islands) == 0 {
+		for i := range sizes {
+			sizes[i] = len(pop) / len(alg.islands)
+		}
+		total = len(pop)
+	}
+	if total != len(pop) {
+		panic("Wrong population size")
+	}
+	for i, isl := range alg.islands {
+		isl.Algorithm.SetPopulation(pop[:sizes[i]])
+		pop = pop[sizes[i]:]
+	}
+}
+
+// GetPopulation returns the populations of all islands concatenated.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	var pop []problems.Solution
+	for _, isl := range alg.islands {
+		pop = append(pop, isl.Algorithm.GetPopulation()...)
+	}
+	return pop
+}
+
+// Islands returns the wrapped algorithms.
+func (alg *Algorithm) Islands() []Island {
+	return alg.islands
+}
+
+// nonDominated returns the objective vectors not dominated by any other vector (minimization).
+func nonDominated(points [][]float64) [][]float64 {
+	front := make([][]float64, 0, len(points))
+	for i, p := range points {
+		dominated := false
+		for j, q := range points {
+			if i != j && (dominates(q, p) || (j < i && equal(p, q))) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, p)
+		}
+	}
+	return front
+}
+
+func dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+func equal(a, b []float64) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
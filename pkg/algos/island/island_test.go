@@ -0,0 +1,96 @@
+package island
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/adaptive"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/sga"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestIslandsFromMap(t *testing.T) {
+	sphere := testutil.Sphere{Dimensions: 3}
+	mutation, err := adaptive.NewMutation([]string{"nudge"}, []problems.MutationFunc{testutil.Nudge}, adaptive.Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := algos.ParamMap{
+		"island_algorithm": "sga",
+		"islands":          3,
+		"island_params": algos.ParamMap{
+			"population_size": 10,
+			"mutation":        mutation,
+			"crossover":       problems.CrossoverFunc(testutil.Midpoint),
+		},
+	}
+	islands, err := IslandsFromMap(sphere, m, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[algos.Observer]bool{mutation: true}
+	for i, island := range islands {
+		observers := island.Algorithm.(*sga.Algorithm).Observers
+		if len(observers) != 1 {
+			t.Fatalf("island %d has %d observers, want its own mutation", i, len(observers))
+		}
+		if seen[observers[0]] {
+			t.Errorf("island %d shares its mutation", i)
+		}
+		seen[observers[0]] = true
+	}
+
+	m["island_params"].(algos.ParamMap)["observer"] = algos.ObserverFuncs{}
+	if _, err := IslandsFromMap(sphere, m, 1, 10); err == nil {
+		t.Error("IslandsFromMap() shared an observer that cannot be cloned")
+	}
+	m["islands"] = 1
+	if _, err := IslandsFromMap(sphere, m, 1, 10); err != nil {
+		t.Errorf("IslandsFromMap() rejected the observer of a single island: %v", err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	m := algos.ParamMap{
+		"island_algorithm":   "sga",
+		"islands":            3,
+		"migration_interval": 2,
+		"seed":               1,
+		"island_params": algos.ParamMap{
+			"population_size": 20,
+			"mutation":        problems.MutationFunc(testutil.Nudge),
+			"crossover":       problems.CrossoverFunc(testutil.Midpoint),
+		},
+	}
+	alg, err := algos.New("island", testutil.Sphere{Dimensions: 3}, m, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alg.Run(context.Background())
+	first := alg.GetSolution().Fitness()
+
+	model := alg.(*Algorithm)
+	model.GenerationLimit = 30
+	alg.Run(context.Background())
+	if last := alg.GetSolution().Fitness(); last >= first/2 {
+		t.Errorf("best fitness went from %v to %v", first, last)
+	}
+	best := math.Inf(1)
+	evaluations := 0
+	for _, isl := range model.Islands() {
+		best = min(best, isl.Algorithm.GetSolution().Fitness())
+		evaluations += isl.Algorithm.(*sga.Algorithm).Evaluations
+	}
+	if alg.GetSolution().Fitness() != best {
+		t.Errorf("model reports %v, the best island %v", alg.GetSolution().Fitness(), best)
+	}
+	if model.Evaluations != evaluations {
+		t.Errorf("model counts %d evaluations, the islands %d", model.Evaluations, evaluations)
+	}
+	if len(alg.GetPopulation()) != 60 {
+		t.Errorf("population of %d, want 60", len(alg.GetPopulation()))
+	}
+}
@@ -0,0 +1,216 @@
+package island
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Topology returns the islands that island from sends emigrants to, out of n islands.
+type Topology func(from, n int) []int
+
+// Ring sends emigrants to the next island, the last one sending to the first.
+func Ring(from, n int) []int {
+	if n < 2 {
+		return nil
+	}
+	return []int{(from + 1) % n}
+}
+
+// Full sends emigrants to every other island.
+func Full(from, n int) []int {
+	to := make([]int, 0, n-1)
+	for i := range n {
+		if i != from {
+			to = append(to, i)
+		}
+	}
+	return to
+}
+
+// Star connects every island with hub in both directions.
+func Star(hub int) Topology {
+	return func(from, n int) []int {
+		if from == hub {
+			return Full(from, n)
+		}
+		return []int{hub}
+	}
+}
+
+// FanIn makes every island feed hub, which sends nothing back,
+// e.g. several SSGA islands feeding one NSGA-II island.
+func FanIn(hub int) Topology {
+	return func(from, n int) []int {
+		if from == hub {
+			return nil
+		}
+		return []int{hub}
+	}
+}
+
+// EmigrantPolicy picks count individuals of pop that leave the island.
+// Emigrants are not copied: the same solutions stay in pop as well, which is
+// safe because solutions are not modified once created.
+type EmigrantPolicy func(rng *rand.Rand, pop []problems.Solution, count int) []problems.Solution
+
+// BestEmigrants sends the count best individuals in the order of ranked.
+func BestEmigrants(rng *rand.Rand, pop []problems.Solution, count int) []problems.Solution {
+	sorted := ranked(pop)
+	return sorted[:min(count, len(sorted))]
+}
+
+// RandomEmigrants sends count individuals chosen uniformly without replacement.
+func RandomEmigrants(rng *rand.Rand, pop []problems.Solution, count int) []problems.Solution {
+	count = min(count, len(pop))
+	emigrants := make([]problems.Solution, count)
+	for i, j := range rng.Perm(len(pop))[:count] {
+		emigrants[i] = pop[j]
+	}
+	return emigrants
+}
+
+// ImmigrantPolicy inserts immigrants into pop and returns the new population of the same size.
+type ImmigrantPolicy func(rng *rand.Rand, pop, immigrants []problems.Solution) []problems.Solution
+
+// ReplaceWorst replaces the worst individuals in the order of ranked with immigrants.
+func ReplaceWorst(rng *rand.Rand, pop, immigrants []problems.Solution) []problems.Solution {
+	next := ranked(pop)
+	count := min(len(immigrants), len(next))
+	copy(next[len(next)-count:], immigrants[:count])
+	return next
+}
+
+// ReplaceRandom replaces randomly chosen individuals with immigrants.
+func ReplaceRandom(rng *rand.Rand, pop, immigrants []problems.Solution) []problems.Solution {
+	next := make([]problems.Solution, len(pop))
+	copy(next, pop)
+	count := min(len(immigrants), len(next))
+	for i, j := range rng.Perm(len(next))[:count] {
+		next[j] = immigrants[i]
+	}
+	return next
+}
+
+// ReplaceWorstIfBetter replaces the worst individuals, but only with immigrants
+// that rank before them: that dominate them under the constraints, or that are
+// incomparable and better by algos.Better.
+func ReplaceWorstIfBetter(rng *rand.Rand, pop, immigrants []problems.Solution) []problems.Solution {
+	next := ranked(pop)
+	incoming := ranked(immigrants)
+	for i, j := 0, len(next)-1; i < len(incoming) && j >= 0; i, j = i+1, j-1 {
+		if !before(incoming[i], next[j]) {
+			break
+		}
+		next[j] = incoming[i]
+	}
+	return next
+}
+
+// TopologyByName resolves "ring", "full", "star" and "fanin" (the last two use island 0 as hub).
+func TopologyByName(name string) (Topology, error) {
+	switch name {
+	case "ring":
+		return Ring, nil
+	case "full":
+		return Full, nil
+	case "star":
+		return Star(0), nil
+	case "fanin":
+		return FanIn(0), nil
+	}
+	return nil, fmt.Errorf("unknown topology %q", name)
+}
+
+// EmigrantPolicyByName resolves "best" and "random".
+func EmigrantPolicyByName(name string) (EmigrantPolicy, error) {
+	switch name {
+	case "best":
+		return BestEmigrants, nil
+	case "random":
+		return RandomEmigrants, nil
+	}
+	return nil, fmt.Errorf("unknown emigrant policy %q", name)
+}
+
+// ImmigrantPolicyByName resolves "worst", "random" and "worst_if_better".
+func ImmigrantPolicyByName(name string) (ImmigrantPolicy, error) {
+	switch name {
+	case "worst":
+		return ReplaceWorst, nil
+	case "random":
+		return ReplaceRandom, nil
+	case "worst_if_better":
+		return ReplaceWorstIfBetter, nil
+	}
+	return nil, fmt.Errorf("unknown immigrant policy %q", name)
+}
+
+// ranked returns a copy of pop, best first: by the non-dominated front under
+// the constraints (algos.ConstrainedDominates), so feasible solutions come
+// before infeasible ones, and within a front by algos.Better. With a single
+// objective this is the order of algos.Better.
+func ranked(pop []problems.Solution) []problems.Solution {
+	n := len(pop)
+	domCount := make([]int, n)
+	dominatedSet := make([][]int, n)
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			if algos.ConstrainedDominates(pop[i], pop[j]) {
+				dominatedSet[i] = append(dominatedSet[i], j)
+				domCount[j]++
+			} else if algos.ConstrainedDominates(pop[j], pop[i]) {
+				dominatedSet[j] = append(dominatedSet[j], i)
+				domCount[i]++
+			}
+		}
+	}
+	front := make([]int, n)
+	var current []int
+	for i := range n {
+		if domCount[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for rank := 0; len(current) > 0; rank++ {
+		var next []int
+		for _, i := range current {
+			front[i] = rank
+			for _, j := range dominatedSet[i] {
+				domCount[j]--
+				if domCount[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		current = next
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if front[i] != front[j] {
+			return front[i] < front[j]
+		}
+		return algos.Better(pop[i], pop[j])
+	})
+	sorted := make([]problems.Solution, n)
+	for k, i := range order {
+		sorted[k] = pop[i]
+	}
+	return sorted
+}
+
+// before reports whether a ranks before b when the two are compared alone.
+func before(a, b problems.Solution) bool {
+	if algos.ConstrainedDominates(a, b) {
+		return true
+	}
+	return !algos.ConstrainedDominates(b, a) && algos.Better(a, b)
+}
@@ -0,0 +1,167 @@
+package island
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestTopologies(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology Topology
+		want     [][]int // by island
+	}{
+		{"ring", Ring, [][]int{{1}, {2}, {3}, {0}}},
+		{"full", Full, [][]int{{1, 2, 3}, {0, 2, 3}, {0, 1, 3}, {0, 1, 2}}},
+		{"star", Star(0), [][]int{{1, 2, 3}, {0}, {0}, {0}}},
+		{"fan-in", FanIn(0), [][]int{nil, {0}, {0}, {0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for from, want := range tt.want {
+				if got := tt.topology(from, 4); !slices.Equal(got, want) {
+					t.Errorf("island %d sends to %v, want %v", from, got, want)
+				}
+			}
+		})
+	}
+	if got := Ring(0, 1); got != nil {
+		t.Errorf("a single island sends to %v", got)
+	}
+}
+
+// objectives returns the objectives of every solution.
+func objectives(pop []problems.Solution) [][]float64 {
+	points := make([][]float64, len(pop))
+	for i, sol := range pop {
+		points[i] = sol.Objectives()
+	}
+	return points
+}
+
+func equalPoints(a, b [][]float64) bool {
+	return slices.EqualFunc(a, b, func(p, q []float64) bool { return slices.Equal(p, q) })
+}
+
+func TestRanked(t *testing.T) {
+	tests := []struct {
+		name string
+		pop  []problems.Solution
+		want [][]float64
+	}{
+		{"single objective", []problems.Solution{testutil.Point{3}, testutil.Point{1}, testutil.Point{2}}, [][]float64{{1}, {2}, {3}}},
+		// (3, 1) has the worst fitness but is on the first front with (1, 3)
+		{"fronts before fitness", []problems.Solution{testutil.Point{2, 4}, testutil.Point{3, 1}, testutil.Point{1, 3}}, [][]float64{{1, 3}, {3, 1}, {2, 4}}},
+		{"feasible first", []problems.Solution{
+			testutil.Constrained{Point: testutil.Point{0, 0}, Violation: 2},
+			testutil.Constrained{Point: testutil.Point{5, 5}},
+			testutil.Constrained{Point: testutil.Point{1, 1}, Violation: 1},
+		}, [][]float64{{5, 5}, {1, 1}, {0, 0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := objectives(ranked(tt.pop)); !equalPoints(got, tt.want) {
+				t.Errorf("ranked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmigrantPolicies(t *testing.T) {
+	pop := []problems.Solution{testutil.Point{4}, testutil.Point{1}, testutil.Point{3}, testutil.Point{2}}
+	rng := rand.New(rand.NewPCG(1, 1))
+	if got := objectives(BestEmigrants(rng, pop, 2)); !equalPoints(got, [][]float64{{1}, {2}}) {
+		t.Errorf("BestEmigrants() = %v, want [[1] [2]]", got)
+	}
+	if got := BestEmigrants(rng, pop, 10); len(got) != 4 {
+		t.Errorf("BestEmigrants() sent %d of 4 individuals", len(got))
+	}
+	random := RandomEmigrants(rng, pop, 3)
+	if len(random) != 3 {
+		t.Fatalf("RandomEmigrants() sent %d, want 3", len(random))
+	}
+	seen := map[float64]bool{}
+	for _, sol := range random {
+		if seen[sol.Fitness()] {
+			t.Errorf("RandomEmigrants() sent %v twice", sol)
+		}
+		seen[sol.Fitness()] = true
+	}
+	if pop[0].Fitness() != 4 || pop[1].Fitness() != 1 {
+		t.Error("emigration reordered the population")
+	}
+}
+
+func TestImmigrantPolicies(t *testing.T) {
+	pop := func() []problems.Solution {
+		return []problems.Solution{testutil.Point{4}, testutil.Point{1}, testutil.Point{3}, testutil.Point{2}}
+	}
+	immigrants := []problems.Solution{testutil.Point{5}, testutil.Point{0}}
+	tests := []struct {
+		name   string
+		policy ImmigrantPolicy
+		want   [][]float64
+	}{
+		{"worst", ReplaceWorst, [][]float64{{1}, {2}, {5}, {0}}},
+		// 0 replaces 4, 5 is worse than 3 and stays out
+		{"worst if better", ReplaceWorstIfBetter, [][]float64{{1}, {2}, {3}, {0}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := objectives(tt.policy(nil, pop(), immigrants)); !equalPoints(got, tt.want) {
+				t.Errorf("policy gave %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	next := ReplaceRandom(rand.New(rand.NewPCG(1, 1)), pop(), immigrants)
+	if len(next) != 4 {
+		t.Fatalf("ReplaceRandom() gave %d individuals, want 4", len(next))
+	}
+	kept := 0
+	for _, sol := range next {
+		if f := sol.Fitness(); f >= 1 && f <= 4 {
+			kept++
+		}
+	}
+	if kept != 2 || !slices.ContainsFunc(next, func(s problems.Solution) bool { return s.Fitness() == 5 }) {
+		t.Errorf("ReplaceRandom() = %v, want both immigrants and two residents", objectives(next))
+	}
+}
+
+func TestReplaceWorstIfBetterUnderConstraints(t *testing.T) {
+	pop := []problems.Solution{
+		testutil.Constrained{Point: testutil.Point{1}},
+		testutil.Constrained{Point: testutil.Point{9}},
+	}
+	// a fitter immigrant that violates the constraints does not replace a feasible resident
+	infeasible := []problems.Solution{testutil.Constrained{Point: testutil.Point{0}, Violation: 1}}
+	if got := objectives(ReplaceWorstIfBetter(nil, pop, infeasible)); !equalPoints(got, [][]float64{{1}, {9}}) {
+		t.Errorf("ReplaceWorstIfBetter() = %v, want the residents", got)
+	}
+	feasible := []problems.Solution{testutil.Constrained{Point: testutil.Point{5}}}
+	if got := objectives(ReplaceWorstIfBetter(nil, pop, feasible)); !equalPoints(got, [][]float64{{1}, {5}}) {
+		t.Errorf("ReplaceWorstIfBetter() = %v, want [[1] [5]]", got)
+	}
+}
+
+func TestPolicyByName(t *testing.T) {
+	for _, name := range []string{"ring", "full", "star", "fanin"} {
+		if _, err := TopologyByName(name); err != nil {
+			t.Error(err)
+		}
+	}
+	if _, err := TopologyByName("torus"); err == nil {
+		t.Error("TopologyByName() accepted an unknown name")
+	}
+	if _, err := EmigrantPolicyByName("worst"); err == nil {
+		t.Error("EmigrantPolicyByName() accepted an unknown name")
+	}
+	if _, err := ImmigrantPolicyByName("best"); err == nil {
+		t.Error("ImmigrantPolicyByName() accepted an unknown name")
+	}
+}
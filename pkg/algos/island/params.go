@@ -0,0 +1,48 @@
+package island
+
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Params holds the migration settings of the island model.
+type Params struct {
+	MigrationInterval int // generations between migrations; 0 disables migration
+	Migrants          int // individuals sent along every topology edge
+	Topology          Topology
+	Emigration        EmigrantPolicy
+	Immigration       ImmigrantPolicy
+	Seed              uint64 // seed of the migration RNG; 0 picks a random seed
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+// Topology and policies are given by name, see TopologyByName and the policy lookups.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.MigrationInterval, err = m.Int("migration_interval", 10); err != nil {
+		return
+	}
+	if params.Migrants, err = m.Int("migrants", 2); err != nil {
+		return
+	}
+	name, err := m.String("topology", "ring")
+	if err != nil {
+		return
+	}
+	if params.Topology, err = TopologyByName(name); err != nil {
+		return
+	}
+	if name, err = m.String("emigration", "best"); err != nil {
+		return
+	}
+	if params.Emigration, err = EmigrantPolicyByName(name); err != nil {
+		return
+	}
+	if name, err = m.String("immigration", "worst"); err != nil {
+		return
+	}
+	if params.Immigration, err = ImmigrantPolicyByName(name); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
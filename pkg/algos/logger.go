@@ -1,71 +1,221 @@
 package algos
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+// ProgressLoggerProvider receives the problem and the progress of a run.
+// The first logged record is expected to be the problem, every following one a step.
 type ProgressLoggerProvider interface {
-	InitLogging()
-	LogProblem(problem problems.Problem)
-	LogStep(step any)
-	Log(obj any)
+	InitLogging() error
+	LogProblem(problem problems.Problem) error
+	LogStep(step any) error
+	Log(obj any) error
+	// Flush writes buffered records to the underlying sink.
+	Flush() error
+	// Close flushes and releases the sink; the logger must not be used afterwards.
+	Close() error
 }
 
+// ExistingLogPolicy tells InitLogging what to do with an already existing log file.
+type ExistingLogPolicy int
+
+const (
+	// RefuseExisting makes InitLogging fail.
+	RefuseExisting ExistingLogPolicy = iota
+	// TruncateExisting discards the old log.
+	TruncateExisting
+	// RotateExisting renames the old log to "<path>.<n>" with the smallest free n.
+	RotateExisting
+	// AppendExisting keeps writing after the old records, e.g. when resuming a run.
+	AppendExisting
+)
+
+var ErrLoggerClosed = errors.New("logger is closed")
+
+// ProgressLogger writes records as JSON lines to a file, which it keeps open and buffered.
 type ProgressLogger struct {
 	filepath string
+	// Existing is applied by InitLogging, the zero value refuses to touch an old log.
+	Existing ExistingLogPolicy
+	file     *os.File
+	writer   *bufio.Writer
+	encoder  *json.Encoder
+	closed   bool
 }
 
-func NewProgressLogger(filepath string) ProgressLoggerProvider {
+func NewProgressLogger(filepath string) *ProgressLogger {
+	if filepath == "" {
+		filepath = "progress-log.jsonl"
+	}
 	return &ProgressLogger{
 		filepath: filepath,
 	}
 }
 
-func (pl *ProgressLogger) InitLogging() {
-	if pl.filepath == "" {
-		pl.filepath = "progress-log.jsonl"
+// InitLogging opens the log file according to the Existing policy.
+// Logging without InitLogging appends to the file, creating it if needed.
+func (pl *ProgressLogger) InitLogging() error {
+	if pl.file != nil {
+		if err := pl.Close(); err != nil {
+			return err
+		}
 	}
 
-	if _, err := os.Stat(pl.filepath); !errors.Is(err, os.ErrNotExist) {
-		err = os.Remove(pl.filepath)
-		if err != nil {
-			panic(fmt.Sprintf("error removing old log file: %v", err))
+	flags := os.O_CREATE | os.O_WRONLY
+	_, err := os.Stat(pl.filepath)
+	exists := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking log file: %w", err)
+	}
+
+	switch pl.Existing {
+	case RefuseExisting:
+		if exists {
+			return fmt.Errorf("%s progress log file already exists", pl.filepath)
 		}
+		flags |= os.O_EXCL
+	case TruncateExisting:
+		flags |= os.O_TRUNC
+	case RotateExisting:
+		if exists {
+			if err := rotate(pl.filepath); err != nil {
+				return err
+			}
+		}
+		flags |= os.O_EXCL
+	case AppendExisting:
+		flags |= os.O_APPEND
 	}
+	return pl.open(flags)
+}
 
-	if _, err := os.Stat(pl.filepath); errors.Is(err, os.ErrNotExist) {
-		file, err := os.Create(pl.filepath)
+// TruncateLog drops the records of the log at path whose step is past step,
+// e.g. those a crashed run wrote after the checkpoint it is resumed from.
+// Records without a step, like the problem header, are kept, and a last record
+// cut short by a crash is dropped. The file is replaced atomically.
+func TruncateLog(path string, step int) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	reader, writer := bufio.NewReader(in), bufio.NewWriter(tmp)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var record struct {
+				Step *int `json:"step"`
+			}
+			if jsonErr := json.Unmarshal(line, &record); jsonErr != nil {
+				// a crash may cut the last record short
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				tmp.Close()
+				return fmt.Errorf("truncating log: %w", jsonErr)
+			}
+			if record.Step == nil || *record.Step <= step {
+				writer.Write(line)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
 		if err != nil {
-			panic(fmt.Sprintf("error creating log file: %v", err))
+			tmp.Close()
+			return fmt.Errorf("truncating log: %w", err)
 		}
-		file.Close()
-	} else {
-		panic(fmt.Sprintf("%s progress log file already exists", pl.filepath))
 	}
+	if err := errors.Join(writer.Flush(), tmp.Close()); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
-func (pl *ProgressLogger) LogProblem(problem problems.Problem) {
-	pl.Log(problem)
+func rotate(path string) error {
+	for n := 1; ; n++ {
+		rotated := fmt.Sprintf("%s.%d", path, n)
+		_, err := os.Stat(rotated)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotating log file: %w", err)
+		}
+		if err := os.Rename(path, rotated); err != nil {
+			return fmt.Errorf("rotating log file: %w", err)
+		}
+		return nil
+	}
 }
 
-func (pl *ProgressLogger) LogStep(step any) {
-	pl.Log(step)
+func (pl *ProgressLogger) open(flags int) error {
+	file, err := os.OpenFile(pl.filepath, flags, 0600)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+	pl.file = file
+	pl.writer = bufio.NewWriter(file)
+	pl.encoder = json.NewEncoder(pl.writer)
+	pl.closed = false
+	return nil
 }
 
-func (pl *ProgressLogger) Log(obj any) {
-	file, err := os.OpenFile(pl.filepath, os.O_APPEND|os.O_WRONLY, 0600)
+// LogProblem writes the problem as the log header, tagged with its name.
+func (pl *ProgressLogger) LogProblem(problem problems.Problem) error {
+	header, err := problems.MarshalProblem(problem)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	return pl.Log(json.RawMessage(header))
+}
+
+func (pl *ProgressLogger) LogStep(step any) error {
+	return pl.Log(step)
+}
+
+func (pl *ProgressLogger) Log(obj any) error {
+	if pl.closed {
+		return ErrLoggerClosed
+	}
+	if pl.file == nil {
+		if err := pl.open(os.O_CREATE | os.O_WRONLY | os.O_APPEND); err != nil {
+			return err
+		}
 	}
-	defer file.Close()
+	return pl.encoder.Encode(obj)
+}
+
+func (pl *ProgressLogger) Flush() error {
+	if pl.writer == nil {
+		return nil
+	}
+	return pl.writer.Flush()
+}
 
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(obj); err != nil {
-		panic(err)
+func (pl *ProgressLogger) Close() error {
+	if pl.file == nil {
+		pl.closed = true
+		return nil
 	}
+	err := errors.Join(pl.writer.Flush(), pl.file.Close())
+	pl.file, pl.writer, pl.encoder = nil, nil, nil
+	pl.closed = true
+	return err
 }
@@ -0,0 +1,53 @@
+package algos
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTruncateLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run.jsonl")
+	log := `{"name":"sphere"}
+{"step":1,"solution":[1]}
+{"step":100,"solution":[0.5]}
+{"step":101,"solution":[0.25]}
+{"step":150,"stop_reason":"deadline"}
+`
+	if err := os.WriteFile(path, []byte(log), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := TruncateLog(path, 100); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"name":"sphere"}
+{"step":1,"solution":[1]}
+{"step":100,"solution":[0.5]}
+`
+	if string(got) != want {
+		t.Errorf("truncated log:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := os.WriteFile(path, []byte("{\"step\":1}\n{\"step\":2,\"sol"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := TruncateLog(path, 100); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "{\"step\":1}\n" {
+		t.Errorf("log with a cut record truncated to %q", got)
+	}
+	if err := os.WriteFile(path, []byte("{\"step\":1}\nnot json\n{\"step\":2}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := TruncateLog(path, 100); err == nil {
+		t.Error("TruncateLog() accepted a corrupt record")
+	}
+	if err := TruncateLog(filepath.Join(t.TempDir(), "missing.jsonl"), 1); err == nil {
+		t.Error("TruncateLog() accepted a missing log")
+	}
+}
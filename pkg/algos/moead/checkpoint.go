@@ -0,0 +1,48 @@
+package moead
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// checkpointState keeps the ideal point, which may hold values of solutions that
+// have since been replaced and therefore cannot be recomputed from the population.
+type checkpointState struct {
+	Ideal []float64 `json:"ideal,omitempty"`
+}
+
+// Checkpoint captures the full state of the run. The population is stored in
+// subproblem order; weights and neighborhoods follow from the params.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("moead", alg.population)
+	if err != nil {
+		return nil, err
+	}
+	cp.State, err = json.Marshal(checkpointState{Ideal: alg.ideal})
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("moead", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding moead state: %w", err)
+		}
+	}
+	if len(state.Ideal) != 0 && len(state.Ideal) != len(alg.weights[0]) {
+		return fmt.Errorf("checkpoint ideal point has %d objectives, expected %d", len(state.Ideal), len(alg.weights[0]))
+	}
+	alg.population = pop
+	alg.ideal = state.Ideal
+	return nil
+}
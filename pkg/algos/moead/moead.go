@@ -0,0 +1,256 @@
+package moead
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/nsga3"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("moead", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		if objectives := len(problem.RandomSolution(problems.NewRand(1)).Objectives()); objectives < 2 {
+			return nil, fmt.Errorf("moead needs at least two objectives, %s has %d", problem.Name(), objectives)
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements MOEA/D (Zhang and Li, 2007): the problem is decomposed into
+// one scalar subproblem per weight vector and every subproblem is optimized with
+// the help of its neighbors. population[i] is the current solution of subproblem i.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params     Params
+	weights    [][]float64
+	neighbors  [][]int
+	ideal      []float64
+	population []problems.Solution
+}
+
+// NewAlgorithm creates a new MOEA/D instance. The weight vectors are placed for
+// the number of objectives of the problem's solutions, which must be at least two.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	if len(alg.Solution.Objectives()) < 2 {
+		panic("moead: " + problem.Name() + " has a single objective")
+	}
+	if alg.params.Scalarization == nil {
+		alg.params.Scalarization = Tchebycheff
+	}
+	alg.weights = weightVectors(len(alg.Solution.Objectives()), params.PopulationSize, params.Divisions)
+	alg.params.PopulationSize = len(alg.weights)
+	alg.neighbors = neighborhoods(alg.weights, max(min(params.NeighborhoodSize, len(alg.weights)), 2))
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Weights returns the weight vectors of the subproblems.
+func (alg *Algorithm) Weights() [][]float64 {
+	return alg.weights
+}
+
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = alg.params.MutationFunc(alg.Rand, seedSolution)
+	}
+	alg.population[0] = seedSolution
+	alg.Solution = seedSolution
+	alg.ideal = nil
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = append([]problems.Solution(nil), pop...)
+	alg.ideal = nil
+}
+
+// GetPopulation returns the solutions of the subproblems.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes the MOEA/D process until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step performs one MOEA/D generation. Every subproblem breeds one child from its
+// neighborhood; the children are evaluated together and then, subproblem by
+// subproblem, update the ideal point and replace the neighbors they improve on.
+// Breeding the whole generation before evaluating it keeps the worker pool busy
+// and makes runs independent of the number of workers.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+	if alg.ideal == nil {
+		alg.Evaluator.Evaluate(alg.population)
+		alg.ideal = make([]float64, len(alg.weights[0]))
+		for i := range alg.ideal {
+			alg.ideal[i] = math.Inf(1)
+		}
+		for _, sol := range alg.population {
+			alg.updateIdeal(sol)
+		}
+	}
+
+	alg.Generation++
+
+	order := alg.Rand.Perm(len(alg.population))
+	children := make([]problems.Solution, len(order))
+	pools := make([][]int, len(order))
+	for k, i := range order {
+		pools[k] = alg.neighbors[i]
+		if alg.Rand.Float64() >= alg.params.NeighborhoodProb {
+			pools[k] = nil // the whole population
+		}
+		a, b := alg.pick(pools[k]), alg.pick(pools[k])
+		child := alg.params.CrossoverFunc(alg.Rand, alg.population[a], alg.population[b])[0]
+		children[k] = alg.params.MutationFunc(alg.Rand, child)
+	}
+	alg.Evaluator.Evaluate(children)
+	alg.Evaluations += len(children)
+
+	for k, child := range children {
+		alg.updateIdeal(child)
+		alg.replace(child, pools[k])
+		if algos.Better(child, alg.Solution) {
+			alg.Solution = child
+		}
+	}
+
+	pareto := nonDominated(alg.population)
+	alg.ParetoFront = pareto
+	if !alg.params.Verbose {
+		pareto = nil
+	}
+	alg.LogProgress(algos.GAStep{
+		Elapsed:     time.Since(alg.StartTimestamp),
+		Seed:        alg.RandSeed,
+		Step:        alg.Generation,
+		ParetoFront: pareto,
+		Solution:    alg.Solution,
+	})
+	alg.NotifyObservers()
+}
+
+// pick returns a random subproblem of pool, or of the whole population if pool is nil.
+func (alg *Algorithm) pick(pool []int) int {
+	if pool == nil {
+		return alg.Rand.IntN(len(alg.population))
+	}
+	return pool[alg.Rand.IntN(len(pool))]
+}
+
+// replace puts child into at most MaxReplacements subproblems of pool it solves better.
+func (alg *Algorithm) replace(child problems.Solution, pool []int) {
+	if pool == nil {
+		pool = alg.Rand.Perm(len(alg.population))
+	} else {
+		pool = append([]int(nil), pool...)
+		alg.Rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	}
+	replaced := 0
+	for _, j := range pool {
+		if replaced >= max(alg.params.MaxReplacements, 1) {
+			return
+		}
+		g := alg.params.Scalarization
+		if g(child.Objectives(), alg.weights[j], alg.ideal) <= g(alg.population[j].Objectives(), alg.weights[j], alg.ideal) {
+			alg.population[j] = child
+			replaced++
+		}
+	}
+}
+
+func (alg *Algorithm) updateIdeal(sol problems.Solution) {
+	for i, v := range sol.Objectives() {
+		alg.ideal[i] = min(alg.ideal[i], v)
+	}
+}
+
+// initPopulation creates the initial population randomly.
+func (alg *Algorithm) initPopulation() {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.population {
+		alg.population[i] = alg.Problem.RandomSolution(alg.Rand)
+	}
+	alg.ideal = nil
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+// weightVectors returns the Das–Dennis lattice with the given divisions or, if
+// divisions is 0, the coarsest one with at least size points. With two or more
+// objectives size-1 divisions always give enough points.
+func weightVectors(objectives, size, divisions int) [][]float64 {
+	if divisions > 0 {
+		return nsga3.ReferencePoints(objectives, divisions)
+	}
+	for divisions = 1; divisions < size-1; divisions++ {
+		if w := nsga3.ReferencePoints(objectives, divisions); len(w) >= size {
+			return w
+		}
+	}
+	return nsga3.ReferencePoints(objectives, max(divisions, 1))
+}
+
+// neighborhoods returns for every weight vector the indexes of its t closest
+// weight vectors, itself included.
+func neighborhoods(weights [][]float64, t int) [][]int {
+	result := make([][]int, len(weights))
+	for i, w := range weights {
+		order := make([]int, len(weights))
+		dist := make([]float64, len(weights))
+		for j, v := range weights {
+			order[j] = j
+			for k := range w {
+				dist[j] += (w[k] - v[k]) * (w[k] - v[k])
+			}
+		}
+		sort.SliceStable(order, func(a, b int) bool { return dist[order[a]] < dist[order[b]] })
+		result[i] = order[:t]
+	}
+	return result
+}
+
+// nonDominated returns the objectives of the non-dominated solutions of pop.
+func nonDominated(pop []problems.Solution) [][]float64 {
+	var front [][]float64
+	for i, a := range pop {
+		dominated := false
+		for j, b := range pop {
+			if i != j && metrics.Dominates(b.Objectives(), a.Objectives()) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			front = append(front, a.Objectives())
+		}
+	}
+	return front
+}
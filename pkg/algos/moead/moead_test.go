@@ -0,0 +1,129 @@
+package moead
+
+import (
+	"context"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/zdt"
+)
+
+func TestScalarizations(t *testing.T) {
+	origin := []float64{0, 0}
+	tests := []struct {
+		name        string
+		g           Scalarization
+		f, w, ideal []float64
+		want        float64
+	}{
+		{"weighted sum", WeightedSum, []float64{1, 2}, []float64{0.5, 0.5}, origin, 1.5},
+		{"weighted sum ignores the ideal point", WeightedSum, []float64{1, 2}, []float64{0.5, 0.5}, []float64{1, 1}, 1.5},
+		{"Tchebycheff", Tchebycheff, []float64{1, 3}, []float64{0.5, 0.5}, origin, 1.5},
+		{"Tchebycheff from the ideal point", Tchebycheff, []float64{1, 3}, []float64{0.5, 0.5}, []float64{1, 2}, 0.5},
+		// the zero weight is raised to 1e-6
+		{"Tchebycheff with a zero weight", Tchebycheff, []float64{2, 3}, []float64{1, 0}, origin, 2},
+		{"PBI on the weight direction", PBI(5), []float64{1, 1}, []float64{0.5, 0.5}, origin, math.Sqrt2},
+		// distance 1 along (1, 0) and 1 across it
+		{"PBI off the weight direction", PBI(5), []float64{1, 1}, []float64{1, 0}, origin, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g(tt.f, tt.w, tt.ideal); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("scalarization = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, err := ScalarizationByName("chebyshev", 5); err == nil {
+		t.Error("ScalarizationByName() accepted an unknown name")
+	}
+}
+
+func TestWeightVectors(t *testing.T) {
+	tests := []struct {
+		name                        string
+		objectives, size, divisions int
+		want                        int
+	}{
+		{"two objectives, exact size", 2, 10, 0, 10},
+		{"three objectives, exact lattice", 3, 91, 0, 91},
+		{"three objectives, next lattice", 3, 92, 0, 105},
+		{"divisions override the size", 3, 10, 12, 91},
+		{"tiny population", 2, 1, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weightVectors(tt.objectives, tt.size, tt.divisions); len(got) != tt.want {
+				t.Errorf("%d weight vectors, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestNeighborhoods(t *testing.T) {
+	weights := [][]float64{{0, 1}, {0.25, 0.75}, {0.5, 0.5}, {0.75, 0.25}, {1, 0}}
+	tests := []struct {
+		t    int
+		want [][]int
+	}{
+		{1, [][]int{{0}, {1}, {2}, {3}, {4}}},
+		{3, [][]int{{0, 1, 2}, {0, 1, 2}, {1, 2, 3}, {2, 3, 4}, {2, 3, 4}}},
+		{5, [][]int{{0, 1, 2, 3, 4}, {0, 1, 2, 3, 4}, {0, 1, 2, 3, 4}, {0, 1, 2, 3, 4}, {0, 1, 2, 3, 4}}},
+	}
+	for _, tt := range tests {
+		got := neighborhoods(weights, tt.t)
+		for i := range got {
+			if len(got[i]) != tt.t || got[i][0] != i {
+				t.Fatalf("T = %d: neighborhood %v of %d", tt.t, got[i], i)
+			}
+			slices.Sort(got[i])
+			if !slices.Equal(got[i], tt.want[i]) {
+				t.Errorf("T = %d: neighborhood %v of %d, want %v", tt.t, got[i], i, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestSingleObjective(t *testing.T) {
+	params := algos.ParamMap{"mutation": zdt.ZDT1MutationFunc(), "crossover": zdt.ZDT1CrossoverFunc()}
+	if _, err := algos.New("moead", testutil.Sphere{Dimensions: 2}, params, 10, nil); err == nil {
+		t.Error("New() accepted a single-objective problem")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("NewAlgorithm() accepted a single-objective problem")
+		}
+	}()
+	NewAlgorithm(testutil.Sphere{Dimensions: 2}, Params{PopulationSize: 20}, 10, nil)
+}
+
+func TestZDT1(t *testing.T) {
+	params := Params{
+		PopulationSize:   20,
+		NeighborhoodSize: 5,
+		NeighborhoodProb: 0.9,
+		MaxReplacements:  2,
+		MutationFunc:     zdt.ZDT1MutationFunc(),
+		CrossoverFunc:    zdt.ZDT1CrossoverFunc(),
+		Seed:             1,
+	}
+	alg := NewAlgorithm(zdt.NewZDT1Problem(10), params, 1, nil)
+	if len(alg.Weights()) != 20 {
+		t.Fatalf("%d subproblems, want 20", len(alg.Weights()))
+	}
+	alg.Run(context.Background())
+	reference := zdt.ZDT1Front(100)
+	first := metrics.IGD(alg.ParetoFront, reference)
+
+	alg.GenerationLimit = 200
+	alg.Run(context.Background())
+	if len(alg.GetPopulation()) != 20 {
+		t.Fatalf("population of %d, want 20", len(alg.GetPopulation()))
+	}
+	if last := metrics.IGD(alg.ParetoFront, reference); last >= first/2 {
+		t.Errorf("IGD went from %v to %v", first, last)
+	}
+}
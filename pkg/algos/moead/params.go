@@ -0,0 +1,73 @@
+package moead
+
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Params holds configurable parameters for the MOEA/D algorithm.
+type Params struct {
+	// PopulationSize is the desired number of subproblems. The weight vectors are
+	// a Das–Dennis lattice, so the actual number is the smallest lattice size not
+	// below it (exactly PopulationSize for two objectives).
+	PopulationSize int
+	// Divisions sets the lattice directly and overrides PopulationSize when positive.
+	Divisions int
+	// NeighborhoodSize is the number of closest weight vectors mating and replacement are restricted to.
+	NeighborhoodSize int
+	// NeighborhoodProb is the probability of mating within the neighborhood rather than the whole population.
+	NeighborhoodProb float64
+	// MaxReplacements limits how many neighbors a single child may replace.
+	MaxReplacements int
+	Scalarization   Scalarization // Tchebycheff if nil
+	MutationFunc    problems.MutationFunc
+	CrossoverFunc   problems.CrossoverFunc
+	Seed            uint64 // seed of the run RNG; 0 picks a random seed
+	Workers         int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+	Verbose         bool
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 100); err != nil {
+		return
+	}
+	if params.Divisions, err = m.Int("divisions", 0); err != nil {
+		return
+	}
+	if params.NeighborhoodSize, err = m.Int("neighborhood_size", 20); err != nil {
+		return
+	}
+	if params.NeighborhoodProb, err = m.Float("neighborhood_prob", 0.9); err != nil {
+		return
+	}
+	if params.MaxReplacements, err = m.Int("max_replacements", 2); err != nil {
+		return
+	}
+	name, err := m.String("scalarization", "tchebycheff")
+	if err != nil {
+		return
+	}
+	theta, err := m.Float("theta", 5)
+	if err != nil {
+		return
+	}
+	if params.Scalarization, err = ScalarizationByName(name, theta); err != nil {
+		return
+	}
+	if params.Verbose, err = m.Bool("verbose", false); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
@@ -0,0 +1,67 @@
+package moead
+
+import (
+	"fmt"
+	"math"
+)
+
+// Scalarization turns the objectives f of a solution into the single value a
+// subproblem with the given weight vector minimizes; ideal is the best value
+// seen so far for every objective.
+type Scalarization func(f, weight, ideal []float64) float64
+
+// WeightedSum is the weighted sum of the objectives. It cannot reach the
+// non-convex parts of a front.
+func WeightedSum(f, weight, ideal []float64) float64 {
+	sum := 0.0
+	for i := range f {
+		sum += weight[i] * f[i]
+	}
+	return sum
+}
+
+// Tchebycheff is the largest weighted distance from the ideal point over all objectives.
+func Tchebycheff(f, weight, ideal []float64) float64 {
+	worst := math.Inf(-1)
+	for i := range f {
+		// a zero weight would ignore the objective entirely
+		worst = max(worst, max(weight[i], 1e-6)*math.Abs(f[i]-ideal[i]))
+	}
+	return worst
+}
+
+// PBI is the penalty-based boundary intersection: the distance from the ideal
+// point along the weight direction plus theta times the distance from that direction.
+func PBI(theta float64) Scalarization {
+	return func(f, weight, ideal []float64) float64 {
+		norm := 0.0
+		for _, w := range weight {
+			norm += w * w
+		}
+		norm = math.Sqrt(norm)
+		along := 0.0
+		for i := range f {
+			along += (f[i] - ideal[i]) * weight[i]
+		}
+		along /= norm
+		across := 0.0
+		for i := range f {
+			d := f[i] - ideal[i] - along*weight[i]/norm
+			across += d * d
+		}
+		return along + theta*math.Sqrt(across)
+	}
+}
+
+// ScalarizationByName resolves "tchebycheff", "weighted_sum" and "pbi" (with penalty theta).
+func ScalarizationByName(name string, theta float64) (Scalarization, error) {
+	switch name {
+	case "tchebycheff":
+		return Tchebycheff, nil
+	case "weighted_sum":
+		return WeightedSum, nil
+	case "pbi":
+		return PBI(theta), nil
+	}
+	return nil, fmt.Errorf("unknown scalarization %q", name)
+}
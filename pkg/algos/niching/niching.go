@@ -0,0 +1,304 @@
+// Package niching keeps a population diverse: fitness sharing, clearing and
+// deterministic crowding spread it over several niches, and duplicate
+// elimination keeps clones out of it. Solutions are compared with
+// problems.Distance unless Niching.Distance says otherwise.
+package niching
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Method is a niching method.
+type Method int
+
+const (
+	// None leaves the fitness alone.
+	None Method = iota
+	// Sharing (Goldberg and Richardson, 1987) worsens the fitness of every
+	// solution by its niche count, the sum of 1 - (d/Radius)^Alpha over the
+	// solutions at a distance d below Radius, itself included.
+	Sharing
+	// Clearing (Pétrowski, 1996) keeps the fitness of the Capacity best
+	// solutions of every niche of Radius and clears the others to +Inf.
+	Clearing
+	// Crowding lets every child compete with the closer of its parents for its
+	// place in the population (deterministic crowding, Mahfoud 1995).
+	Crowding
+)
+
+// MethodByName resolves "none", "sharing", "clearing" and "crowding".
+func MethodByName(name string) (Method, error) {
+	switch name {
+	case "none":
+		return None, nil
+	case "sharing":
+		return Sharing, nil
+	case "clearing":
+		return Clearing, nil
+	case "crowding":
+		return Crowding, nil
+	}
+	return 0, fmt.Errorf("unknown niching %q", name)
+}
+
+// Niching configures diversity preservation. The zero value disables it.
+type Niching struct {
+	Method   Method
+	Radius   float64 // niche radius σ of sharing and clearing
+	Alpha    float64 // shape of the sharing function; 1 is triangular
+	Capacity int     // solutions clearing keeps per niche
+	// Deduplicate discards children within DuplicateRadius of a member of the
+	// population; a radius of 0 discards exact duplicates only.
+	Deduplicate     bool
+	DuplicateRadius float64
+	// Distance measures how far apart two solutions are; nil uses problems.Distance.
+	Distance func(a, b problems.Solution) float64
+}
+
+// FromMap reads "niching" (default "none"), "niche_radius", "sharing_alpha"
+// (default 1), "niche_capacity" (default 1), "deduplicate" (default false) and
+// "duplicate_radius" (default 0) from a registry parameter map.
+func FromMap(m algos.ParamMap) (n Niching, err error) {
+	method, err := m.String("niching", "none")
+	if err != nil {
+		return
+	}
+	if n.Method, err = MethodByName(method); err != nil {
+		return
+	}
+	if n.Radius, err = m.Float("niche_radius", 0); err != nil {
+		return
+	}
+	if n.Alpha, err = m.Float("sharing_alpha", 1); err != nil {
+		return
+	}
+	if n.Capacity, err = m.Int("niche_capacity", 1); err != nil {
+		return
+	}
+	if n.Deduplicate, err = m.Bool("deduplicate", false); err != nil {
+		return
+	}
+	if n.DuplicateRadius, err = m.Float("duplicate_radius", 0); err != nil {
+		return
+	}
+	if (n.Method == Sharing || n.Method == Clearing) && n.Radius <= 0 {
+		return n, fmt.Errorf("%s needs a positive \"niche_radius\"", method)
+	}
+	if n.Capacity < 1 {
+		return n, errors.New(`"niche_capacity" must be positive`)
+	}
+	return
+}
+
+// Reshapes reports whether the method changes the fitness (sharing and clearing).
+func (n Niching) Reshapes() bool {
+	return n.Method == Sharing || n.Method == Clearing
+}
+
+// Fitness returns the fitness of every solution of pop under sharing or
+// clearing, given their raw fitness; other methods return fitness unchanged.
+func (n Niching) Fitness(pop []problems.Solution, fitness []float64) []float64 {
+	niched := make([]float64, len(pop))
+	switch n.Method {
+	case Sharing:
+		for i, count := range n.Counts(pop) {
+			niched[i] = shared(fitness[i], count)
+		}
+	case Clearing:
+		for i, cleared := range n.Cleared(pop, fitness) {
+			niched[i] = fitness[i]
+			if cleared {
+				niched[i] = math.Inf(1)
+			}
+		}
+	default:
+		copy(niched, fitness)
+	}
+	return niched
+}
+
+// FitnessOf returns the fitness of sol alone under sharing or clearing, with
+// len(pop) distance computations instead of len(pop)² for Fitness. Clearing
+// then clears sol if Capacity better solutions lie within Radius of it, which
+// approximates the niches that Fitness forms around the best solutions.
+func (n Niching) FitnessOf(sol problems.Solution, pop []problems.Solution, fitness func(problems.Solution) float64) float64 {
+	f := fitness(sol)
+	switch n.Method {
+	case Sharing:
+		count := 0.0
+		for _, other := range pop {
+			count += n.share(n.Measure(sol, other))
+		}
+		// sol counts itself even if it is not a member of pop yet
+		return shared(f, max(count, 1))
+	case Clearing:
+		better := 0
+		for _, other := range pop {
+			if fitness(other) < f && n.Measure(sol, other) < n.Radius {
+				better++
+			}
+		}
+		if better >= n.Capacity {
+			return math.Inf(1)
+		}
+	}
+	return f
+}
+
+// Counts returns the niche count of every solution of pop.
+func (n Niching) Counts(pop []problems.Solution) []float64 {
+	counts := make([]float64, len(pop))
+	for i := range pop {
+		counts[i]++
+		for j := i + 1; j < len(pop); j++ {
+			sh := n.share(n.Measure(pop[i], pop[j]))
+			counts[i] += sh
+			counts[j] += sh
+		}
+	}
+	return counts
+}
+
+// Cleared reports which solutions of pop clearing removes from their niche.
+// Going from the best to the worst fitness, every solution not cleared yet
+// keeps the Capacity best solutions within Radius and clears the others.
+func (n Niching) Cleared(pop []problems.Solution, fitness []float64) []bool {
+	order := make([]int, len(pop))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return fitness[order[a]] < fitness[order[b]] })
+	cleared := make([]bool, len(pop))
+	for a, i := range order {
+		if cleared[i] {
+			continue
+		}
+		winners := 1
+		for _, j := range order[a+1:] {
+			if cleared[j] || n.Measure(pop[i], pop[j]) >= n.Radius {
+				continue
+			}
+			if winners < n.Capacity {
+				winners++
+			} else {
+				cleared[j] = true
+			}
+		}
+	}
+	return cleared
+}
+
+// Sort orders pop from the best to the worst solution by their niched
+// fitness, which it returns in the new order. Infeasible solutions come after feasible ones, ordered by violation,
+// unless c applies penalties; stochastic ranking is treated like constrained
+// domination.
+func (n Niching) Sort(pop []problems.Solution, c algos.Constraints) []float64 {
+	fitness := make([]float64, len(pop))
+	for i, sol := range pop {
+		fitness[i] = c.Fitness(sol)
+	}
+	niched := n.Fitness(pop, fitness)
+	violation := make([]float64, len(pop))
+	if c.Handling != algos.Penalty {
+		for i, sol := range pop {
+			violation[i] = problems.Violation(sol)
+		}
+	}
+	order := make([]int, len(pop))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if violation[i] != violation[j] {
+			return violation[i] < violation[j]
+		}
+		return niched[i] < niched[j]
+	})
+	sorted := make([]problems.Solution, len(pop))
+	sortedFitness := make([]float64, len(pop))
+	for k, i := range order {
+		sorted[k], sortedFitness[k] = pop[i], niched[i]
+	}
+	copy(pop, sorted)
+	return sortedFitness
+}
+
+// Less compares two solutions of pop like Sort, computing their niched
+// fitness with FitnessOf.
+func (n Niching) Less(c algos.Constraints, pop []problems.Solution, a, b problems.Solution) bool {
+	if c.Handling != algos.Penalty {
+		if va, vb := problems.Violation(a), problems.Violation(b); va != vb {
+			return va < vb
+		}
+	}
+	return n.FitnessOf(a, pop, c.Fitness) < n.FitnessOf(b, pop, c.Fitness)
+}
+
+// Pair returns for every child the index (0 or 1) of the parent it competes
+// with under deterministic crowding: two children are matched with the parents
+// so that the sum of the distances is the smallest, other children each with
+// the closer parent.
+func (n Niching) Pair(parents [2]problems.Solution, children []problems.Solution) []int {
+	pairs := make([]int, len(children))
+	if len(children) == 2 {
+		straight := n.Measure(parents[0], children[0]) + n.Measure(parents[1], children[1])
+		crossed := n.Measure(parents[0], children[1]) + n.Measure(parents[1], children[0])
+		if crossed < straight {
+			pairs[0], pairs[1] = 1, 0
+		} else {
+			pairs[1] = 1
+		}
+		return pairs
+	}
+	for i, child := range children {
+		if n.Measure(parents[1], child) < n.Measure(parents[0], child) {
+			pairs[i] = 1
+		}
+	}
+	return pairs
+}
+
+// Duplicate reports whether duplicate elimination discards sol because it is
+// within DuplicateRadius of a solution of pop.
+func (n Niching) Duplicate(sol problems.Solution, pop []problems.Solution) bool {
+	if !n.Deduplicate {
+		return false
+	}
+	for _, other := range pop {
+		if n.Measure(sol, other) <= n.DuplicateRadius {
+			return true
+		}
+	}
+	return false
+}
+
+// Measure returns the distance between a and b.
+func (n Niching) Measure(a, b problems.Solution) float64 {
+	if n.Distance != nil {
+		return n.Distance(a, b)
+	}
+	return problems.Distance(a, b)
+}
+
+// share is the sharing function of a distance.
+func (n Niching) share(d float64) float64 {
+	if d >= n.Radius {
+		return 0
+	}
+	return 1 - math.Pow(d/n.Radius, n.Alpha)
+}
+
+// shared worsens a fitness (lower is better) by a niche count of at least 1.
+func shared(f, count float64) float64 {
+	if f < 0 {
+		return f / count
+	}
+	return f * count
+}
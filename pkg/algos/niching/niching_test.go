@@ -0,0 +1,206 @@
+package niching
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// points returns one-dimensional points at x, each with fitness x.
+func points(x ...float64) []problems.Solution {
+	pop := make([]problems.Solution, len(x))
+	for i, v := range x {
+		pop[i] = testutil.Point{v}
+	}
+	return pop
+}
+
+func close(got, want []float64) bool {
+	return slices.EqualFunc(got, want, func(a, b float64) bool {
+		return a == b || math.Abs(a-b) < 1e-12
+	})
+}
+
+func TestCounts(t *testing.T) {
+	tests := []struct {
+		name  string
+		alpha float64
+		want  []float64
+	}{
+		// 0 and 1 share half of a niche of radius 2, 3 is 2 away from 1 and alone
+		{"triangular", 1, []float64{1.5, 1.5, 1}},
+		{"quadratic", 2, []float64{1.75, 1.75, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := Niching{Method: Sharing, Radius: 2, Alpha: tt.alpha}
+			if got := n.Counts(points(0, 1, 3)); !close(got, tt.want) {
+				t.Errorf("Counts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSharing(t *testing.T) {
+	n := Niching{Method: Sharing, Radius: 2, Alpha: 1}
+	pop := points(0, 1, 3)
+	// a negative fitness is divided by the count, so it worsens too
+	if got, want := n.Fitness(pop, []float64{2, -3, 5}), []float64{3, -2, 5}; !close(got, want) {
+		t.Errorf("Fitness() = %v, want %v", got, want)
+	}
+
+	fitness := func(sol problems.Solution) float64 { return sol.Fitness() + 1 }
+	if got := n.FitnessOf(testutil.Point{0}, pop, fitness); got != 1.5 {
+		t.Errorf("FitnessOf() a member = %v, want 1.5", got)
+	}
+	// a newcomer far from the population counts itself only
+	if got := n.FitnessOf(testutil.Point{10}, pop, fitness); got != 11 {
+		t.Errorf("FitnessOf() a newcomer = %v, want 11", got)
+	}
+}
+
+func TestCleared(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		x        []float64
+		want     []bool
+	}{
+		// 1 lies exactly on the radius of 0, so it opens a niche of its own
+		{"one per niche", 1, []float64{0, 0.5, 1, 5, 5.2}, []bool{false, true, false, false, true}},
+		{"two per niche", 2, []float64{0, 0.3, 0.6, 5, 5.2}, []bool{false, false, true, false, false}},
+		{"unordered", 1, []float64{5.2, 0.5, 5, 0}, []bool{true, true, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := Niching{Method: Clearing, Radius: 1, Capacity: tt.capacity}
+			if got := n.Cleared(points(tt.x...), tt.x); !slices.Equal(got, tt.want) {
+				t.Errorf("Cleared() = %v, want %v", got, tt.want)
+			}
+			fitness := n.Fitness(points(tt.x...), tt.x)
+			for i, cleared := range tt.want {
+				if cleared != math.IsInf(fitness[i], 1) {
+					t.Errorf("Fitness()[%d] = %v, cleared %v", i, fitness[i], cleared)
+				}
+			}
+		})
+	}
+
+	n := Niching{Method: Clearing, Radius: 1, Capacity: 2}
+	pop := points(0, 0.3, 5)
+	raw := problems.Solution.Fitness
+	if got := n.FitnessOf(testutil.Point{0.6}, pop, raw); !math.IsInf(got, 1) {
+		t.Errorf("FitnessOf() behind two better neighbors = %v, want +Inf", got)
+	}
+	if got := n.FitnessOf(testutil.Point{0.2}, pop, raw); got != 0.2 {
+		t.Errorf("FitnessOf() behind one better neighbor = %v, want 0.2", got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	constrained := func(x, violation float64) problems.Solution {
+		return testutil.Constrained{Point: testutil.Point{x}, Violation: violation}
+	}
+	pop := []problems.Solution{constrained(-5, 1), constrained(2, 0), constrained(2.5, 0), constrained(3.5, 0)}
+	n := Niching{Method: Sharing, Radius: 1, Alpha: 1}
+	fitness := n.Sort(pop, algos.Constraints{})
+	// 2.5 is crowded by 2 and falls behind 3.5; the infeasible -5 comes last
+	want := []float64{2, 3.5, 2.5, -5}
+	for i, sol := range pop {
+		if sol.Fitness() != want[i] {
+			t.Fatalf("sorted %v, want fitness %v", pop, want)
+		}
+	}
+	if want := []float64{3, 3.5, 3.75, -5}; !close(fitness, want) {
+		t.Errorf("Sort() = %v, want %v", fitness, want)
+	}
+	if !n.Less(algos.Constraints{}, pop, pop[1], pop[2]) || n.Less(algos.Constraints{}, pop, pop[3], pop[0]) {
+		t.Error("Less() disagrees with Sort()")
+	}
+}
+
+func TestPair(t *testing.T) {
+	n := Niching{Method: Crowding}
+	parents := [2]problems.Solution{testutil.Point{0}, testutil.Point{10}}
+	tests := []struct {
+		name     string
+		children []problems.Solution
+		want     []int
+	}{
+		{"straight", points(1, 9), []int{0, 1}},
+		{"crossed", points(9, 1), []int{1, 0}},
+		// each child alone would pick parent 1, together the total distance decides
+		{"shared parent", points(6, 7), []int{0, 1}},
+		{"one child", points(7), []int{1}},
+		{"three children", points(1, 7, 4), []int{0, 1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.Pair(parents, tt.children); !slices.Equal(got, tt.want) {
+				t.Errorf("Pair() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	pop := points(0, 1)
+	tests := []struct {
+		name string
+		n    Niching
+		sol  problems.Solution
+		want bool
+	}{
+		{"disabled", Niching{}, testutil.Point{0}, false},
+		{"same solution", Niching{Deduplicate: true}, pop[1], true},
+		{"equal", Niching{Deduplicate: true}, testutil.Point{1}, true},
+		{"near", Niching{Deduplicate: true}, testutil.Point{1.05}, false},
+		{"within the radius", Niching{Deduplicate: true, DuplicateRadius: 0.1}, testutil.Point{1.05}, true},
+		{"on the radius", Niching{Deduplicate: true, DuplicateRadius: 0.5}, testutil.Point{0.5}, true},
+		{"custom distance", Niching{Deduplicate: true, Distance: func(a, b problems.Solution) float64 { return 1 }}, testutil.Point{1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.n.Duplicate(tt.sol, pop); got != tt.want {
+				t.Errorf("Duplicate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       algos.ParamMap
+		want    Niching
+		wantErr bool
+	}{
+		{"default", algos.ParamMap{}, Niching{Alpha: 1, Capacity: 1}, false},
+		{"clearing", algos.ParamMap{"niching": "clearing", "niche_radius": 0.5, "niche_capacity": 3},
+			Niching{Method: Clearing, Radius: 0.5, Alpha: 1, Capacity: 3}, false},
+		{"deduplicate", algos.ParamMap{"deduplicate": true, "duplicate_radius": 0.1},
+			Niching{Alpha: 1, Capacity: 1, Deduplicate: true, DuplicateRadius: 0.1}, false},
+		{"sharing without a radius", algos.ParamMap{"niching": "sharing"}, Niching{}, true},
+		{"empty niches", algos.ParamMap{"niche_capacity": 0}, Niching{}, true},
+		{"unknown method", algos.ParamMap{"niching": "fencing"}, Niching{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromMap(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.Method != tt.want.Method || got.Radius != tt.want.Radius || got.Alpha != tt.want.Alpha ||
+				got.Capacity != tt.want.Capacity || got.Deduplicate != tt.want.Deduplicate || got.DuplicateRadius != tt.want.DuplicateRadius) {
+				t.Errorf("FromMap() = %+v, want %+v", got, tt.want)
+			}
+			if err == nil && got.Reshapes() != (tt.want.Method == Sharing || tt.want.Method == Clearing) {
+				t.Errorf("Reshapes() = %v for method %v", got.Reshapes(), got.Method)
+			}
+		})
+	}
+}
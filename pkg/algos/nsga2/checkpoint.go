@@ -0,0 +1,60 @@
+package nsga2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// individualState stores NSGA-II metadata of one individual.
+// Boundary individuals have an infinite crowding distance, which JSON cannot encode.
+type individualState struct {
+	Rank             int     `json:"rank"`
+	CrowdingDistance float64 `json:"crowding"`
+	Boundary         bool    `json:"boundary,omitempty"`
+}
+
+// Checkpoint captures the full state of the run, including ranks and crowding distances.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("nsga2", solutions(alg.population))
+	if err != nil {
+		return nil, err
+	}
+	state := make([]individualState, len(alg.population))
+	for i, ind := range alg.population {
+		state[i] = individualState{Rank: ind.Rank, CrowdingDistance: ind.CrowdingDistance}
+		if math.IsInf(ind.CrowdingDistance, 1) {
+			state[i] = individualState{Rank: ind.Rank, Boundary: true}
+		}
+	}
+	cp.State, err = json.Marshal(state)
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("nsga2", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	var state []individualState
+	if err := json.Unmarshal(cp.State, &state); err != nil {
+		return fmt.Errorf("decoding nsga2 state: %w", err)
+	}
+	if len(state) != len(pop) {
+		return fmt.Errorf("checkpoint has metadata for %d of %d individuals", len(state), len(pop))
+	}
+	alg.population = make([]Individual, len(pop))
+	for i := range pop {
+		alg.population[i] = Individual{Solution: pop[i], Rank: state[i].Rank, CrowdingDistance: state[i].CrowdingDistance}
+		if state[i].Boundary {
+			alg.population[i].CrowdingDistance = math.Inf(1)
+		}
+	}
+	return nil
+}
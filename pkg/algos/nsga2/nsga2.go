@@ -8,9 +8,26 @@ import (
 	"time"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/niching"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	algos.Register("nsga2", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
 // Individual wraps a candidate solution along with NSGA-II specific metadata.
 type Individual struct {
 	Solution         problems.Solution
@@ -22,26 +39,28 @@ type Individual struct {
 type Algorithm struct {
 	algos.GeneticAlgorithm // embedded common fields (start time, timeout, logger, problem, etc.)
 	params                 Params
-	generation             int
 	population             []Individual
 }
 
 // NewAlgorithm creates a new NSGA-II instance.
 func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
-	return &Algorithm{
-		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, logger),
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
 		params:           params,
-		generation:       0,
 	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
 }
 
 func (alg *Algorithm) Seed(seedSolution problems.Solution) {
 	alg.population = make([]Individual, alg.params.PopulationSize)
 	for i := range alg.params.PopulationSize {
-		alg.population[i] = Individual{Solution: alg.params.MutationFunc(seedSolution)}
+		alg.population[i] = Individual{Solution: alg.params.MutationFunc(alg.Rand, seedSolution)}
 	}
 	alg.population[0] = Individual{Solution: seedSolution}
 	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
 }
 
 func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
@@ -52,94 +71,128 @@ func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
 	for i := range alg.params.PopulationSize {
 		alg.population[i] = Individual{Solution: pop[i]}
 	}
+	alg.rank()
 }
 
-func (alg *Algorithm) GetSteps() int {
-	return alg.generation
+// GetPopulation returns the solutions of the current population.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return solutions(alg.population)
 }
 
 // Run executes the NSGA-II process until timeout.
 func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step performs one NSGA-II generation: offspring creation, non-dominated sorting and truncation.
+func (alg *Algorithm) Step() {
 	if len(alg.population) < alg.params.PopulationSize {
 		alg.initPopulation()
 	}
-	for alg.generation < alg.GenerationLimit {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
 
-		alg.generation++
+	alg.Generation++
 
-		// Generate offspring population by selection, crossover and mutation.
-		offspring := alg.makeOffspring()
+	// Generate offspring population by selection, crossover and mutation.
+	offspring := alg.makeOffspring()
+	alg.Evaluations += len(offspring)
 
-		// Combine populations.
-		combined := append(alg.population, offspring...)
-		fronts := fastNonDominatedSort(combined)
-		nextPopulation := make([]Individual, 0, alg.params.PopulationSize)
-		for _, front := range fronts {
-			computeCrowdingDistance(front)
-			// If adding the full front would exceed population, sort by crowding distance.
-			if len(nextPopulation)+len(front) > alg.params.PopulationSize {
-				sort.Slice(front, func(i, j int) bool {
-					return front[i].CrowdingDistance > front[j].CrowdingDistance
-				})
-				remaining := alg.params.PopulationSize - len(nextPopulation)
-				nextPopulation = append(nextPopulation, front[:remaining]...)
-				break
-			} else {
-				nextPopulation = append(nextPopulation, front...)
-			}
+	// Combine populations and evaluate the new individuals concurrently.
+	combined := append(alg.population, offspring...)
+	alg.Evaluator.Evaluate(solutions(combined))
+	fronts := fastNonDominatedSort(combined, alg.params.Constraints.Dominance(alg.Rand))
+	nextPopulation := make([]Individual, 0, alg.params.PopulationSize)
+	for _, front := range fronts {
+		computeCrowdingDistance(front)
+		alg.niche(front)
+		// If adding the full front would exceed population, sort by crowding distance.
+		if len(nextPopulation)+len(front) > alg.params.PopulationSize {
+			sort.Slice(front, func(i, j int) bool {
+				return front[i].CrowdingDistance > front[j].CrowdingDistance
+			})
+			remaining := alg.params.PopulationSize - len(nextPopulation)
+			nextPopulation = append(nextPopulation, front[:remaining]...)
+			break
+		} else {
+			nextPopulation = append(nextPopulation, front...)
 		}
-		alg.population = nextPopulation
+	}
+	alg.population = nextPopulation
 
-		// Log current generation data: record generation number and the Pareto front
-		if len(fronts) > 0 {
-			var pareto [][]float64
-			for _, ind := range fronts[0] {
-				pareto = append(pareto, ind.Solution.Objectives())
-				if ind.Solution.Fitness() < alg.Solution.Fitness() {
-					alg.Solution = ind.Solution
-				}
+	// Log current generation data: record generation number and the Pareto front
+	if len(fronts) > 0 {
+		var pareto [][]float64
+		for _, ind := range fronts[0] {
+			pareto = append(pareto, ind.Solution.Objectives())
+			if algos.Better(ind.Solution, alg.Solution) {
+				alg.Solution = ind.Solution
 			}
+		}
 
-			if !alg.params.Verbose {
-				pareto = nil
-			}
+		alg.ParetoFront = pareto
 
-			if alg.ProgressLoggerProvider != nil {
-				alg.LogStep(algos.GAStep{
-					Elapsed:     time.Since(alg.StartTimestamp),
-					Step:        alg.generation,
-					ParetoFront: pareto,
-					Solution:    alg.Solution,
-				})
-			}
+		if !alg.params.Verbose {
+			pareto = nil
 		}
+
+		alg.LogProgress(algos.GAStep{
+			Elapsed:     time.Since(alg.StartTimestamp),
+			Seed:        alg.RandSeed,
+			Step:        alg.Generation,
+			ParetoFront: pareto,
+			Solution:    alg.Solution,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// rank sorts a population that did not come out of Step into fronts, so that
+// tournaments compare its individuals by rank and crowding distance.
+func (alg *Algorithm) rank() {
+	alg.Evaluator.Evaluate(solutions(alg.population))
+	fronts := fastNonDominatedSort(alg.population, alg.params.Constraints.Dominance(alg.Rand))
+	ranked := make([]Individual, 0, len(alg.population))
+	for _, front := range fronts {
+		computeCrowdingDistance(front)
+		alg.niche(front)
+		ranked = append(ranked, front...)
 	}
+	alg.population = ranked
 }
 
 // initPopulation creates the initial population randomly.
 func (alg *Algorithm) initPopulation() {
 	alg.population = make([]Individual, alg.params.PopulationSize)
 	for i := range alg.params.PopulationSize {
-		alg.population[i] = Individual{Solution: alg.Problem.RandomSolution()}
+		alg.population[i] = Individual{Solution: alg.Problem.RandomSolution(alg.Rand)}
 	}
+	alg.Evaluations += alg.params.PopulationSize
 }
 
 // makeOffspring performs selection, crossover and mutation to create offspring population.
 func (alg *Algorithm) makeOffspring() []Individual {
 	offspring := make([]Individual, 0, alg.params.PopulationSize)
+	pick := alg.selector()
+	var bred []problems.Solution // population and offspring, for duplicate elimination
+	if alg.params.Niching.Deduplicate {
+		bred = solutions(alg.population)
+	}
+	duplicates := 0
 	for len(offspring) < alg.params.PopulationSize {
-		parent1 := tournamentSelection(alg.population)
-		parent2 := tournamentSelection(alg.population)
+		parent1 := pick(alg.Rand)
+		parent2 := pick(alg.Rand)
 
-		children := alg.params.CrossoverFunc(parent1.Solution, parent2.Solution)
+		children := algos.ApplyCrossover(alg.Rand, alg.params.CrossoverFunc, 1-alg.params.SkipCrossoverProb, parent1.Solution, parent2.Solution)
 
 		for _, child := range children {
-			child = alg.params.MutationFunc(child)
+			child = algos.ApplyMutation(alg.Rand, alg.params.MutationFunc, 1-alg.params.SkipMutationProb, child)
+			// a converged population may breed nothing new, so give up after a while
+			if duplicates < alg.params.PopulationSize && alg.params.Niching.Duplicate(child, bred) {
+				duplicates++
+				continue
+			}
+			if alg.params.Niching.Deduplicate {
+				bred = append(bred, child)
+			}
 			offspring = append(offspring, Individual{Solution: child})
 			if len(offspring) >= alg.params.PopulationSize {
 				break
@@ -149,10 +202,71 @@ func (alg *Algorithm) makeOffspring() []Individual {
 	return offspring
 }
 
+// niche applies sharing or clearing to the crowding distances of a front:
+// sharing divides them by the niche counts of the individuals, and clearing
+// sets those of the cleared ones to -1, below any other.
+func (alg *Algorithm) niche(front []Individual) {
+	if !alg.params.Niching.Reshapes() {
+		return
+	}
+	sols := solutions(front)
+	if alg.params.Niching.Method == niching.Sharing {
+		for i, count := range alg.params.Niching.Counts(sols) {
+			front[i].CrowdingDistance /= count
+		}
+		return
+	}
+	// the least crowded individuals lead their niches
+	fitness := make([]float64, len(front))
+	for i, ind := range front {
+		fitness[i] = -ind.CrowdingDistance
+	}
+	for i, cleared := range alg.params.Niching.Cleared(sols, fitness) {
+		if cleared {
+			front[i].CrowdingDistance = -1
+		}
+	}
+}
+
+// selector returns the parent selection of a generation: binary tournaments on
+// the crowded comparison, unless Params.Selection or Params.Comparison say otherwise.
+func (alg *Algorithm) selector() func(rng *rand.Rand) Individual {
+	if alg.params.Selection == nil && alg.params.Comparison == selection.CrowdedComparison {
+		return func(rng *rand.Rand) Individual { return tournamentSelection(rng, alg.population) }
+	}
+	scheme := alg.params.Selection
+	if scheme == nil {
+		scheme = selection.Tournament{Size: 2}
+	}
+	pop := selection.Ranked{
+		Solutions:  solutions(alg.population),
+		Ranks:      make([]int, len(alg.population)),
+		Crowding:   make([]float64, len(alg.population)),
+		Comparison: alg.params.Comparison,
+		Dominates:  alg.params.Constraints.Dominance(alg.Rand),
+	}
+	for i, ind := range alg.population {
+		pop.Ranks[i], pop.Crowding[i] = ind.Rank, ind.CrowdingDistance
+	}
+	// the initial population is not evaluated yet
+	alg.Evaluator.Evaluate(pop.Solutions)
+	pick := scheme.Prepare(pop)
+	return func(rng *rand.Rand) Individual { return alg.population[pick(rng)] }
+}
+
+// solutions extracts the candidate solutions of individuals.
+func solutions(pop []Individual) []problems.Solution {
+	sols := make([]problems.Solution, len(pop))
+	for i, ind := range pop {
+		sols[i] = ind.Solution
+	}
+	return sols
+}
+
 // tournamentSelection picks one individual using binary tournament selection.
-func tournamentSelection(pop []Individual) Individual {
-	i := rand.IntN(len(pop))
-	j := rand.IntN(len(pop))
+func tournamentSelection(rng *rand.Rand, pop []Individual) Individual {
+	i := rng.IntN(len(pop))
+	j := rng.IntN(len(pop))
 	ind1, ind2 := pop[i], pop[j]
 	// Compare by rank (lower is better) then crowding distance.
 	if ind1.Rank < ind2.Rank {
@@ -166,8 +280,8 @@ func tournamentSelection(pop []Individual) Individual {
 	return ind2
 }
 
-// fastNonDominatedSort performs fast non-dominated sort.
-func fastNonDominatedSort(pop []Individual) [][]Individual {
+// fastNonDominatedSort performs fast non-dominated sort under the dominance relation dominates.
+func fastNonDominatedSort(pop []Individual, dominates func(a, b problems.Solution) bool) [][]Individual {
 	fronts := [][]Individual{}
 	n := len(pop)
 	domCount := make([]int, n)
@@ -241,19 +355,3 @@ func computeCrowdingDistance(front []Individual) {
 		}
 	}
 }
-
-// dominates returns true if solution a dominates solution b (minimization).
-func dominates(a, b problems.Solution) bool {
-	aObjs := a.Objectives()
-	bObjs := b.Objectives()
-	less := false
-	for i := range aObjs {
-		if aObjs[i] > bObjs[i] {
-			return false
-		}
-		if aObjs[i] < bObjs[i] {
-			less = true
-		}
-	}
-	return less
-}
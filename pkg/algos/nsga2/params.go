@@ -1,11 +1,77 @@
 package nsga2
 
-import "github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+import (
+	"errors"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/niching"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
 
 // Params holds configurable parameters for the NSGA-II algorithm.
 type Params struct {
-	PopulationSize int
-	MutationFunc   problems.MutationFunc
-	CrossoverFunc  problems.CrossoverFunc
-	Verbose        bool
+	PopulationSize    int
+	MutationFunc      problems.MutationFunc
+	CrossoverFunc     problems.CrossoverFunc
+	SkipCrossoverProb float64              // 1 - "crossover_prob": chance of copying a pair of parents instead of recombining them, so 0 always recombines
+	SkipMutationProb  float64              // 1 - "mutation_prob": chance of leaving a child unmutated, so 0 always mutates
+	Selection         selection.Selector   // picks parents; nil runs binary tournaments with Comparison
+	Comparison        selection.Comparison // order of parents in tournaments
+	Constraints       algos.Constraints
+	Niching           niching.Niching
+	Seed              uint64 // seed of the run RNG; 0 picks a random seed
+	Workers           int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+	Verbose           bool
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 100); err != nil {
+		return
+	}
+	if params.Verbose, err = m.Bool("verbose", false); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	crossoverProb, err := m.Probability("crossover_prob", 1)
+	if err != nil {
+		return
+	}
+	mutationProb, err := m.Probability("mutation_prob", 1)
+	if err != nil {
+		return
+	}
+	// the zero value of Params recombines and mutates every time
+	params.SkipCrossoverProb, params.SkipMutationProb = 1-crossoverProb, 1-mutationProb
+	if params.Selection, err = selection.FromMap(m, "selection"); err != nil {
+		return
+	}
+	comparison, err := m.String("comparison", "crowding")
+	if err != nil {
+		return
+	}
+	if params.Comparison, err = selection.ComparisonByName(comparison); err != nil {
+		return
+	}
+	if params.Constraints, err = algos.ConstraintsFromMap(m); err != nil {
+		return
+	}
+	if params.Niching, err = niching.FromMap(m); err != nil {
+		return
+	}
+	if params.Niching.Method == niching.Crowding {
+		return params, errors.New("nsga2 keeps its fronts spread by crowding distance and does not support crowding niching")
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
 }
@@ -0,0 +1,235 @@
+package nsga3
+
+import (
+	"context"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("nsga3", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements NSGA-III (Deb and Jain, 2014): NSGA-II with the crowding
+// distance replaced by niching around a set of reference directions, which keeps
+// the front spread out when there are many objectives.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params     Params
+	refs       [][]float64
+	population []problems.Solution
+}
+
+// NewAlgorithm creates a new NSGA-III instance. The reference points are placed
+// for the number of objectives of the problem's solutions.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	objectives := len(alg.Solution.Objectives())
+	if params.InnerDivisions > 0 {
+		alg.refs = TwoLayerReferencePoints(objectives, params.Divisions, params.InnerDivisions)
+	} else {
+		alg.refs = ReferencePoints(objectives, params.Divisions)
+	}
+	if alg.params.PopulationSize <= 0 {
+		alg.params.PopulationSize = (len(alg.refs) + 3) / 4 * 4
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// ReferencePoints returns the reference directions the population is spread along.
+func (alg *Algorithm) ReferencePoints() [][]float64 {
+	return alg.refs
+}
+
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = alg.params.MutationFunc(alg.Rand, seedSolution)
+	}
+	alg.population[0] = seedSolution
+	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = append([]problems.Solution(nil), pop...)
+}
+
+// GetPopulation returns the solutions of the current population.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes the NSGA-III process until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step performs one NSGA-III generation: offspring creation, non-dominated
+// sorting and reference-point based selection of the last accepted front.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+
+	alg.Generation++
+
+	offspring := alg.makeOffspring()
+	alg.Evaluations += len(offspring)
+
+	combined := append(alg.population, offspring...)
+	alg.Evaluator.Evaluate(combined)
+	fronts := nonDominatedSort(combined)
+	alg.population = alg.selectSurvivors(combined, fronts)
+
+	var pareto [][]float64
+	for _, i := range fronts[0] {
+		sol := combined[i]
+		pareto = append(pareto, sol.Objectives())
+		if algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+	}
+	alg.ParetoFront = pareto
+	if !alg.params.Verbose {
+		pareto = nil
+	}
+	alg.LogProgress(algos.GAStep{
+		Elapsed:     time.Since(alg.StartTimestamp),
+		Seed:        alg.RandSeed,
+		Step:        alg.Generation,
+		ParetoFront: pareto,
+		Solution:    alg.Solution,
+	})
+	alg.NotifyObservers()
+}
+
+// selectSurvivors keeps whole fronts while they fit and fills the remaining
+// places from the last front by niching.
+func (alg *Algorithm) selectSurvivors(combined []problems.Solution, fronts [][]int) []problems.Solution {
+	size := alg.params.PopulationSize
+	var accepted, last []int
+	for _, front := range fronts {
+		if len(accepted)+len(front) > size {
+			last = front
+			break
+		}
+		accepted = append(accepted, front...)
+	}
+
+	next := make([]problems.Solution, 0, size)
+	for _, i := range accepted {
+		next = append(next, combined[i])
+	}
+	if len(next) == size {
+		return next
+	}
+
+	// normalize and associate the accepted members and the last front together
+	members := append(append([]int(nil), accepted...), last...)
+	objs := make([][]float64, len(members))
+	for k, i := range members {
+		objs[k] = combined[i].Objectives()
+	}
+	niche, distance := associate(normalize(objs), alg.refs)
+
+	counts := make([]int, len(alg.refs))
+	for k := range accepted {
+		counts[niche[k]]++
+	}
+	lastMembers := make([]int, len(last))
+	for k := range last {
+		lastMembers[k] = len(accepted) + k
+	}
+	for _, k := range niching(alg.Rand, size-len(next), lastMembers, niche, distance, counts) {
+		next = append(next, combined[members[k]])
+	}
+	return next
+}
+
+// initPopulation creates the initial population randomly.
+func (alg *Algorithm) initPopulation() {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.population {
+		alg.population[i] = alg.Problem.RandomSolution(alg.Rand)
+	}
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+// makeOffspring mates random parents: NSGA-III puts all selection pressure into survival.
+func (alg *Algorithm) makeOffspring() []problems.Solution {
+	offspring := make([]problems.Solution, 0, alg.params.PopulationSize)
+	for len(offspring) < alg.params.PopulationSize {
+		parent1 := alg.population[alg.Rand.IntN(len(alg.population))]
+		parent2 := alg.population[alg.Rand.IntN(len(alg.population))]
+		for _, child := range alg.params.CrossoverFunc(alg.Rand, parent1, parent2) {
+			offspring = append(offspring, alg.params.MutationFunc(alg.Rand, child))
+			if len(offspring) >= alg.params.PopulationSize {
+				break
+			}
+		}
+	}
+	return offspring
+}
+
+// nonDominatedSort splits pop into fronts of indexes, the non-dominated front first.
+func nonDominatedSort(pop []problems.Solution) [][]int {
+	n := len(pop)
+	domCount := make([]int, n)
+	dominatedSet := make([][]int, n)
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			a, b := pop[i].Objectives(), pop[j].Objectives()
+			if metrics.Dominates(a, b) {
+				dominatedSet[i] = append(dominatedSet[i], j)
+				domCount[j]++
+			} else if metrics.Dominates(b, a) {
+				dominatedSet[j] = append(dominatedSet[j], i)
+				domCount[i]++
+			}
+		}
+	}
+	var fronts [][]int
+	var current []int
+	for i := range n {
+		if domCount[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for len(current) > 0 {
+		fronts = append(fronts, current)
+		var next []int
+		for _, i := range current {
+			for _, j := range dominatedSet[i] {
+				domCount[j]--
+				if domCount[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		current = next
+	}
+	return fronts
+}
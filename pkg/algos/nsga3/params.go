@@ -0,0 +1,52 @@
+package nsga3
+
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Params holds configurable parameters for the NSGA-III algorithm.
+type Params struct {
+	// PopulationSize of 0 picks the smallest multiple of four not below the
+	// number of reference points, as in the original paper.
+	PopulationSize int
+	// Divisions is the number of segments every objective axis is divided into
+	// when placing the reference points on the unit simplex.
+	Divisions int
+	// InnerDivisions adds an inner layer of reference points, halfway to the
+	// simplex centre; with many objectives two sparse layers are cheaper than one dense one.
+	InnerDivisions int
+	MutationFunc   problems.MutationFunc
+	CrossoverFunc  problems.CrossoverFunc
+	Seed           uint64 // seed of the run RNG; 0 picks a random seed
+	Workers        int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+	Verbose        bool
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 0); err != nil {
+		return
+	}
+	if params.Divisions, err = m.Int("divisions", 12); err != nil {
+		return
+	}
+	if params.InnerDivisions, err = m.Int("inner_divisions", 0); err != nil {
+		return
+	}
+	if params.Verbose, err = m.Bool("verbose", false); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
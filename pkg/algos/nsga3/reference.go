@@ -0,0 +1,219 @@
+package nsga3
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// ReferencePoints returns the Das–Dennis points of the unit simplex in the given
+// number of objectives: all points whose coordinates are multiples of 1/divisions
+// and sum to one. There are C(objectives+divisions-1, divisions) of them.
+func ReferencePoints(objectives, divisions int) [][]float64 {
+	if objectives < 1 || divisions < 1 {
+		return nil
+	}
+	var points [][]float64
+	point := make([]int, objectives)
+	var place func(axis, left int)
+	place = func(axis, left int) {
+		if axis == objectives-1 {
+			point[axis] = left
+			p := make([]float64, objectives)
+			for i, v := range point {
+				p[i] = float64(v) / float64(divisions)
+			}
+			points = append(points, p)
+			return
+		}
+		for v := left; v >= 0; v-- {
+			point[axis] = v
+			place(axis+1, left-v)
+		}
+	}
+	place(0, divisions)
+	return points
+}
+
+// TwoLayerReferencePoints combines an outer layer of Das–Dennis points with an
+// inner layer shrunk halfway towards the simplex centre (Deb and Jain, 2014).
+func TwoLayerReferencePoints(objectives, outer, inner int) [][]float64 {
+	points := ReferencePoints(objectives, outer)
+	for _, p := range ReferencePoints(objectives, inner) {
+		for i := range p {
+			p[i] = (p[i] + 1/float64(objectives)) / 2
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// normalize translates objs so the ideal point is at the origin and scales every
+// objective by the intercept of the hyperplane through the extreme points.
+// If the hyperplane is degenerate the intercepts fall back to the worst values.
+func normalize(objs [][]float64) [][]float64 {
+	m := len(objs[0])
+	ideal := make([]float64, m)
+	for i := range ideal {
+		ideal[i] = math.Inf(1)
+	}
+	for _, f := range objs {
+		for i, v := range f {
+			ideal[i] = min(ideal[i], v)
+		}
+	}
+	translated := make([][]float64, len(objs))
+	for k, f := range objs {
+		translated[k] = make([]float64, m)
+		for i, v := range f {
+			translated[k][i] = v - ideal[i]
+		}
+	}
+
+	intercepts := hyperplaneIntercepts(translated)
+	if intercepts == nil {
+		intercepts = make([]float64, m)
+		for _, f := range translated {
+			for i, v := range f {
+				intercepts[i] = max(intercepts[i], v)
+			}
+		}
+	}
+	for i, a := range intercepts {
+		if a < 1e-10 {
+			intercepts[i] = 1
+		}
+	}
+
+	for _, f := range translated {
+		for i := range f {
+			f[i] /= intercepts[i]
+		}
+	}
+	return translated
+}
+
+// hyperplaneIntercepts finds the extreme point of every axis (the one minimizing
+// the achievement scalarizing function with that axis as direction) and returns
+// the axis intercepts of the hyperplane through them, or nil if there is none.
+func hyperplaneIntercepts(translated [][]float64) []float64 {
+	m := len(translated[0])
+	extremes := mat.NewDense(m, m, nil)
+	for axis := range m {
+		best, bestASF := 0, math.Inf(1)
+		for k, f := range translated {
+			asf := 0.0
+			for i, v := range f {
+				w := 1e-6
+				if i == axis {
+					w = 1
+				}
+				asf = max(asf, v/w)
+			}
+			if asf < bestASF {
+				best, bestASF = k, asf
+			}
+		}
+		extremes.SetRow(axis, translated[best])
+	}
+
+	ones := make([]float64, m)
+	for i := range ones {
+		ones[i] = 1
+	}
+	var x mat.VecDense
+	if err := x.SolveVec(extremes, mat.NewVecDense(m, ones)); err != nil {
+		return nil
+	}
+	intercepts := make([]float64, m)
+	for i := range intercepts {
+		intercepts[i] = 1 / x.AtVec(i)
+		if math.IsNaN(intercepts[i]) || intercepts[i] < 1e-6 {
+			return nil
+		}
+	}
+	return intercepts
+}
+
+// associate returns, for every normalized point, the closest reference line and
+// the perpendicular distance to it.
+func associate(points, refs [][]float64) ([]int, []float64) {
+	niche := make([]int, len(points))
+	distance := make([]float64, len(points))
+	for k, f := range points {
+		distance[k] = math.Inf(1)
+		for j, w := range refs {
+			if d := perpendicular(f, w); d < distance[k] {
+				niche[k], distance[k] = j, d
+			}
+		}
+	}
+	return niche, distance
+}
+
+// perpendicular is the distance of f from the line through the origin and w.
+func perpendicular(f, w []float64) float64 {
+	dot, norm := 0.0, 0.0
+	for i := range w {
+		dot += f[i] * w[i]
+		norm += w[i] * w[i]
+	}
+	t := dot / norm
+	d := 0.0
+	for i := range f {
+		diff := f[i] - t*w[i]
+		d += diff * diff
+	}
+	return math.Sqrt(d)
+}
+
+// niching picks k members of the last front (indexes into niche and distance) so
+// that sparsely populated reference lines are filled first. counts holds the
+// number of already selected members associated with every reference line.
+func niching(rng *rand.Rand, k int, last []int, niche []int, distance []float64, counts []int) []int {
+	candidates := make(map[int][]int)
+	for _, i := range last {
+		candidates[niche[i]] = append(candidates[niche[i]], i)
+	}
+	excluded := make([]bool, len(counts))
+	chosen := make([]int, 0, k)
+	for len(chosen) < k {
+		// the least crowded reference lines, ties broken at random
+		lowest := math.MaxInt
+		var least []int
+		for j, c := range counts {
+			if excluded[j] {
+				continue
+			}
+			if c < lowest {
+				lowest, least = c, least[:0]
+			}
+			if c == lowest {
+				least = append(least, j)
+			}
+		}
+		if len(least) == 0 {
+			break
+		}
+		j := least[rng.IntN(len(least))]
+		members := candidates[j]
+		if len(members) == 0 {
+			excluded[j] = true
+			continue
+		}
+
+		pick := rng.IntN(len(members))
+		if counts[j] == 0 {
+			for p, i := range members {
+				if distance[i] < distance[members[pick]] {
+					pick = p
+				}
+			}
+		}
+		chosen = append(chosen, members[pick])
+		candidates[j] = append(members[:pick], members[pick+1:]...)
+		counts[j]++
+	}
+	return chosen
+}
@@ -0,0 +1,123 @@
+package nsga3
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestReferencePointCounts(t *testing.T) {
+	// the settings of Deb and Jain (2014), Table I
+	tests := []struct {
+		name   string
+		points [][]float64
+		want   int
+	}{
+		{"2 objectives, 4 divisions", ReferencePoints(2, 4), 5},
+		{"3 objectives, 12 divisions", ReferencePoints(3, 12), 91},
+		{"5 objectives, 6 divisions", ReferencePoints(5, 6), 210},
+		{"8 objectives, 3 and 2 divisions", TwoLayerReferencePoints(8, 3, 2), 156},
+		{"10 objectives, 3 and 2 divisions", TwoLayerReferencePoints(10, 3, 2), 275},
+		{"15 objectives, 2 and 1 divisions", TwoLayerReferencePoints(15, 2, 1), 135},
+		{"no divisions", ReferencePoints(3, 0), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.points) != tt.want {
+				t.Fatalf("%d points, want %d", len(tt.points), tt.want)
+			}
+			for _, p := range tt.points {
+				sum := 0.0
+				for _, v := range p {
+					sum += v
+				}
+				if math.Abs(sum-1) > 1e-9 {
+					t.Fatalf("point %v is off the simplex", p)
+				}
+			}
+		})
+	}
+}
+
+func TestReferencePointsAreDistinctGridPoints(t *testing.T) {
+	points := ReferencePoints(3, 4)
+	for i, p := range points {
+		for _, v := range p {
+			if scaled := v * 4; scaled != math.Round(scaled) {
+				t.Fatalf("coordinate %v is not a multiple of 1/4", v)
+			}
+		}
+		for _, q := range points[:i] {
+			if slices.Equal(p, q) {
+				t.Fatalf("point %v appears twice", p)
+			}
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	// ideal point (1, 1), extreme points (3, 1) and (1, 3), so both intercepts are 2
+	got := normalize([][]float64{{1, 3}, {2, 2}, {3, 1}})
+	want := [][]float64{{0, 1}, {0.5, 0.5}, {1, 0}}
+	for i := range want {
+		for j := range want[i] {
+			if math.Abs(got[i][j]-want[i][j]) > 1e-9 {
+				t.Fatalf("normalize() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestAssociate(t *testing.T) {
+	refs := [][]float64{{1, 0}, {0.5, 0.5}, {0, 1}}
+	niche, distance := associate([][]float64{{0.9, 0.1}, {0.4, 0.6}, {0, 2}}, refs)
+	wantNiche := []int{0, 1, 2}
+	wantDistance := []float64{0.1, math.Sqrt(0.02), 0}
+	for i := range wantNiche {
+		if niche[i] != wantNiche[i] || math.Abs(distance[i]-wantDistance[i]) > 1e-9 {
+			t.Fatalf("associate() = %v, %v, want %v, %v", niche, distance, wantNiche, wantDistance)
+		}
+	}
+}
+
+func TestNiching(t *testing.T) {
+	// line 0 is empty and has members 1 and 2, line 1 already holds two individuals
+	niche := []int{1, 0, 0}
+	distance := []float64{0, 0.3, 0.1}
+	tests := []struct {
+		name string
+		k    int
+		want []int
+	}{
+		{"closest member of the empty line first", 1, []int{2}},
+		{"least crowded line until it runs out", 2, []int{2, 1}},
+		{"then the next line", 3, []int{2, 1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := niching(rand.New(rand.NewPCG(1, 1)), tt.k, []int{0, 1, 2}, niche, distance, []int{0, 2})
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("niching() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	pop := []problems.Solution{testutil.Point{1, 3}, testutil.Point{2, 2}, testutil.Point{3, 3}, testutil.Point{3, 1}, testutil.Point{4, 4}}
+	got := nonDominatedSort(pop)
+	want := [][]int{{0, 1, 3}, {2}, {4}}
+	if len(got) != len(want) {
+		t.Fatalf("nonDominatedSort() = %v, want %v", got, want)
+	}
+	for i := range want {
+		slices.Sort(got[i])
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("nonDominatedSort() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package algos
+
+import (
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Observer receives callbacks while an algorithm runs. OnStart and OnFinish
+// wrap every Run, OnGeneration follows every step and OnImprovement precedes
+// it whenever the best fitness improved. Snapshots are only valid during the call.
+type Observer interface {
+	OnStart(s *Snapshot)
+	OnGeneration(s *Snapshot)
+	OnImprovement(s *Snapshot)
+	OnFinish(s *Snapshot)
+}
+
+// ObserverFuncs implements Observer with optional callbacks, nil ones are skipped.
+type ObserverFuncs struct {
+	Start       func(s *Snapshot)
+	Generation  func(s *Snapshot)
+	Improvement func(s *Snapshot)
+	Finish      func(s *Snapshot)
+}
+
+func (o ObserverFuncs) OnStart(s *Snapshot)       { call(o.Start, s) }
+func (o ObserverFuncs) OnGeneration(s *Snapshot)  { call(o.Generation, s) }
+func (o ObserverFuncs) OnImprovement(s *Snapshot) { call(o.Improvement, s) }
+func (o ObserverFuncs) OnFinish(s *Snapshot)      { call(o.Finish, s) }
+
+func call(f func(s *Snapshot), s *Snapshot) {
+	if f != nil {
+		f(s)
+	}
+}
+
+// Snapshot is a read-only view of a run handed to observers.
+type Snapshot struct {
+	RunState
+	StopReason string
+	ga         *GeneticAlgorithm
+}
+
+// Stats summarizes the fitness of a population.
+type Stats struct {
+	Size  int
+	Best  float64
+	Worst float64
+	Mean  float64
+	Std   float64
+}
+
+// Population returns a copy of the current population.
+func (s *Snapshot) Population() []problems.Solution {
+	if s.ga.populationFunc == nil {
+		return nil
+	}
+	return s.ga.populationFunc()
+}
+
+// Stats computes fitness statistics of the current population.
+func (s *Snapshot) Stats() Stats {
+	pop := s.Population()
+	if len(pop) == 0 {
+		return Stats{}
+	}
+	fitness := make([]float64, len(pop))
+	stats := Stats{Size: len(pop), Best: math.Inf(1), Worst: math.Inf(-1)}
+	for i, sol := range pop {
+		fitness[i] = sol.Fitness()
+		stats.Best = min(stats.Best, fitness[i])
+		stats.Worst = max(stats.Worst, fitness[i])
+	}
+	stats.Mean, stats.Std = stat.MeanStdDev(fitness, nil)
+	return stats
+}
+
+// FitnessReference captures the fitness of the current population for
+// operators that judge their children, see FitnessReference.Of.
+func (s *Snapshot) FitnessReference() *FitnessReference {
+	pop := s.Population()
+	r := &FitnessReference{fitness: make(map[problems.Solution]float64, len(pop)), median: math.Inf(1)}
+	values := make([]float64, len(pop))
+	for i, sol := range pop {
+		values[i] = sol.Fitness()
+		// only pointers identify an individual
+		if reflect.ValueOf(sol).Kind() == reflect.Pointer {
+			r.fitness[sol] = values[i]
+		}
+	}
+	if len(values) > 0 {
+		slices.Sort(values)
+		r.median = values[len(values)/2]
+	}
+	return r
+}
+
+// FitnessReference holds the fitness of a population that parents are drawn from.
+type FitnessReference struct {
+	fitness map[problems.Solution]float64
+	median  float64
+}
+
+// Of returns the fitness a child of parent has to beat: that of parent if it is
+// a member of the population, and the median fitness of the population otherwise,
+// e.g. for a child of crossover that no algorithm evaluates. Of never evaluates parent.
+func (r *FitnessReference) Of(parent problems.Solution) float64 {
+	if reflect.ValueOf(parent).Kind() == reflect.Pointer {
+		if fitness, ok := r.fitness[parent]; ok {
+			return fitness
+		}
+	}
+	return r.median
+}
+
+// LogOperators writes a progress record of the current generation carrying the
+// operator statistics of an adaptive operator.
+func (s *Snapshot) LogOperators(stats []OperatorStats) {
+	s.ga.LogProgress(GAStep{
+		Elapsed:   s.Elapsed,
+		Step:      s.Generation,
+		Seed:      s.ga.RandSeed,
+		Solution:  s.Best,
+		Operators: stats,
+	})
+}
+
+// Stop asks the algorithm to end Run before the next step, reporting reason.
+func (s *Snapshot) Stop(reason string) {
+	s.ga.RequestStop(reason)
+}
+
+// AddObserver subscribes o to the run progress.
+func (ga *GeneticAlgorithm) AddObserver(o Observer) {
+	ga.Observers = append(ga.Observers, o)
+}
+
+// ObservePopulation tells the observers how to read the population of the concrete algorithm.
+// Algorithms call it from their constructors.
+func (ga *GeneticAlgorithm) ObservePopulation(population func() []problems.Solution) {
+	ga.populationFunc = population
+}
+
+// NotifyObservers is called by algorithms at the end of every step.
+func (ga *GeneticAlgorithm) NotifyObservers() {
+	if len(ga.Observers) == 0 {
+		return
+	}
+	snapshot := ga.snapshot()
+	fitness := ga.Solution.Fitness()
+	if !ga.observedBest || fitness < ga.bestFitness {
+		ga.observedBest, ga.bestFitness = true, fitness
+		for _, o := range ga.Observers {
+			o.OnImprovement(snapshot)
+		}
+	}
+	for _, o := range ga.Observers {
+		o.OnGeneration(snapshot)
+	}
+}
+
+func (ga *GeneticAlgorithm) notifyStart() {
+	for _, o := range ga.Observers {
+		o.OnStart(ga.snapshot())
+	}
+}
+
+func (ga *GeneticAlgorithm) notifyFinish() {
+	for _, o := range ga.Observers {
+		o.OnFinish(ga.snapshot())
+	}
+}
+
+func (ga *GeneticAlgorithm) snapshot() *Snapshot {
+	return &Snapshot{RunState: ga.State(), StopReason: ga.StopReason, ga: ga}
+}
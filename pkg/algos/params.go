@@ -0,0 +1,163 @@
+package algos
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// ParamMap holds algorithm parameters by name, as used by the registry.
+// Numbers may be given as any Go integer or float type (JSON decodes them as float64).
+type ParamMap map[string]any
+
+// Int returns the integer parameter key, or def if it is absent.
+func (m ParamMap) Int(key string, def int) (int, error) {
+	v, ok := m[key]
+	if !ok {
+		return def, nil
+	}
+	switch n := v.(type) {
+	case int:
+		return n, nil
+	case int64:
+		return int(n), nil
+	case float64:
+		if n != float64(int(n)) {
+			return 0, fmt.Errorf("parameter %q: %v is not an integer", key, n)
+		}
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("parameter %q: expected integer, got %T", key, v)
+}
+
+// Float returns the float parameter key, or def if it is absent.
+func (m ParamMap) Float(key string, def float64) (float64, error) {
+	v, ok := m[key]
+	if !ok {
+		return def, nil
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	}
+	return 0, fmt.Errorf("parameter %q: expected number, got %T", key, v)
+}
+
+// Bool returns the boolean parameter key, or def if it is absent.
+func (m ParamMap) Bool(key string, def bool) (bool, error) {
+	v, ok := m[key]
+	if !ok {
+		return def, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("parameter %q: expected bool, got %T", key, v)
+	}
+	return b, nil
+}
+
+// String returns the string parameter key, or def if it is absent.
+func (m ParamMap) String(key string, def string) (string, error) {
+	v, ok := m[key]
+	if !ok {
+		return def, nil
+	}
+	str, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("parameter %q: expected string, got %T", key, v)
+	}
+	return str, nil
+}
+
+// Params returns a copy of the nested parameter map key, or an empty map if it is absent.
+func (m ParamMap) Params(key string) (ParamMap, error) {
+	v, ok := m[key]
+	if !ok {
+		return ParamMap{}, nil
+	}
+	var nested map[string]any
+	switch n := v.(type) {
+	case map[string]any:
+		nested = n
+	case ParamMap:
+		nested = n
+	default:
+		return nil, fmt.Errorf("parameter %q: expected object, got %T", key, v)
+	}
+	params := make(ParamMap, len(nested))
+	for k, v := range nested {
+		params[k] = v
+	}
+	return params, nil
+}
+
+// Probability returns the probability parameter key, or def if it is absent.
+func (m ParamMap) Probability(key string, def float64) (float64, error) {
+	p, err := m.Float(key, def)
+	if err != nil {
+		return 0, err
+	}
+	if p < 0 || p > 1 {
+		return 0, fmt.Errorf("parameter %q: probability %v is outside [0, 1]", key, p)
+	}
+	return p, nil
+}
+
+// MutationOperator is implemented by stateful mutation operators, which can be
+// given in place of a problems.MutationFunc. Operators that also implement
+// Observer are subscribed to the run by New.
+type MutationOperator interface {
+	Mutate(rng *rand.Rand, individual problems.Solution) problems.Solution
+}
+
+// CrossoverOperator is implemented by stateful crossover operators, which can be
+// given in place of a problems.CrossoverFunc. Like a MutationOperator, one that
+// also implements Observer is subscribed to the run by New.
+type CrossoverOperator interface {
+	Crossover(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution
+}
+
+// Cloner is implemented by parameters with state of their own, like stateful
+// operators. Models that build several algorithms from one ParamMap give each
+// algorithm its own clone.
+type Cloner interface {
+	// Clone returns a value of the same configuration with the state of a new run.
+	Clone() any
+}
+
+// Mutation returns the required mutation operator parameter key.
+func (m ParamMap) Mutation(key string) (problems.MutationFunc, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf("parameter %q is required", key)
+	}
+	switch f := v.(type) {
+	case problems.MutationFunc:
+		return f, nil
+	case MutationOperator:
+		return f.Mutate, nil
+	}
+	return nil, fmt.Errorf("parameter %q: expected problems.MutationFunc, got %T", key, v)
+}
+
+// Crossover returns the required crossover operator parameter key.
+func (m ParamMap) Crossover(key string) (problems.CrossoverFunc, error) {
+	v, ok := m[key]
+	if !ok {
+		return nil, fmt.Errorf("parameter %q is required", key)
+	}
+	switch f := v.(type) {
+	case problems.CrossoverFunc:
+		return f, nil
+	case CrossoverOperator:
+		return f.Crossover, nil
+	}
+	return nil, fmt.Errorf("parameter %q: expected problems.CrossoverFunc, got %T", key, v)
+}
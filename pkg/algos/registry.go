@@ -0,0 +1,80 @@
+package algos
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Factory constructs an algorithm from a generic parameter map.
+type Factory func(
+	problem problems.Problem,
+	params ParamMap,
+	generationLimit int,
+	logger ProgressLoggerProvider,
+) (Algorithm, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes an algorithm available by name. Algorithm packages call it from init,
+// so importing a package (even with a blank import) is enough to register it.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if factory == nil {
+		panic("algos: Register factory is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("algos: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// New constructs the algorithm registered under name.
+func New(
+	name string,
+	problem problems.Problem,
+	params ParamMap,
+	generationLimit int,
+	logger ProgressLoggerProvider,
+) (Algorithm, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown algorithm %q (registered: %v)", name, Registered())
+	}
+	alg, err := factory(problem, params, generationLimit, logger)
+	if err != nil {
+		return nil, err
+	}
+	// parameters with state of their own, like adaptive operators, follow the run
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if o, ok := params[key].(Observer); ok {
+			alg.AddObserver(o)
+		}
+	}
+	return alg, nil
+}
+
+// Registered returns the sorted names of all registered algorithms.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
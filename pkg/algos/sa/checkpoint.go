@@ -0,0 +1,48 @@
+package sa
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	InitialTemperature float64 `json:"initial_temperature"`
+	LoggedFitness      float64 `json:"logged_fitness"`
+}
+
+// Checkpoint captures the full state of the run: the current solution and the
+// initial temperature, which may have been calibrated.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("sa", alg.GetPopulation())
+	if err != nil {
+		return nil, err
+	}
+	cp.State, err = json.Marshal(checkpointState{InitialTemperature: alg.t0, LoggedFitness: alg.loggedFitness})
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("sa", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) > 1 {
+		return fmt.Errorf("checkpoint has %d current solutions, expected 1", len(pop))
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding sa state: %w", err)
+		}
+	}
+	alg.current = nil
+	if len(pop) == 1 {
+		alg.current = pop[0]
+	}
+	alg.t0 = state.InitialTemperature
+	alg.loggedFitness = state.LoggedFitness
+	return nil
+}
@@ -0,0 +1,45 @@
+package sa
+
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Params holds configurable parameters for simulated annealing.
+type Params struct {
+	// MutationFunc draws a neighbor of the current solution.
+	MutationFunc problems.MutationFunc
+	// InitialTemperature of 0 is calibrated on the first step, so that a worsening
+	// move of average size is accepted with probability InitialAcceptance.
+	InitialTemperature float64
+	InitialAcceptance  float64
+	Cooling            Schedule
+	Seed               uint64 // seed of the run RNG; 0 picks a random seed
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.InitialTemperature, err = m.Float("initial_temperature", 0); err != nil {
+		return
+	}
+	if params.InitialAcceptance, err = m.Float("initial_acceptance", 0.8); err != nil {
+		return
+	}
+	cooling, err := m.String("cooling", "geometric")
+	if err != nil {
+		return
+	}
+	rate, err := m.Float("cooling_rate", 0)
+	if err != nil {
+		return
+	}
+	if params.Cooling, err = ScheduleByName(cooling, rate); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
@@ -0,0 +1,160 @@
+package sa
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("sa", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// calibrationSamples is the number of neighbors drawn to calibrate the initial temperature.
+const calibrationSamples = 50
+
+// Algorithm implements simulated annealing on Fitness: every step draws one
+// neighbor of the current solution with the mutation operator and moves to it
+// if it is better, or with probability exp(-Δ/T) if it is worse by Δ.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params        Params
+	current       problems.Solution
+	t0            float64
+	loggedFitness float64
+}
+
+// NewAlgorithm creates a new simulated annealing instance.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+		t0:               params.InitialTemperature,
+	}
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Seed starts the search from seedSolution.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.current = seedSolution
+	alg.Solution = seedSolution
+	alg.Evaluations++
+}
+
+// SetPopulation starts the search from the fittest solution of pop.
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) == 0 {
+		panic("Wrong population size")
+	}
+	alg.current = pop[0]
+	for _, sol := range pop[1:] {
+		if algos.Better(sol, alg.current) {
+			alg.current = sol
+		}
+	}
+	alg.Solution = alg.current
+}
+
+// GetPopulation returns the current solution.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	if alg.current == nil {
+		return nil
+	}
+	return []problems.Solution{alg.current}
+}
+
+// Run executes simulated annealing until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step tries one move at the temperature of the current step and logs the best
+// solution if it changed.
+func (alg *Algorithm) Step() {
+	if alg.current == nil {
+		alg.current = alg.Problem.RandomSolution(alg.Rand)
+		alg.Evaluations++
+	}
+	if alg.t0 <= 0 {
+		alg.calibrate()
+	}
+
+	temperature := alg.params.Cooling(alg.t0, alg.Generation, alg.GenerationLimit)
+	alg.Generation++
+
+	candidate := alg.params.MutationFunc(alg.Rand, alg.current)
+	alg.Evaluations++
+	if alg.accepts(candidate, temperature) {
+		alg.current = candidate
+	}
+	if algos.Better(alg.current, alg.Solution) {
+		alg.Solution = alg.current
+	}
+
+	if alg.Solution.Fitness() != alg.loggedFitness {
+		alg.loggedFitness = alg.Solution.Fitness()
+		alg.LogProgress(algos.GAStep{
+			Elapsed:  time.Since(alg.StartTimestamp),
+			Seed:     alg.RandSeed,
+			Step:     alg.Generation,
+			Solution: alg.Solution,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// accepts reports whether the search moves to candidate. A move that changes the
+// constraint violation is accepted only if it lowers it; between solutions that
+// violate the constraints equally the Metropolis criterion applies to fitness.
+func (alg *Algorithm) accepts(candidate problems.Solution, temperature float64) bool {
+	if vc, v := problems.Violation(candidate), problems.Violation(alg.current); vc != v {
+		return vc < v
+	}
+	delta := candidate.Fitness() - alg.current.Fitness()
+	return delta <= 0 || (temperature > 0 && alg.Rand.Float64() < math.Exp(-delta/temperature))
+}
+
+// Temperature returns the temperature the next step will use.
+func (alg *Algorithm) Temperature() float64 {
+	return alg.params.Cooling(alg.t0, alg.Generation, alg.GenerationLimit)
+}
+
+// calibrate sets the initial temperature from the worsening moves among
+// neighbors of the current solution that violate the constraints as much as it
+// does. If none of them is worse the landscape gives no scale, and a temperature
+// tiny against the fitness is used.
+func (alg *Algorithm) calibrate() {
+	sum, count := 0.0, 0
+	for range calibrationSamples {
+		neighbor := alg.params.MutationFunc(alg.Rand, alg.current)
+		if problems.Violation(neighbor) != problems.Violation(alg.current) {
+			continue
+		}
+		delta := neighbor.Fitness() - alg.current.Fitness()
+		if delta > 0 && !math.IsInf(delta, 1) {
+			sum += delta
+			count++
+		}
+	}
+	alg.Evaluations += calibrationSamples
+	if count == 0 {
+		alg.t0 = 1e-6 * max(math.Abs(alg.current.Fitness()), 1)
+		return
+	}
+	alg.t0 = -(sum / float64(count)) / math.Log(alg.params.InitialAcceptance)
+}
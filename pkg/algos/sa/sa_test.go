@@ -0,0 +1,170 @@
+package sa
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestSchedules(t *testing.T) {
+	tests := []struct {
+		name        string
+		schedule    Schedule
+		step, limit int
+		want        float64
+	}{
+		{"geometric start", Geometric(0.5), 0, 10, 8},
+		{"geometric", Geometric(0.5), 3, 10, 1},
+		{"linear", Linear(), 2, 4, 4},
+		{"linear at the limit", Linear(), 4, 4, 0},
+		{"linear past the limit", Linear(), 6, 4, 0},
+		{"linear without a limit", Linear(), 6, 0, 8},
+		{"logarithmic", Logarithmic(), 3, 10, 8 / (1 + math.Log(4))},
+		{"lundy-mees", LundyMees(0.5), 2, 10, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule(8, tt.step, tt.limit); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("temperature %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScheduleByName(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    float64
+		want    float64 // temperature at step 1 from t0 = 1
+		wantErr bool
+	}{
+		{"geometric", 0, 0.999, false},
+		{"geometric", 0.5, 0.5, false},
+		{"geometric", 1, 0, true},
+		{"geometric", -0.5, 0, true},
+		{"lundy-mees", 0, 1 / 1.01, false},
+		{"lundy-mees", -1, 0, true},
+		{"linear", 0, 1, false},
+		{"logarithmic", 0, 1 / (1 + math.Ln2), false},
+		{"cubic", 0, 0, true},
+	}
+	for _, tt := range tests {
+		schedule, err := ScheduleByName(tt.name, tt.rate)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ScheduleByName(%q, %v) error = %v, wantErr %v", tt.name, tt.rate, err, tt.wantErr)
+			continue
+		}
+		if err == nil {
+			if got := schedule(1, 1, 0); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("ScheduleByName(%q, %v) gives %v at step 1, want %v", tt.name, tt.rate, got, tt.want)
+			}
+		}
+	}
+}
+
+// shift moves the first coordinate of a sphere point by delta.
+func shift(delta float64) problems.MutationFunc {
+	return func(_ *rand.Rand, individual problems.Solution) problems.Solution {
+		x := slices.Clone(individual.(*testutil.SphereSolution).X)
+		x[0] += delta
+		return &testutil.SphereSolution{X: x}
+	}
+}
+
+func TestCalibrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		mutation problems.MutationFunc
+		want     float64
+	}{
+		// every neighbor of 1 is 2, worse by 3
+		{"worsening moves", shift(1), -3 / math.Log(0.8)},
+		// every neighbor of 1 is 0, so there is no scale
+		{"no worsening move", shift(-1), 1e-6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := Params{MutationFunc: tt.mutation, InitialAcceptance: 0.8, Cooling: Geometric(0.9), Seed: 1}
+			alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, params, 0, nil)
+			alg.Seed(&testutil.SphereSolution{X: []float64{1}})
+			alg.Step()
+			if math.Abs(alg.t0-tt.want) > 1e-12 {
+				t.Errorf("calibrated temperature %v, want %v", alg.t0, tt.want)
+			}
+			if alg.Evaluations != 2+calibrationSamples {
+				t.Errorf("%d evaluations, want %d", alg.Evaluations, 2+calibrationSamples)
+			}
+			if got := alg.Temperature(); math.Abs(got-0.9*tt.want) > 1e-12 {
+				t.Errorf("next temperature %v, want %v", got, 0.9*tt.want)
+			}
+		})
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{Seed: 1}, 0, nil)
+	alg.current = testutil.Constrained{Point: testutil.Point{2}, Violation: 1}
+	tests := []struct {
+		name        string
+		candidate   problems.Solution
+		temperature float64
+		want        bool
+	}{
+		{"better", testutil.Constrained{Point: testutil.Point{1}, Violation: 1}, 0, true},
+		{"equal", testutil.Constrained{Point: testutil.Point{2}, Violation: 1}, 0, true},
+		{"worse when frozen", testutil.Constrained{Point: testutil.Point{3}, Violation: 1}, 0, false},
+		{"less violation", testutil.Constrained{Point: testutil.Point{100}, Violation: 0.5}, 0, true},
+		{"more violation", testutil.Constrained{Point: testutil.Point{0}, Violation: 2}, math.Inf(1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alg.accepts(tt.candidate, tt.temperature); got != tt.want {
+				t.Errorf("accepts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("metropolis", func(t *testing.T) {
+		// a move worse by 1 at temperature 1/ln 2 is accepted half of the time
+		accepted := 0
+		for range 10000 {
+			if alg.accepts(testutil.Constrained{Point: testutil.Point{3}, Violation: 1}, 1/math.Ln2) {
+				accepted++
+			}
+		}
+		if accepted < 4800 || accepted > 5200 {
+			t.Errorf("accepted %d of 10000 moves, want about 5000", accepted)
+		}
+	})
+}
+
+func TestSetPopulationResetsBest(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{MutationFunc: testutil.Nudge, Seed: 1}, 0, nil)
+	alg.SetPopulation([]problems.Solution{&testutil.SphereSolution{X: []float64{0.1}}})
+	alg.SetPopulation([]problems.Solution{
+		&testutil.SphereSolution{X: []float64{3}},
+		&testutil.SphereSolution{X: []float64{2}},
+	})
+	if got := alg.GetSolution().Fitness(); got != 4 {
+		t.Errorf("best fitness %v, want 4 from the new population", got)
+	}
+	if got := alg.GetPopulation()[0].Fitness(); got != 4 {
+		t.Errorf("search starts from fitness %v, want 4", got)
+	}
+}
+
+func TestSphere(t *testing.T) {
+	params := Params{MutationFunc: testutil.Nudge, InitialAcceptance: 0.8, Cooling: Geometric(0.995), Seed: 1}
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 2}, params, 0, nil)
+	alg.Seed(&testutil.SphereSolution{X: []float64{4, 4}})
+	for range 2000 {
+		alg.Step()
+	}
+	if got := alg.GetSolution().Fitness(); got > 0.1 {
+		t.Errorf("best fitness %v after 2000 steps from 32", got)
+	}
+}
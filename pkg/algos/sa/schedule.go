@@ -0,0 +1,72 @@
+package sa
+
+import (
+	"fmt"
+	"math"
+)
+
+// Schedule gives the temperature at step (counted from 0) of a run starting at
+// temperature t0; limit is the generation limit of the run.
+type Schedule func(t0 float64, step, limit int) float64
+
+// Geometric multiplies the temperature by alpha every step.
+func Geometric(alpha float64) Schedule {
+	return func(t0 float64, step, _ int) float64 {
+		return t0 * math.Pow(alpha, float64(step))
+	}
+}
+
+// Linear lowers the temperature in equal decrements, reaching 0 at the generation limit.
+func Linear() Schedule {
+	return func(t0 float64, step, limit int) float64 {
+		if limit <= 0 {
+			return t0
+		}
+		return t0 * max(0, 1-float64(step)/float64(limit))
+	}
+}
+
+// Logarithmic is the slow schedule t0 / (1 + ln(1 + step)) of the convergence
+// proofs of simulated annealing.
+func Logarithmic() Schedule {
+	return func(t0 float64, step, _ int) float64 {
+		return t0 / (1 + math.Log1p(float64(step)))
+	}
+}
+
+// LundyMees is the schedule T ← T / (1 + beta·T/t0) of Lundy and Mees, which
+// works out to t0 / (1 + beta·step).
+func LundyMees(beta float64) Schedule {
+	return func(t0 float64, step, _ int) float64 {
+		return t0 / (1 + beta*float64(step))
+	}
+}
+
+// ScheduleByName resolves "geometric", "linear", "logarithmic" and "lundy-mees".
+// rate is alpha of the geometric schedule (default 0.999) and beta of Lundy–Mees
+// (default 0.01); zero picks the default, the other schedules ignore it.
+func ScheduleByName(name string, rate float64) (Schedule, error) {
+	switch name {
+	case "geometric":
+		if rate == 0 {
+			rate = 0.999
+		}
+		if rate <= 0 || rate >= 1 {
+			return nil, fmt.Errorf("geometric cooling rate must be in (0, 1), got %v", rate)
+		}
+		return Geometric(rate), nil
+	case "linear":
+		return Linear(), nil
+	case "logarithmic":
+		return Logarithmic(), nil
+	case "lundy-mees":
+		if rate == 0 {
+			rate = 0.01
+		}
+		if rate < 0 {
+			return nil, fmt.Errorf("lundy-mees cooling rate must be positive, got %v", rate)
+		}
+		return LundyMees(rate), nil
+	}
+	return nil, fmt.Errorf("unknown cooling schedule %q", name)
+}
@@ -0,0 +1,209 @@
+package schedule
+
+import (
+	"math/rand/v2"
+	"sync"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+var (
+	_ algos.MutationOperator  = (*Mutation)(nil)
+	_ algos.Observer          = (*Mutation)(nil)
+	_ algos.CrossoverOperator = (*Crossover)(nil)
+	_ algos.Observer          = (*Crossover)(nil)
+)
+
+// Mutation is a mutation operator whose parameter follows a schedule. It is
+// rebuilt with build whenever the value changes.
+//
+// Mutation is an algos.MutationOperator and an algos.Observer: given as the
+// "mutation" parameter of algos.New it moves to the next value after every
+// generation. A Mutation keeps the state of one run and must not be shared by
+// several algorithms; Clone gives another run its own.
+type Mutation struct {
+	build func(value float64) problems.MutationFunc
+	driver
+	op problems.MutationFunc
+}
+
+// NewMutation creates a mutation built by build with the values of schedule.
+func NewMutation(build func(value float64) problems.MutationFunc, schedule Schedule) *Mutation {
+	m := &Mutation{build: build, driver: newDriver(schedule)}
+	m.op = build(m.value)
+	return m
+}
+
+// Clone returns a Mutation with the same build and a schedule in its initial state.
+func (m *Mutation) Clone() any {
+	return NewMutation(m.build, clone(m.schedule))
+}
+
+func (m *Mutation) Mutate(rng *rand.Rand, individual problems.Solution) problems.Solution {
+	m.mu.Lock()
+	op := m.op
+	m.mu.Unlock()
+
+	child := op(rng, individual)
+	m.record(child, individual)
+	return child
+}
+
+func (m *Mutation) OnStart(s *algos.Snapshot)       {}
+func (m *Mutation) OnImprovement(s *algos.Snapshot) {}
+func (m *Mutation) OnFinish(s *algos.Snapshot)      {}
+
+// OnGeneration moves to the value of the next generation.
+func (m *Mutation) OnGeneration(s *algos.Snapshot) {
+	if value, changed := m.advance(s); changed {
+		op := m.build(value)
+		m.mu.Lock()
+		m.op = op
+		m.mu.Unlock()
+	}
+}
+
+// Crossover is the crossover counterpart of Mutation. A child is successful
+// when it is better than both its parents.
+type Crossover struct {
+	build func(value float64) problems.CrossoverFunc
+	driver
+	op problems.CrossoverFunc
+}
+
+// NewCrossover creates a crossover built by build with the values of schedule.
+func NewCrossover(build func(value float64) problems.CrossoverFunc, schedule Schedule) *Crossover {
+	c := &Crossover{build: build, driver: newDriver(schedule)}
+	c.op = build(c.value)
+	return c
+}
+
+// Clone returns a Crossover with the same build and a schedule in its initial state.
+func (c *Crossover) Clone() any {
+	return NewCrossover(c.build, clone(c.schedule))
+}
+
+func (c *Crossover) Crossover(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
+	c.mu.Lock()
+	op := c.op
+	c.mu.Unlock()
+
+	children := op(rng, parentA, parentB)
+	for _, child := range children {
+		c.record(child, parentA, parentB)
+	}
+	return children
+}
+
+func (c *Crossover) OnStart(s *algos.Snapshot)       {}
+func (c *Crossover) OnImprovement(s *algos.Snapshot) {}
+func (c *Crossover) OnFinish(s *algos.Snapshot)      {}
+
+// OnGeneration moves to the value of the next generation.
+func (c *Crossover) OnGeneration(s *algos.Snapshot) {
+	if value, changed := c.advance(s); changed {
+		op := c.build(value)
+		c.mu.Lock()
+		c.op = op
+		c.mu.Unlock()
+	}
+}
+
+// driver tracks the value of a schedule and, for a Feedback schedule, the
+// children made with it. A child is judged against the fitness of its parents
+// taken from the population (see algos.FitnessReference), so judging never
+// evaluates a solution; children made before the first generation ends are not
+// judged.
+type driver struct {
+	schedule Schedule
+	feedback Feedback
+
+	mu        sync.Mutex
+	value     float64
+	pending   []trial
+	ripe      []trial
+	reference *algos.FitnessReference
+}
+
+// trial records one child and the fitness it has to beat.
+type trial struct {
+	child      problems.Solution
+	references []float64
+}
+
+func newDriver(schedule Schedule) driver {
+	feedback, _ := schedule.(Feedback)
+	return driver{schedule: schedule, feedback: feedback, value: schedule.Value(0)}
+}
+
+// Value returns the current value of the parameter.
+func (d *driver) Value() float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.value
+}
+
+func (d *driver) record(child problems.Solution, parents ...problems.Solution) {
+	if d.feedback == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.reference == nil {
+		return
+	}
+	references := make([]float64, len(parents))
+	for i, parent := range parents {
+		references[i] = d.reference.Of(parent)
+	}
+	d.pending = append(d.pending, trial{child: child, references: references})
+}
+
+// advance reports the successes of the children of the previous generation,
+// which every algorithm has evaluated by now, and moves to the value after
+// the generation of s. It returns the value and whether it changed.
+func (d *driver) advance(s *algos.Snapshot) (float64, bool) {
+	var reference *algos.FitnessReference
+	if d.feedback != nil {
+		reference = s.FitnessReference()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.feedback != nil {
+		d.reference = reference
+		trials := d.ripe
+		d.ripe, d.pending = d.pending, nil
+		successes := 0
+		for _, t := range trials {
+			if t.successful() {
+				successes++
+			}
+		}
+		d.feedback.Observe(successes, len(trials))
+	}
+	value := d.schedule.Value(s.Generation)
+	if value == d.value {
+		return value, false
+	}
+	d.value = value
+	return value, true
+}
+
+func (t trial) successful() bool {
+	fitness := t.child.Fitness()
+	for _, reference := range t.references {
+		if !(fitness < reference) {
+			return false
+		}
+	}
+	return true
+}
+
+// clone returns schedule in its initial state for another run.
+func clone(schedule Schedule) Schedule {
+	if c, ok := schedule.(algos.Cloner); ok {
+		return c.Clone().(Schedule)
+	}
+	return schedule
+}
@@ -0,0 +1,150 @@
+package schedule
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// scale builds an operator multiplying the point by value.
+func scale(value float64) problems.MutationFunc {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
+		x := individual.(problems.RealVector).Vector()
+		child := make([]float64, len(x))
+		for i, v := range x {
+			child[i] = v * value
+		}
+		return &testutil.SphereSolution{X: child}
+	}
+}
+
+// counted is a point that counts its evaluations.
+type counted struct {
+	*testutil.SphereSolution
+	evaluations *int
+}
+
+func (c counted) Fitness() float64 {
+	*c.evaluations++
+	return c.SphereSolution.Fitness()
+}
+
+// observe subscribes o to a run over pop and returns the function that ends a generation.
+func observe(o algos.Observer, pop []problems.Solution) (generation func()) {
+	var ga algos.GeneticAlgorithm
+	ga.Solution = pop[0]
+	ga.ObservePopulation(func() []problems.Solution { return pop })
+	ga.AddObserver(o)
+	return func() {
+		ga.Generation++
+		ga.NotifyObservers()
+	}
+}
+
+func TestMutationFollowsSchedule(t *testing.T) {
+	m := NewMutation(scale, Linear(1, 0, 4))
+	generation := observe(m, []problems.Solution{&testutil.SphereSolution{X: []float64{2}}})
+	rng := rand.New(rand.NewPCG(1, 1))
+	for _, want := range []float64{2, 1.5, 1, 0.5, 0, 0} {
+		if got := m.Mutate(rng, &testutil.SphereSolution{X: []float64{2}}).(*testutil.SphereSolution).X[0]; got != want {
+			t.Errorf("generation with value %v: child %v, want %v", m.Value(), got, want)
+		}
+		generation()
+	}
+}
+
+func TestOneFifthMutation(t *testing.T) {
+	// value 0.5 halves every point, so every child of a member beats its parent;
+	// a value of 1 or more never does
+	pop := []problems.Solution{
+		&testutil.SphereSolution{X: []float64{1}},
+		&testutil.SphereSolution{X: []float64{2}},
+		&testutil.SphereSolution{X: []float64{3}},
+	}
+	m := NewMutation(scale, NewOneFifth(0.5, 0.5, 1))
+	generation := observe(m, pop)
+	rng := rand.New(rand.NewPCG(1, 1))
+	for _, sol := range pop {
+		m.Mutate(rng, sol) // made before any reference, never judged
+	}
+	generation()
+	if m.Value() != 0.5 {
+		t.Fatalf("value %v after a generation without judged children", m.Value())
+	}
+	for _, sol := range pop {
+		m.Mutate(rng, sol)
+	}
+	generation()
+	generation()
+	if m.Value() != 1 {
+		t.Errorf("value %v after successful children, want 1", m.Value())
+	}
+	for _, sol := range pop {
+		m.Mutate(rng, sol)
+	}
+	generation()
+	generation()
+	if m.Value() != 0.5 {
+		t.Errorf("value %v after failed children, want 0.5", m.Value())
+	}
+
+	// a parent from outside the population is not evaluated; its child of
+	// fitness 25 does not beat the median fitness 4
+	var evaluations int
+	m.Mutate(rng, counted{&testutil.SphereSolution{X: []float64{10}}, &evaluations})
+	generation()
+	generation()
+	if evaluations != 0 {
+		t.Errorf("the parent from outside the population was evaluated %d times", evaluations)
+	}
+	if m.Value() != 0.25 {
+		t.Errorf("value %v after a failed child, want 0.25", m.Value())
+	}
+}
+
+func TestCrossoverNeedsToBeatBothParents(t *testing.T) {
+	// the child of value v is v times the first parent
+	build := func(value float64) problems.CrossoverFunc {
+		return func(rng *rand.Rand, a, b problems.Solution) []problems.Solution {
+			return []problems.Solution{scale(value)(rng, a)}
+		}
+	}
+	pop := []problems.Solution{
+		&testutil.SphereSolution{X: []float64{2}},
+		&testutil.SphereSolution{X: []float64{1}},
+	}
+	c := NewCrossover(build, NewOneFifth(0.75, 0.5, 1))
+	generation := observe(c, pop)
+	generation()
+	// fitness 2.25 beats 4 but not 1
+	c.Crossover(nil, pop[0], pop[1])
+	generation()
+	generation()
+	if c.Value() != 0.375 {
+		t.Errorf("value %v, want 0.375", c.Value())
+	}
+}
+
+func TestClone(t *testing.T) {
+	pop := []problems.Solution{&testutil.SphereSolution{X: []float64{1}}}
+	m := NewMutation(scale, NewOneFifth(0.5, 0.5, 1))
+	generation := observe(m, pop)
+	generation()
+	m.Mutate(rand.New(rand.NewPCG(1, 1)), pop[0])
+	generation()
+	generation()
+	if m.Value() != 1 {
+		t.Fatalf("value %v, want 1", m.Value())
+	}
+	clone := m.Clone().(*Mutation)
+	if clone.Value() != 0.5 || clone.schedule == m.schedule {
+		t.Errorf("clone starts at %v, want its own schedule at 0.5", clone.Value())
+	}
+	linear := NewMutation(scale, Linear(1, 0, 4))
+	if linear.Clone().(*Mutation).schedule != linear.schedule {
+		t.Error("a stateless schedule was not reused")
+	}
+}
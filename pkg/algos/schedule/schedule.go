@@ -0,0 +1,190 @@
+// Package schedule varies a numeric operator parameter over the generations of
+// a run, like the step size of a mutation or the swap probability of a crossover.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Schedule gives the value of a parameter once generation generations are done.
+type Schedule interface {
+	Value(generation int) float64
+}
+
+// Feedback is a Schedule that adapts to how often the operator it drives
+// produces a child better than its parents. Observe is called once per
+// generation with the number of successful children among those evaluated.
+// A Feedback with state of its own should implement algos.Cloner, so that
+// operators cloned for another run do not share it.
+type Feedback interface {
+	Schedule
+	Observe(successes, trials int)
+}
+
+type linear struct {
+	from, to    float64
+	generations int
+}
+
+// Linear moves from from to to in equal steps over generations generations
+// and stays at to afterwards.
+func Linear(from, to float64, generations int) Schedule {
+	return linear{from: from, to: to, generations: generations}
+}
+
+func (s linear) Value(generation int) float64 {
+	t := min(float64(generation)/float64(s.generations), 1)
+	return s.from + (s.to-s.from)*t
+}
+
+type exponential struct {
+	from, to    float64
+	generations int
+}
+
+// Exponential moves from from to to by a constant factor per generation over
+// generations generations and stays at to afterwards. from and to must be
+// positive.
+func Exponential(from, to float64, generations int) Schedule {
+	return exponential{from: from, to: to, generations: generations}
+}
+
+func (s exponential) Value(generation int) float64 {
+	t := min(float64(generation)/float64(s.generations), 1)
+	return s.from * math.Pow(s.to/s.from, t)
+}
+
+type step struct {
+	from, factor float64
+	every        int
+}
+
+// Step multiplies the value by factor every every generations.
+func Step(from, factor float64, every int) Schedule {
+	return step{from: from, factor: factor, every: every}
+}
+
+func (s step) Value(generation int) float64 {
+	return s.from * math.Pow(s.factor, float64(generation/s.every))
+}
+
+// OneFifth applies Rechenberg's 1/5th success rule to a step-size parameter:
+// every Window generations the value is divided by Factor if more than a fifth
+// of the children were successful and multiplied by it if fewer were, within
+// [Min, Max].
+type OneFifth struct {
+	Factor   float64 // in (0, 1); 0.817 is the classic choice
+	Window   int
+	Min, Max float64
+
+	initial, value                 float64
+	successes, trials, generations int
+}
+
+// NewOneFifth creates a 1/5th success rule starting at initial.
+func NewOneFifth(initial, factor float64, window int) *OneFifth {
+	return &OneFifth{Factor: factor, Window: window, Max: math.Inf(1), initial: initial, value: initial}
+}
+
+// Clone returns the rule with the same settings, back at its initial value.
+func (s *OneFifth) Clone() any {
+	return &OneFifth{Factor: s.Factor, Window: s.Window, Min: s.Min, Max: s.Max, initial: s.initial, value: s.initial}
+}
+
+// Value returns the current value, whatever the generation.
+func (s *OneFifth) Value(generation int) float64 {
+	return s.value
+}
+
+func (s *OneFifth) Observe(successes, trials int) {
+	s.successes += successes
+	s.trials += trials
+	s.generations++
+	if s.generations < s.Window {
+		return
+	}
+	if s.trials > 0 {
+		switch rate := float64(s.successes) / float64(s.trials); {
+		case rate > 0.2:
+			s.value /= s.Factor
+		case rate < 0.2:
+			s.value *= s.Factor
+		}
+		s.value = min(max(s.value, s.Min), s.Max)
+	}
+	s.successes, s.trials, s.generations = 0, 0, 0
+}
+
+// FromMap builds the schedule named by "schedule" from a registry parameter
+// map. Every schedule starts at "from"; the other parameters are
+//
+//	"linear", "exponential": "to", "generations"
+//	"step":                  "factor" (default 0.5), "every" (default 100)
+//	"one-fifth":             "factor" (default 0.817), "window" (default 10), "min", "max"
+func FromMap(m algos.ParamMap) (Schedule, error) {
+	name, err := m.String("schedule", "")
+	if err != nil {
+		return nil, err
+	}
+	from, err := m.Float("from", 0)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "linear", "exponential":
+		to, err := m.Float("to", 0)
+		if err != nil {
+			return nil, err
+		}
+		generations, err := m.Int("generations", 0)
+		if err != nil {
+			return nil, err
+		}
+		if generations <= 0 {
+			return nil, fmt.Errorf("%s schedule needs a positive number of \"generations\"", name)
+		}
+		if name == "linear" {
+			return Linear(from, to, generations), nil
+		}
+		if from <= 0 || to <= 0 {
+			return nil, errors.New(`exponential schedule needs positive "from" and "to"`)
+		}
+		return Exponential(from, to, generations), nil
+	case "step":
+		factor, err := m.Float("factor", 0.5)
+		if err != nil {
+			return nil, err
+		}
+		every, err := m.Int("every", 100)
+		if err != nil {
+			return nil, err
+		}
+		if every <= 0 {
+			return nil, errors.New(`step schedule needs a positive "every"`)
+		}
+		return Step(from, factor, every), nil
+	case "one-fifth":
+		s := NewOneFifth(from, 0, 0)
+		if s.Factor, err = m.Float("factor", 0.817); err != nil {
+			return nil, err
+		}
+		if s.Window, err = m.Int("window", 10); err != nil {
+			return nil, err
+		}
+		if s.Min, err = m.Float("min", 0); err != nil {
+			return nil, err
+		}
+		if s.Max, err = m.Float("max", math.Inf(1)); err != nil {
+			return nil, err
+		}
+		if s.Factor <= 0 || s.Factor >= 1 {
+			return nil, fmt.Errorf("one-fifth schedule factor %v is outside (0, 1)", s.Factor)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown schedule %q", name)
+}
@@ -0,0 +1,99 @@
+package schedule
+
+import (
+	"math"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+func TestSchedules(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		values   map[int]float64 // by generation
+	}{
+		{"linear", Linear(1, 0, 4), map[int]float64{0: 1, 1: 0.75, 2: 0.5, 4: 0, 10: 0}},
+		{"linear upwards", Linear(0, 2, 2), map[int]float64{0: 0, 1: 1, 3: 2}},
+		{"exponential", Exponential(1, 0.01, 2), map[int]float64{0: 1, 1: 0.1, 2: 0.01, 5: 0.01}},
+		{"step", Step(8, 0.5, 3), map[int]float64{0: 8, 2: 8, 3: 4, 6: 2, 7: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for generation, want := range tt.values {
+				if got := tt.schedule.Value(generation); math.Abs(got-want) > 1e-12 {
+					t.Errorf("Value(%d) = %v, want %v", generation, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestOneFifth(t *testing.T) {
+	tests := []struct {
+		name              string
+		successes, trials []int // of every generation
+		want              float64
+	}{
+		{"too few successes", []int{1, 1}, []int{10, 10}, 0.5},
+		{"too many successes", []int{5, 5}, []int{10, 10}, 2},
+		{"exactly a fifth", []int{1, 3}, []int{10, 10}, 1},
+		// the rates of the two generations are pooled over the window
+		{"pooled window", []int{0, 2}, []int{2, 8}, 1},
+		{"no trials", []int{0, 0}, []int{0, 0}, 1},
+		{"window not full", []int{0}, []int{10}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewOneFifth(1, 0.5, 2)
+			for i := range tt.successes {
+				s.Observe(tt.successes[i], tt.trials[i])
+			}
+			if got := s.Value(100); got != tt.want {
+				t.Errorf("Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	s := NewOneFifth(1, 0.5, 1)
+	s.Min, s.Max = 0.3, 1.5
+	s.Observe(0, 10)
+	s.Observe(0, 10)
+	if got := s.Value(0); got != 0.3 {
+		t.Errorf("Value() = %v below Min 0.3", got)
+	}
+	c := s.Clone().(*OneFifth)
+	if c.Value(0) != 1 || c.Min != 0.3 || c.Max != 1.5 || c == s {
+		t.Errorf("Clone() = %+v, want the initial value 1 and the same bounds", c)
+	}
+}
+
+func TestFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       algos.ParamMap
+		want    float64 // value at generation 2
+		wantErr bool
+	}{
+		{"linear", algos.ParamMap{"schedule": "linear", "from": 1, "to": 0, "generations": 4}, 0.5, false},
+		{"exponential", algos.ParamMap{"schedule": "exponential", "from": 1, "to": 0.25, "generations": 4}, 0.5, false},
+		{"step defaults", algos.ParamMap{"schedule": "step", "from": 1}, 1, false},
+		{"one-fifth", algos.ParamMap{"schedule": "one-fifth", "from": 0.3}, 0.3, false},
+		{"linear without generations", algos.ParamMap{"schedule": "linear", "from": 1}, 0, true},
+		{"exponential through zero", algos.ParamMap{"schedule": "exponential", "from": 1, "to": 0, "generations": 4}, 0, true},
+		{"step every zero", algos.ParamMap{"schedule": "step", "every": 0}, 0, true},
+		{"one-fifth factor", algos.ParamMap{"schedule": "one-fifth", "factor": 1}, 0, true},
+		{"unknown", algos.ParamMap{"schedule": "cosine"}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := FromMap(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && math.Abs(s.Value(2)-tt.want) > 1e-12 {
+				t.Errorf("Value(2) = %v, want %v", s.Value(2), tt.want)
+			}
+		})
+	}
+}
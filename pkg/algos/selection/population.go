@@ -0,0 +1,104 @@
+package selection
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+var (
+	_ Population = Solutions{}
+	_ Population = Ranked{}
+)
+
+// Solutions is the Population of a single-objective algorithm, compared under
+// Constraints. Rand is drawn from by stochastic ranking.
+type Solutions struct {
+	Solutions   []problems.Solution
+	Constraints algos.Constraints
+	Rand        *rand.Rand
+}
+
+func (p Solutions) Len() int { return len(p.Solutions) }
+
+func (p Solutions) Better(i, j int) bool {
+	return p.Constraints.Less(p.Rand, p.Solutions[i], p.Solutions[j])
+}
+
+func (p Solutions) Fitness(i int) float64 {
+	return p.Constraints.Fitness(p.Solutions[i])
+}
+
+func (p Solutions) Objectives(i int) []float64 {
+	return p.Constraints.Objectives(p.Solutions[i])
+}
+
+// Comparison orders the individuals of a population sorted into non-dominated fronts.
+type Comparison int
+
+const (
+	// CrowdedComparison prefers the lower front and then the larger crowding
+	// distance, the crowded-comparison operator of NSGA-II.
+	CrowdedComparison Comparison = iota
+	// DominanceComparison prefers the individual that dominates the other and
+	// breaks ties by crowding distance, as in the binary tournament of Deb's
+	// constrained NSGA-II. Individuals of different fronts may not dominate
+	// each other, so it is a weaker preference than CrowdedComparison.
+	DominanceComparison
+)
+
+// ComparisonByName resolves "crowding" and "dominance".
+func ComparisonByName(name string) (Comparison, error) {
+	switch name {
+	case "crowding":
+		return CrowdedComparison, nil
+	case "dominance":
+		return DominanceComparison, nil
+	}
+	return 0, fmt.Errorf("unknown comparison %q", name)
+}
+
+// Ranked is the Population of a multi-objective algorithm that sorts its
+// individuals into fronts (Ranks, 0 being the non-dominated front) and
+// measures their Crowding distance. Dominates is the dominance relation of
+// DominanceComparison. The scalar fitness of an individual is its rank plus
+// 1/(1+crowding), which orders individuals like CrowdedComparison.
+type Ranked struct {
+	Solutions  []problems.Solution
+	Ranks      []int
+	Crowding   []float64
+	Comparison Comparison
+	Dominates  func(a, b problems.Solution) bool
+}
+
+func (p Ranked) Len() int { return len(p.Solutions) }
+
+func (p Ranked) Better(i, j int) bool {
+	if p.Comparison == DominanceComparison {
+		if p.Dominates(p.Solutions[i], p.Solutions[j]) {
+			return true
+		}
+		if p.Dominates(p.Solutions[j], p.Solutions[i]) {
+			return false
+		}
+		return p.Crowding[i] > p.Crowding[j]
+	}
+	if p.Ranks[i] != p.Ranks[j] {
+		return p.Ranks[i] < p.Ranks[j]
+	}
+	return p.Crowding[i] > p.Crowding[j]
+}
+
+func (p Ranked) Fitness(i int) float64 {
+	if math.IsInf(p.Crowding[i], 1) {
+		return float64(p.Ranks[i])
+	}
+	return float64(p.Ranks[i]) + 1/(1+p.Crowding[i])
+}
+
+func (p Ranked) Objectives(i int) []float64 {
+	return p.Solutions[i].Objectives()
+}
@@ -0,0 +1,274 @@
+package selection
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+)
+
+// Tournament picks the best of Size individuals drawn with replacement.
+type Tournament struct {
+	Size int
+}
+
+func (s Tournament) Prepare(pop Population) func(rng *rand.Rand) int {
+	n := pop.Len()
+	return func(rng *rand.Rand) int {
+		best := rng.IntN(n)
+		for k := 1; k < s.Size; k++ {
+			if c := rng.IntN(n); pop.Better(c, best) {
+				best = c
+			}
+		}
+		return best
+	}
+}
+
+// Roulette picks individuals with probabilities proportional to how much
+// better than the worst of the population their fitness is, so the worst
+// individual is never picked unless all are equal.
+type Roulette struct{}
+
+func (Roulette) Prepare(pop Population) func(rng *rand.Rand) int {
+	return wheel(fitnessWeights(pop))
+}
+
+// SUS is stochastic universal sampling (Baker, 1987) with the weights of
+// Roulette: one spin places Len equally spaced pointers on the wheel, and the
+// individuals they point at are handed out in random order before the next spin.
+// The number of times an individual is picked then stays within one of its
+// expected value.
+type SUS struct{}
+
+func (SUS) Prepare(pop Population) func(rng *rand.Rand) int {
+	weights := fitnessWeights(pop)
+	cumulative, total := cumulate(weights)
+	var queue []int
+	return func(rng *rand.Rand) int {
+		if len(queue) == 0 {
+			queue = spin(rng, cumulative, total)
+		}
+		i := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+		return i
+	}
+}
+
+// LinearRank picks individuals with probabilities falling linearly with their
+// rank, from Pressure/n for the best to (2-Pressure)/n for the worst (Baker, 1985).
+type LinearRank struct {
+	Pressure float64 // in [1, 2]; 1 picks uniformly
+}
+
+func (s LinearRank) Prepare(pop Population) func(rng *rand.Rand) int {
+	order := ranking(pop)
+	n := len(order)
+	weights := make([]float64, n)
+	for r, i := range order {
+		weights[i] = 1
+		if n > 1 {
+			weights[i] = 2 - s.Pressure + 2*(s.Pressure-1)*float64(n-1-r)/float64(n-1)
+		}
+	}
+	return wheel(weights)
+}
+
+// ExponentialRank picks the individual of rank r (0 being the best) with a
+// probability proportional to Base^r.
+type ExponentialRank struct {
+	Base float64 // in (0, 1)
+}
+
+func (s ExponentialRank) Prepare(pop Population) func(rng *rand.Rand) int {
+	order := ranking(pop)
+	weights := make([]float64, len(order))
+	w := 1.0
+	for _, i := range order {
+		weights[i] = w
+		w *= s.Base
+	}
+	return wheel(weights)
+}
+
+// Boltzmann picks individuals with probabilities proportional to
+// exp(-(f - fmin) / (Temperature · (fmax - fmin))), the temperature being
+// relative to the fitness range of the population. Low temperatures favour the
+// best individuals strongly; high ones pick almost uniformly.
+type Boltzmann struct {
+	Temperature float64
+}
+
+func (s Boltzmann) Prepare(pop Population) func(rng *rand.Rand) int {
+	n := pop.Len()
+	fitness := make([]float64, n)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := range fitness {
+		fitness[i] = pop.Fitness(i)
+		if !math.IsInf(fitness[i], 0) && !math.IsNaN(fitness[i]) {
+			lo, hi = min(lo, fitness[i]), max(hi, fitness[i])
+		}
+	}
+	weights := make([]float64, n)
+	for i, f := range fitness {
+		switch {
+		case math.IsInf(f, 0) || math.IsNaN(f):
+		case hi == lo:
+			weights[i] = 1
+		default:
+			weights[i] = math.Exp(-(f - lo) / (s.Temperature * (hi - lo)))
+		}
+	}
+	return wheel(weights)
+}
+
+// Lexicase selection (Spector, 2012) takes the objectives as test cases: it
+// goes through them in a random order, keeping only the individuals that are
+// best on each, until one is left or the cases run out, and then picks one of
+// those left at random. With Epsilon an individual counts as best on a case
+// when it is within the median absolute deviation of the population on that
+// case from the best value (La Cava et al., 2016). Lexicase selection is meant
+// for multi-objective problems; with a single objective it always picks among
+// the best individuals.
+type Lexicase struct {
+	Epsilon bool
+}
+
+func (s Lexicase) Prepare(pop Population) func(rng *rand.Rand) int {
+	n := pop.Len()
+	objectives := make([][]float64, n)
+	for i := range objectives {
+		objectives[i] = pop.Objectives(i)
+	}
+	cases := len(objectives[0])
+	epsilon := make([]float64, cases)
+	if s.Epsilon {
+		values := make([]float64, n)
+		for c := range epsilon {
+			for i := range values {
+				values[i] = objectives[i][c]
+			}
+			epsilon[c] = medianAbsoluteDeviation(values)
+		}
+	}
+	return func(rng *rand.Rand) int {
+		candidates := make([]int, n)
+		for i := range candidates {
+			candidates[i] = i
+		}
+		for _, c := range rng.Perm(cases) {
+			best := math.Inf(1)
+			for _, i := range candidates {
+				best = min(best, objectives[i][c])
+			}
+			candidates = slices.DeleteFunc(candidates, func(i int) bool {
+				return objectives[i][c] > best+epsilon[c]
+			})
+			if len(candidates) == 1 {
+				break
+			}
+		}
+		return candidates[rng.IntN(len(candidates))]
+	}
+}
+
+// fitnessWeights weighs individuals by how much better than the worst finite
+// fitness of the population theirs is.
+func fitnessWeights(pop Population) []float64 {
+	n := pop.Len()
+	fitness := make([]float64, n)
+	worst := math.Inf(-1)
+	for i := range fitness {
+		fitness[i] = pop.Fitness(i)
+		if !math.IsInf(fitness[i], 0) && !math.IsNaN(fitness[i]) {
+			worst = max(worst, fitness[i])
+		}
+	}
+	weights := make([]float64, n)
+	for i, f := range fitness {
+		if !math.IsInf(f, 0) && !math.IsNaN(f) {
+			weights[i] = worst - f
+		}
+	}
+	return weights
+}
+
+// ranking returns the indices of pop from the best to the worst individual.
+func ranking(pop Population) []int {
+	order := make([]int, pop.Len())
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return pop.Better(order[a], order[b])
+	})
+	return order
+}
+
+// wheel returns a roulette wheel over weights. If no weight is positive, every
+// individual is equally likely.
+func wheel(weights []float64) func(rng *rand.Rand) int {
+	cumulative, total := cumulate(weights)
+	n := len(weights)
+	if total <= 0 {
+		return func(rng *rand.Rand) int { return rng.IntN(n) }
+	}
+	return func(rng *rand.Rand) int {
+		return pointAt(cumulative, rng.Float64()*total)
+	}
+}
+
+// spin places len(cumulative) equally spaced pointers on the wheel with one
+// random offset and returns the individuals they point at, shuffled.
+func spin(rng *rand.Rand, cumulative []float64, total float64) []int {
+	n := len(cumulative)
+	picks := make([]int, n)
+	if total <= 0 {
+		for k := range picks {
+			picks[k] = rng.IntN(n)
+		}
+		return picks
+	}
+	step := total / float64(n)
+	offset := rng.Float64() * step
+	for k := range picks {
+		picks[k] = pointAt(cumulative, offset+float64(k)*step)
+	}
+	rng.Shuffle(n, func(a, b int) { picks[a], picks[b] = picks[b], picks[a] })
+	return picks
+}
+
+func cumulate(weights []float64) ([]float64, float64) {
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+	return cumulative, total
+}
+
+// pointAt returns the individual whose slice of the wheel contains x.
+func pointAt(cumulative []float64, x float64) int {
+	i := sort.Search(len(cumulative), func(i int) bool { return cumulative[i] > x })
+	return min(i, len(cumulative)-1)
+}
+
+func medianAbsoluteDeviation(values []float64) float64 {
+	m := median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - m)
+	}
+	return median(deviations)
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,125 @@
+// Package selection implements parent selection schemes that the genetic
+// algorithms accept through their Params.
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Population is the view of a population that selection schemes work on.
+type Population interface {
+	Len() int
+	// Better reports whether individual i is better than individual j.
+	// Tournaments and rank-based schemes compare with it.
+	Better(i, j int) bool
+	// Fitness is the scalar fitness of individual i, lower being better.
+	// Fitness-proportional schemes weigh individuals with it.
+	Fitness(i int) float64
+	// Objectives returns the objectives of individual i, the cases of lexicase selection.
+	Objectives(i int) []float64
+}
+
+// Selector is a parent selection scheme.
+type Selector interface {
+	// Prepare does the work one round of selection from pop needs (ranking,
+	// weighing) and returns a function that picks the index of one parent.
+	// The population must not change while the function is in use.
+	Prepare(pop Population) func(rng *rand.Rand) int
+}
+
+// ByName builds the scheme name with its parameters:
+//
+//	"tournament":       "size" (default 2)
+//	"roulette", "sus":  none
+//	"linear-rank":      "pressure" (default 1.5)
+//	"exponential-rank": "base" (default 0.95)
+//	"boltzmann":        "temperature" (default 0.25)
+//	"lexicase":         "epsilon" (default false)
+func ByName(name string, params algos.ParamMap) (Selector, error) {
+	switch name {
+	case "tournament":
+		size, err := params.Int("size", 2)
+		if err != nil {
+			return nil, err
+		}
+		if size < 1 {
+			return nil, fmt.Errorf("tournament size %d is not positive", size)
+		}
+		return Tournament{Size: size}, nil
+	case "roulette":
+		return Roulette{}, nil
+	case "sus":
+		return SUS{}, nil
+	case "linear-rank":
+		pressure, err := params.Float("pressure", 1.5)
+		if err != nil {
+			return nil, err
+		}
+		if pressure < 1 || pressure > 2 {
+			return nil, fmt.Errorf("linear rank pressure %v is outside [1, 2]", pressure)
+		}
+		return LinearRank{Pressure: pressure}, nil
+	case "exponential-rank":
+		base, err := params.Float("base", 0.95)
+		if err != nil {
+			return nil, err
+		}
+		if base <= 0 || base >= 1 {
+			return nil, fmt.Errorf("exponential rank base %v is outside (0, 1)", base)
+		}
+		return ExponentialRank{Base: base}, nil
+	case "boltzmann":
+		temperature, err := params.Float("temperature", 0.25)
+		if err != nil {
+			return nil, err
+		}
+		if temperature <= 0 {
+			return nil, fmt.Errorf("boltzmann temperature %v is not positive", temperature)
+		}
+		return Boltzmann{Temperature: temperature}, nil
+	case "lexicase":
+		epsilon, err := params.Bool("epsilon", false)
+		if err != nil {
+			return nil, err
+		}
+		return Lexicase{Epsilon: epsilon}, nil
+	}
+	return nil, fmt.Errorf("unknown selection %q", name)
+}
+
+// FromMap reads the selection parameter key of a registry parameter map: a
+// scheme name, an object with a "name" and the parameters of the scheme, or a
+// Selector. It returns nil if the parameter is absent or null, leaving the algorithm
+// to its own selection.
+func FromMap(m algos.ParamMap, key string) (Selector, error) {
+	v, ok := m[key]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	var params algos.ParamMap
+	switch s := v.(type) {
+	case Selector:
+		return s, nil
+	case string:
+		return ByName(s, algos.ParamMap{})
+	case map[string]any:
+		params = s
+	case algos.ParamMap:
+		params = s
+	default:
+		return nil, fmt.Errorf("parameter %q: expected a selection name or object, got %T", key, v)
+	}
+	name, err := params.String("name", "")
+	if err != nil || name == "" {
+		return nil, errors.New(`selection object needs a "name"`)
+	}
+	s, err := ByName(name, params)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", key, err)
+	}
+	return s, nil
+}
@@ -1,6 +1,13 @@
 package sga
 
-import "github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/niching"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
 
 type Params struct {
 	PopulationSize       int
@@ -8,4 +15,74 @@ type Params struct {
 	MatingPoolPercentile float64
 	MutationFunc         problems.MutationFunc
 	CrossoverFunc        problems.CrossoverFunc
+	SkipCrossoverProb    float64            // 1 - "crossover_prob": chance of copying a pair of parents instead of recombining them, so 0 always recombines
+	SkipMutationProb     float64            // 1 - "mutation_prob": chance of leaving a child unmutated, so 0 always mutates
+	Selection            selection.Selector // picks parents from the whole population; nil draws them uniformly from the mating pool
+	Constraints          algos.Constraints
+	Niching              niching.Niching
+	Seed                 uint64 // seed of the run RNG; 0 picks a random seed
+	Workers              int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 100); err != nil {
+		return
+	}
+	if params.ElitePercentile, err = m.Float("elite_percentile", 0.1); err != nil {
+		return
+	}
+	if params.MatingPoolPercentile, err = m.Float("mating_pool_percentile", 0.5); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	crossoverProb, err := m.Probability("crossover_prob", 1)
+	if err != nil {
+		return
+	}
+	mutationProb, err := m.Probability("mutation_prob", 1)
+	if err != nil {
+		return
+	}
+	// the zero value of Params recombines and mutates every time
+	params.SkipCrossoverProb, params.SkipMutationProb = 1-crossoverProb, 1-mutationProb
+	if params.Selection, err = selection.FromMap(m, "selection"); err != nil {
+		return
+	}
+	if params.Constraints, err = algos.ConstraintsFromMap(m); err != nil {
+		return
+	}
+	if params.Niching, err = niching.FromMap(m); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	if err != nil {
+		return
+	}
+	params.Seed = uint64(seed)
+	err = params.checkMatingPool()
+	return
+}
+
+// matingPoolSize is the number of the fittest individuals that parents are
+// drawn from when Selection is nil.
+func (p Params) matingPoolSize() int {
+	return int(float64(p.PopulationSize) * p.MatingPoolPercentile)
+}
+
+// checkMatingPool reports a mating pool too small to draw two distinct parents from.
+func (p Params) checkMatingPool() error {
+	if p.Selection == nil && p.matingPoolSize() < 2 {
+		return fmt.Errorf("mating pool of %d individuals (%v of %d) cannot supply two distinct parents",
+			p.matingPoolSize(), p.MatingPoolPercentile, p.PopulationSize)
+	}
+	return nil
 }
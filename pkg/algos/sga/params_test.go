@@ -0,0 +1,61 @@
+package sga
+
+import (
+	"context"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestMatingPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       algos.ParamMap
+		wantErr bool
+	}{
+		{"default", algos.ParamMap{}, false},
+		{"two individuals", algos.ParamMap{"population_size": 4, "mating_pool_percentile": 0.5}, false},
+		{"one individual", algos.ParamMap{"population_size": 4, "mating_pool_percentile": 0.25}, true},
+		{"empty", algos.ParamMap{"mating_pool_percentile": 0}, true},
+		// parents are drawn from the whole population
+		{"empty with a selection", algos.ParamMap{"mating_pool_percentile": 0, "selection": "tournament"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.m["mutation"] = problems.MutationFunc(testutil.Nudge)
+			tt.m["crossover"] = problems.CrossoverFunc(testutil.Midpoint)
+			_, err := ParamsFromMap(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParamsFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewAlgorithm() accepted a mating pool of one")
+		}
+	}()
+	NewAlgorithm(testutil.Sphere{Dimensions: 2}, Params{PopulationSize: 4, MatingPoolPercentile: 0.25}, 1, nil)
+}
+
+func TestSphere(t *testing.T) {
+	params := Params{
+		PopulationSize:       20,
+		ElitePercentile:      0.1,
+		MatingPoolPercentile: 0.1,
+		MutationFunc:         testutil.Nudge,
+		CrossoverFunc:        testutil.Midpoint,
+		Seed:                 1,
+	}
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 2}, params, 1, nil)
+	alg.Run(context.Background())
+	first := alg.GetSolution().Fitness()
+	alg.GenerationLimit = 50
+	alg.Run(context.Background())
+	if last := alg.GetSolution().Fitness(); last >= first/10 {
+		t.Errorf("best fitness went from %v to %v", first, last)
+	}
+}
@@ -3,69 +3,117 @@ package sga
 import (
 	"context"
 	"math/rand/v2"
-	"sort"
+	"slices"
 	"time"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/niching"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	algos.Register("sga", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
 type Algorithm struct {
 	algos.GeneticAlgorithm
 	params         Params
-	generation     int
 	population     []problems.Solution
 	eliteSize      int
 	matingPoolSize int
+	niched         []float64 // niched fitness of the sorted population under sharing or clearing
+	loggedFitness  float64
 }
 
+// NewAlgorithm creates an SGA. Without a Selection the mating pool must hold
+// at least two individuals.
 func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
-	return &Algorithm{
-		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, logger),
+	if err := params.checkMatingPool(); err != nil {
+		panic("sga: " + err.Error())
+	}
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
 		params:           params,
-		generation:       0,
 		eliteSize:        int(float64(params.PopulationSize) * params.ElitePercentile),
-		matingPoolSize:   int(float64(params.PopulationSize) * params.MatingPoolPercentile),
+		matingPoolSize:   params.matingPoolSize(),
 	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
 }
 
 func (alg *Algorithm) Run(ctx context.Context) {
-	alg.InitPopulation()
-	fitness := 0.0
-	for alg.generation < alg.GenerationLimit {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-		alg.Evolve()
-		alg.generation++
-		bestFitness := alg.Solution.Fitness()
-		if fitness != bestFitness {
-			fitness = bestFitness
-			if alg.ProgressLoggerProvider != nil {
-				alg.LogStep(algos.GAStep{
-					Elapsed: time.Since(alg.StartTimestamp), Solution: alg.Solution, Step: alg.generation,
-				})
-			}
-		}
-	}
+	alg.Loop(ctx, alg.Step)
 }
 
-func (alg *Algorithm) GetSteps() int {
-	return alg.generation
+// Step evolves the population by one generation and logs the best solution if it changed.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.InitPopulation()
+	}
+	alg.Evolve()
+	alg.Generation++
+	bestFitness := alg.Solution.Fitness()
+	if alg.loggedFitness != bestFitness {
+		alg.loggedFitness = bestFitness
+		alg.LogProgress(algos.GAStep{
+			Elapsed: time.Since(alg.StartTimestamp), Seed: alg.RandSeed, Solution: alg.Solution, Step: alg.Generation,
+		})
+	}
+	alg.NotifyObservers()
 }
 
 func (alg *Algorithm) InitPopulation() {
 	pop := make([]problems.Solution, alg.params.PopulationSize)
 	for i := range pop {
-		pop[i] = alg.Problem.RandomSolution()
+		pop[i] = alg.Problem.RandomSolution(alg.Rand)
 	}
 	alg.population = pop
+	alg.Evaluations += len(pop)
+}
+
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = alg.params.MutationFunc(alg.Rand, seedSolution)
+	}
+	alg.population[0] = seedSolution
+	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	copy(alg.population, pop)
+}
+
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	pop := make([]problems.Solution, len(alg.population))
+	copy(pop, alg.population)
+	return pop
 }
 
 func (alg *Algorithm) Evolve() {
 	alg.evaluateGeneration()
+	if alg.params.Niching.Method == niching.Crowding {
+		alg.crowd()
+		return
+	}
 
 	newPopulation := make([]problems.Solution, 0, alg.params.PopulationSize)
 
@@ -73,20 +121,28 @@ func (alg *Algorithm) Evolve() {
 	newPopulation = append(newPopulation, alg.population[:alg.eliteSize]...)
 
 	// generate rest of the population
+	pick := alg.selector()
+	duplicates := 0
 	for len(newPopulation) < alg.params.PopulationSize {
-		p1Ind := rand.IntN(alg.matingPoolSize)
-		p2Ind := rand.IntN(alg.matingPoolSize)
-		if p1Ind == p2Ind {
+		p1Ind := pick(alg.Rand)
+		p2Ind := pick(alg.Rand)
+		if p1Ind == p2Ind && alg.params.Selection == nil {
 			continue
 		}
 		parent1 := alg.population[p1Ind]
 		parent2 := alg.population[p2Ind]
 
-		children := alg.params.CrossoverFunc(parent1, parent2)
+		children := algos.ApplyCrossover(alg.Rand, alg.params.CrossoverFunc, 1-alg.params.SkipCrossoverProb, parent1, parent2)
 
 		for _, child := range children {
-			child = alg.params.MutationFunc(child)
+			child = algos.ApplyMutation(alg.Rand, alg.params.MutationFunc, 1-alg.params.SkipMutationProb, child)
+			// a converged population may breed nothing new, so give up after a while
+			if duplicates < alg.params.PopulationSize && alg.params.Niching.Duplicate(child, newPopulation) {
+				duplicates++
+				continue
+			}
 			newPopulation = append(newPopulation, child)
+			alg.Evaluations++
 			if len(newPopulation) >= alg.params.PopulationSize {
 				break
 			}
@@ -96,9 +152,85 @@ func (alg *Algorithm) Evolve() {
 	alg.population = newPopulation
 }
 
-func (alg *Algorithm) evaluateGeneration() {
-	sort.Slice(alg.population, func(i, j int) bool {
-		return alg.population[i].Fitness() < alg.population[j].Fitness()
+// crowd breeds a generation by deterministic crowding: the population is
+// paired at random, and every child takes the place of the parent it is
+// matched with unless the parent is better.
+func (alg *Algorithm) crowd() {
+	type family struct {
+		parents  [2]int
+		children []problems.Solution
+	}
+	n := len(alg.population)
+	perm := alg.Rand.Perm(n)
+	families := make([]family, 0, n/2)
+	var offspring []problems.Solution
+	for k := 0; k+1 < n; k += 2 {
+		f := family{parents: [2]int{perm[k], perm[k+1]}}
+		parent1, parent2 := alg.population[f.parents[0]], alg.population[f.parents[1]]
+		for _, child := range algos.ApplyCrossover(alg.Rand, alg.params.CrossoverFunc, 1-alg.params.SkipCrossoverProb, parent1, parent2) {
+			child = algos.ApplyMutation(alg.Rand, alg.params.MutationFunc, 1-alg.params.SkipMutationProb, child)
+			if alg.params.Niching.Duplicate(child, alg.population) || alg.params.Niching.Duplicate(child, offspring) {
+				continue
+			}
+			f.children = append(f.children, child)
+			offspring = append(offspring, child)
+		}
+		families = append(families, f)
+	}
+	alg.Evaluator.Evaluate(offspring)
+	alg.Evaluations += len(offspring)
+
+	next := slices.Clone(alg.population)
+	for _, f := range families {
+		parents := [2]problems.Solution{alg.population[f.parents[0]], alg.population[f.parents[1]]}
+		for i, p := range alg.params.Niching.Pair(parents, f.children) {
+			slot := f.parents[p]
+			if !alg.params.Constraints.Less(alg.Rand, next[slot], f.children[i]) {
+				next[slot] = f.children[i]
+			}
+		}
+	}
+	alg.population = next
+}
+
+// selector returns the parent selection of a generation: uniform sampling from
+// the mating pool, unless Params.Selection is set.
+func (alg *Algorithm) selector() func(rng *rand.Rand) int {
+	if alg.params.Selection == nil {
+		return func(rng *rand.Rand) int { return rng.IntN(alg.matingPoolSize) }
+	}
+	if alg.params.Niching.Reshapes() {
+		return alg.params.Selection.Prepare(nichedSolutions{alg.population, alg.niched})
+	}
+	return alg.params.Selection.Prepare(selection.Solutions{
+		Solutions:   alg.population,
+		Constraints: alg.params.Constraints,
+		Rand:        alg.Rand,
 	})
-	alg.Solution = alg.population[0]
 }
+
+func (alg *Algorithm) evaluateGeneration() {
+	alg.Evaluator.Evaluate(alg.population)
+	if alg.params.Niching.Reshapes() {
+		alg.niched = alg.params.Niching.Sort(alg.population, alg.params.Constraints)
+	} else {
+		alg.params.Constraints.Sort(alg.Rand, alg.population)
+	}
+	// stochastic ranking may put an infeasible solution first
+	for _, sol := range alg.population {
+		if algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+	}
+}
+
+// nichedSolutions is the view of a population sorted by niched fitness for selection.
+type nichedSolutions struct {
+	solutions []problems.Solution
+	fitness   []float64
+}
+
+func (p nichedSolutions) Len() int                   { return len(p.solutions) }
+func (p nichedSolutions) Better(i, j int) bool       { return i < j }
+func (p nichedSolutions) Fitness(i int) float64      { return p.fitness[i] }
+func (p nichedSolutions) Objectives(i int) []float64 { return p.solutions[i].Objectives() }
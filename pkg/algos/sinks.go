@@ -0,0 +1,273 @@
+package algos
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// StepRecord is implemented by records that wrap a GAStep with extra fields,
+// so sinks that only understand GAStep (like CSVLogger) can still write them.
+type StepRecord interface {
+	GAStepRecord() GAStep
+}
+
+func asGAStep(step any) (GAStep, bool) {
+	switch s := step.(type) {
+	case GAStep:
+		return s, true
+	case *GAStep:
+		return *s, true
+	case StepRecord:
+		return s.GAStepRecord(), true
+	}
+	return GAStep{}, false
+}
+
+// WriterLogger writes records as JSON lines to any io.Writer.
+// Close flushes but does not close the writer, which stays owned by the caller.
+type WriterLogger struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	closed  bool
+}
+
+func NewWriterLogger(w io.Writer) *WriterLogger {
+	writer := bufio.NewWriter(w)
+	return &WriterLogger{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+func (l *WriterLogger) InitLogging() error { return nil }
+
+func (l *WriterLogger) LogProblem(problem problems.Problem) error {
+	header, err := problems.MarshalProblem(problem)
+	if err != nil {
+		return err
+	}
+	return l.Log(json.RawMessage(header))
+}
+
+func (l *WriterLogger) LogStep(step any) error { return l.Log(step) }
+
+func (l *WriterLogger) Log(obj any) error {
+	if l.closed {
+		return ErrLoggerClosed
+	}
+	return l.encoder.Encode(obj)
+}
+
+func (l *WriterLogger) Flush() error { return l.writer.Flush() }
+
+func (l *WriterLogger) Close() error {
+	l.closed = true
+	return l.writer.Flush()
+}
+
+// MemoryLogger keeps the problem and the last Capacity records in memory,
+// e.g. for a live dashboard. It is safe to read while an algorithm is logging.
+type MemoryLogger struct {
+	mu       sync.Mutex
+	problem  problems.Problem
+	records  []any
+	next     int
+	full     bool
+	capacity int
+}
+
+func NewMemoryLogger(capacity int) *MemoryLogger {
+	return &MemoryLogger{capacity: max(capacity, 1), records: make([]any, max(capacity, 1))}
+}
+
+func (l *MemoryLogger) InitLogging() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.problem = nil
+	clear(l.records)
+	l.next, l.full = 0, false
+	return nil
+}
+
+func (l *MemoryLogger) LogProblem(problem problems.Problem) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.problem = problem
+	return nil
+}
+
+func (l *MemoryLogger) LogStep(step any) error { return l.Log(step) }
+
+func (l *MemoryLogger) Log(obj any) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records[l.next] = obj
+	l.next = (l.next + 1) % l.capacity
+	if l.next == 0 {
+		l.full = true
+	}
+	return nil
+}
+
+func (l *MemoryLogger) Flush() error { return nil }
+
+func (l *MemoryLogger) Close() error { return nil }
+
+// Problem returns the logged problem, or nil.
+func (l *MemoryLogger) Problem() problems.Problem {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.problem
+}
+
+// Records returns the retained records, oldest first.
+func (l *MemoryLogger) Records() []any {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]any(nil), l.records[:l.next]...)
+	}
+	return append(append([]any(nil), l.records[l.next:]...), l.records[:l.next]...)
+}
+
+// ChannelLogger sends every record, the problem included, to C.
+// Sends block until the record is received, the channel buffer has room or
+// the logger is closed.
+type ChannelLogger struct {
+	C       <-chan any
+	ch      chan any
+	done    chan struct{}
+	mu      sync.Mutex
+	sending int // sends in flight; C is closed once they are over
+	closed  bool
+}
+
+func NewChannelLogger(buffer int) *ChannelLogger {
+	ch := make(chan any, buffer)
+	return &ChannelLogger{C: ch, ch: ch, done: make(chan struct{})}
+}
+
+func (l *ChannelLogger) InitLogging() error { return nil }
+
+func (l *ChannelLogger) LogProblem(problem problems.Problem) error { return l.Log(problem) }
+
+func (l *ChannelLogger) LogStep(step any) error { return l.Log(step) }
+
+func (l *ChannelLogger) Log(obj any) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return ErrLoggerClosed
+	}
+	l.sending++
+	l.mu.Unlock()
+
+	// send without the lock, so that Close does not wait for a stalled receiver
+	var err error
+	select {
+	case l.ch <- obj:
+	case <-l.done:
+		err = ErrLoggerClosed
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sending--; l.closed && l.sending == 0 {
+		close(l.ch)
+	}
+	return err
+}
+
+func (l *ChannelLogger) Flush() error { return nil }
+
+// Close closes C, so receivers can range over it. Blocked sends give up and
+// report ErrLoggerClosed.
+func (l *ChannelLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.closed {
+		l.closed = true
+		close(l.done)
+		if l.sending == 0 {
+			close(l.ch)
+		}
+	}
+	return nil
+}
+
+// CSVLogger writes one row per step: elapsed seconds, step, seed, fitness,
+// every objective and the stop reason. The problem is not written and
+// records other than steps are rejected.
+type CSVLogger struct {
+	writer     *csv.Writer
+	objectives int
+	header     bool
+	closed     bool
+}
+
+func NewCSVLogger(w io.Writer) *CSVLogger {
+	return &CSVLogger{writer: csv.NewWriter(w)}
+}
+
+func (l *CSVLogger) InitLogging() error { return nil }
+
+func (l *CSVLogger) LogProblem(problem problems.Problem) error { return nil }
+
+func (l *CSVLogger) LogStep(step any) error { return l.Log(step) }
+
+func (l *CSVLogger) Log(obj any) error {
+	if l.closed {
+		return ErrLoggerClosed
+	}
+	step, ok := asGAStep(obj)
+	if !ok {
+		return fmt.Errorf("csv logger: unsupported record %T", obj)
+	}
+
+	if !l.header {
+		if step.Solution != nil {
+			l.objectives = len(step.Solution.Objectives())
+		}
+		header := []string{"elapsed", "step", "seed", "fitness"}
+		for i := range l.objectives {
+			header = append(header, fmt.Sprintf("objective_%d", i))
+		}
+		if err := l.writer.Write(append(header, "stop_reason")); err != nil {
+			return err
+		}
+		l.header = true
+	}
+
+	row := []string{
+		strconv.FormatFloat(step.Elapsed.Seconds(), 'f', -1, 64),
+		strconv.Itoa(step.Step),
+		strconv.FormatUint(step.Seed, 10),
+	}
+	objectives := make([]string, l.objectives)
+	fitness := ""
+	if step.Solution != nil {
+		fitness = strconv.FormatFloat(step.Solution.Fitness(), 'g', -1, 64)
+		for i, o := range step.Solution.Objectives() {
+			if i < len(objectives) {
+				objectives[i] = strconv.FormatFloat(o, 'g', -1, 64)
+			}
+		}
+	}
+	row = append(append(row, fitness), objectives...)
+	return l.writer.Write(append(row, step.StopReason))
+}
+
+func (l *CSVLogger) Flush() error {
+	l.writer.Flush()
+	return l.writer.Error()
+}
+
+// Close flushes the rows; the writer stays owned by the caller.
+func (l *CSVLogger) Close() error {
+	l.closed = true
+	return l.Flush()
+}
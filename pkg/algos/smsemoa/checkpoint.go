@@ -0,0 +1,26 @@
+package smsemoa
+
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Checkpoint captures the full state of the run. Fronts and contributions are
+// recomputed every step, so the population is all it needs.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	return alg.NewCheckpoint("smsemoa", alg.population)
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("smsemoa", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	alg.population = pop
+	return nil
+}
@@ -0,0 +1,45 @@
+package smsemoa
+
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Params holds configurable parameters for the SMS-EMOA algorithm.
+type Params struct {
+	PopulationSize int
+	// ReferenceOffset is added to the worst value of every objective in the last
+	// front to get the hypervolume reference point, so the extremes of the front
+	// also have a positive contribution.
+	ReferenceOffset float64
+	MutationFunc    problems.MutationFunc
+	CrossoverFunc   problems.CrossoverFunc
+	Seed            uint64 // seed of the run RNG; 0 picks a random seed
+	Workers         int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+	Verbose         bool
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 100); err != nil {
+		return
+	}
+	if params.ReferenceOffset, err = m.Float("reference_offset", 1); err != nil {
+		return
+	}
+	if params.Verbose, err = m.Bool("verbose", false); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
@@ -0,0 +1,201 @@
+package smsemoa
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("smsemoa", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements SMS-EMOA (Beume, Naujoks and Emmerich, 2007), a
+// steady-state algorithm: every step adds one child and removes the member of the
+// worst non-dominated front that contributes the least hypervolume.
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params     Params
+	population []problems.Solution
+}
+
+// NewAlgorithm creates a new SMS-EMOA instance.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = alg.params.MutationFunc(alg.Rand, seedSolution)
+	}
+	alg.population[0] = seedSolution
+	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = append([]problems.Solution(nil), pop...)
+}
+
+// GetPopulation returns the solutions of the current population.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return alg.population
+}
+
+// Run executes the SMS-EMOA process until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step creates one child and drops one individual. The run is logged once per
+// PopulationSize steps, i.e. once per generation's worth of evaluations.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+
+	alg.Generation++
+
+	parent1 := alg.population[alg.Rand.IntN(len(alg.population))]
+	parent2 := alg.population[alg.Rand.IntN(len(alg.population))]
+	child := alg.params.MutationFunc(alg.Rand, alg.params.CrossoverFunc(alg.Rand, parent1, parent2)[0])
+	alg.Evaluations++
+
+	combined := append(alg.population, child)
+	alg.Evaluator.Evaluate(combined)
+	fronts := nonDominatedSort(combined)
+	worst := alg.leastContributor(combined, fronts[len(fronts)-1])
+	alg.population = append(combined[:worst:worst], combined[worst+1:]...)
+
+	if algos.Better(child, alg.Solution) && worst != len(combined)-1 {
+		alg.Solution = child
+	}
+	var pareto [][]float64
+	for _, i := range fronts[0] {
+		if i != worst {
+			pareto = append(pareto, combined[i].Objectives())
+		}
+	}
+	alg.ParetoFront = pareto
+
+	if alg.Generation%max(alg.params.PopulationSize, 1) == 0 {
+		if !alg.params.Verbose {
+			pareto = nil
+		}
+		alg.LogProgress(algos.GAStep{
+			Elapsed:     time.Since(alg.StartTimestamp),
+			Seed:        alg.RandSeed,
+			Step:        alg.Generation,
+			ParetoFront: pareto,
+			Solution:    alg.Solution,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// leastContributor returns the member of front (indexes into pop) with the
+// smallest exclusive hypervolume contribution.
+func (alg *Algorithm) leastContributor(pop []problems.Solution, front []int) int {
+	if len(front) == 1 {
+		return front[0]
+	}
+	points := make([][]float64, len(front))
+	for k, i := range front {
+		points[k] = pop[i].Objectives()
+	}
+	ref := make([]float64, len(points[0]))
+	for i := range ref {
+		ref[i] = math.Inf(-1)
+	}
+	for _, p := range points {
+		for i, v := range p {
+			ref[i] = max(ref[i], v)
+		}
+	}
+	for i := range ref {
+		ref[i] += alg.params.ReferenceOffset
+	}
+
+	contributions := metrics.Contributions(points, ref)
+	least := 0
+	for k, c := range contributions {
+		if c < contributions[least] {
+			least = k
+		}
+	}
+	return front[least]
+}
+
+// initPopulation creates the initial population randomly.
+func (alg *Algorithm) initPopulation() {
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	for i := range alg.population {
+		alg.population[i] = alg.Problem.RandomSolution(alg.Rand)
+	}
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+// nonDominatedSort splits pop into fronts of indexes, the non-dominated front first.
+func nonDominatedSort(pop []problems.Solution) [][]int {
+	n := len(pop)
+	domCount := make([]int, n)
+	dominatedSet := make([][]int, n)
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			a, b := pop[i].Objectives(), pop[j].Objectives()
+			if metrics.Dominates(a, b) {
+				dominatedSet[i] = append(dominatedSet[i], j)
+				domCount[j]++
+			} else if metrics.Dominates(b, a) {
+				dominatedSet[j] = append(dominatedSet[j], i)
+				domCount[i]++
+			}
+		}
+	}
+	var fronts [][]int
+	var current []int
+	for i := range n {
+		if domCount[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for len(current) > 0 {
+		fronts = append(fronts, current)
+		var next []int
+		for _, i := range current {
+			for _, j := range dominatedSet[i] {
+				domCount[j]--
+				if domCount[j] == 0 {
+					next = append(next, j)
+				}
+			}
+		}
+		current = next
+	}
+	return fronts
+}
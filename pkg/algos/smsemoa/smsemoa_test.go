@@ -0,0 +1,48 @@
+package smsemoa
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestLeastContributor(t *testing.T) {
+	alg := &Algorithm{params: Params{ReferenceOffset: 1}}
+	tests := []struct {
+		name  string
+		pop   []problems.Solution
+		front []int
+		want  int
+	}{
+		{"single member", []problems.Solution{testutil.Point{1, 1}, testutil.Point{2, 2}}, []int{1}, 1},
+		// reference point (4, 4): contributions 1, 1.5 and 0.5
+		{"smallest contribution", []problems.Solution{testutil.Point{1, 3}, testutil.Point{2, 1.5}, testutil.Point{3, 1}}, []int{0, 1, 2}, 2},
+		// the duplicate adds nothing to the hypervolume
+		{"duplicate", []problems.Solution{testutil.Point{1, 3}, testutil.Point{2, 1.5}, testutil.Point{3, 1}, testutil.Point{2, 1.5}}, []int{0, 1, 2, 3}, 1},
+		{"front given out of order", []problems.Solution{testutil.Point{9, 9}, testutil.Point{3, 1}, testutil.Point{1, 3}, testutil.Point{2, 1.5}}, []int{2, 3, 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alg.leastContributor(tt.pop, tt.front); got != tt.want {
+				t.Errorf("leastContributor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNonDominatedSort(t *testing.T) {
+	pop := []problems.Solution{testutil.Point{1, 3}, testutil.Point{2, 2}, testutil.Point{3, 3}, testutil.Point{3, 1}, testutil.Point{4, 4}}
+	got := nonDominatedSort(pop)
+	want := [][]int{{0, 1, 3}, {2}, {4}}
+	if len(got) != len(want) {
+		t.Fatalf("nonDominatedSort() = %v, want %v", got, want)
+	}
+	for i := range want {
+		slices.Sort(got[i])
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("nonDominatedSort() = %v, want %v", got, want)
+		}
+	}
+}
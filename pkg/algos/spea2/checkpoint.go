@@ -0,0 +1,58 @@
+package spea2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	Archive []json.RawMessage `json:"archive"`
+}
+
+// Checkpoint captures the full state of the run, including the external archive.
+// Fitness values are not stored since every generation recomputes them.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("spea2", solutions(alg.population))
+	if err != nil {
+		return nil, err
+	}
+	state := checkpointState{Archive: make([]json.RawMessage, len(alg.archive))}
+	for i, ind := range alg.archive {
+		if state.Archive[i], err = json.Marshal(ind.sol); err != nil {
+			return nil, fmt.Errorf("encoding archive member %d: %w", i, err)
+		}
+	}
+	cp.State, err = json.Marshal(state)
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("spea2", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	var state checkpointState
+	if err := json.Unmarshal(cp.State, &state); err != nil {
+		return fmt.Errorf("decoding spea2 state: %w", err)
+	}
+	archive, err := alg.DecodeSolutions(state.Archive)
+	if err != nil {
+		return fmt.Errorf("decoding archive: %w", err)
+	}
+
+	alg.population = make([]Individual, len(pop))
+	for i := range pop {
+		alg.population[i] = Individual{sol: pop[i]}
+	}
+	alg.archive = make([]Individual, len(archive))
+	for i := range archive {
+		alg.archive[i] = Individual{sol: archive[i]}
+	}
+	return nil
+}
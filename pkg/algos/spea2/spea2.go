@@ -3,16 +3,30 @@ package spea2
 import (
 	"context"
 	"math"
+	"math/rand/v2"
 	"slices"
 	"sort"
 	"time"
 
-	"math/rand/v2"
-
 	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	algos.Register("spea2", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
 // Individual wraps a solution plus SPEA2 metadata.
 type Individual struct {
 	sol      problems.Solution
@@ -28,7 +42,6 @@ type Algorithm struct {
 	params                 Params
 	population             []Individual
 	archive                []Individual
-	generation             int
 }
 
 // NewAlgorithm constructs a SPEA2Algorithm.
@@ -38,49 +51,78 @@ func NewAlgorithm(
 	generationLimit int,
 	logger algos.ProgressLoggerProvider,
 ) *Algorithm {
-	ga := algos.NewGeneticAlgorithm(problem, generationLimit, logger)
-	return &Algorithm{
+	ga := algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger)
+	ga.Evaluator = algos.NewEvaluator(params.Workers)
+	alg := &Algorithm{
 		GeneticAlgorithm: *ga,
 		params:           params,
-		generation:       0,
 	}
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
 }
 
 // Run executes SPEA2 until timeout or generation limit, logging each generation.
 func (alg *Algorithm) Run(ctx context.Context) {
-	alg.initPopulation()
-	alg.archive = nil
+	alg.Loop(ctx, alg.Step)
+}
 
-	for alg.generation < alg.GenerationLimit {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-		alg.generation++
+// Step performs one SPEA2 generation: fitness assignment, archive update and reproduction.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.initPopulation()
+	}
+	alg.Generation++
+
+	combined := slices.Concat(alg.population, alg.archive)
+	alg.Evaluator.Evaluate(solutions(combined))
+	alg.assignFitness(combined, alg.params.Constraints.Dominance(alg.Rand))
+	alg.updateArchive(combined)
+	alg.logParetoFront()
+	alg.reproduce()
+	alg.NotifyObservers()
+}
+
+// Seed fills the population with mutants of seedSolution, keeping the seed itself.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.population = make([]Individual, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = Individual{sol: alg.params.MutationFunc(alg.Rand, seedSolution)}
+	}
+	alg.population[0] = Individual{sol: seedSolution}
+	alg.archive = nil
+	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
 
-		combined := slices.Concat(alg.population, alg.archive)
-		alg.assignFitness(combined)
-		alg.updateArchive(combined)
-		alg.logParetoFront()
-		alg.reproduce()
+// SetPopulation replaces the current population and clears the archive.
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
 	}
+	alg.population = make([]Individual, alg.params.PopulationSize)
+	for i := range alg.params.PopulationSize {
+		alg.population[i] = Individual{sol: pop[i]}
+	}
+	alg.archive = nil
 }
 
-func (alg *Algorithm) GetSteps() int {
-	return alg.generation
+// GetPopulation returns the solutions of the current population.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	return solutions(alg.population)
 }
 
 // initPopulation initializes the population with random solutions.
 func (alg *Algorithm) initPopulation() {
 	alg.population = make([]Individual, alg.params.PopulationSize)
 	for i := range alg.population {
-		alg.population[i] = Individual{sol: alg.Problem.RandomSolution()}
+		alg.population[i] = Individual{sol: alg.Problem.RandomSolution(alg.Rand)}
 	}
+	alg.Evaluations += alg.params.PopulationSize
 }
 
-// assignFitness computes strength, raw fitness, density, and combined fitness.
-func (alg *Algorithm) assignFitness(all []Individual) {
+// assignFitness computes strength, raw fitness, density, and combined fitness
+// under the dominance relation dominates.
+func (alg *Algorithm) assignFitness(all []Individual, dominates func(a, b problems.Solution) bool) {
 	// strength
 	for i := range all {
 		all[i].strength = 0
@@ -131,18 +173,20 @@ func (alg *Algorithm) logParetoFront() {
 	for _, ind := range alg.archive {
 		if ind.rawFit < 1 {
 			pareto = append(pareto, ind.sol.Objectives())
-			if ind.sol.Fitness() < alg.Solution.Fitness() {
+			if algos.Better(ind.sol, alg.Solution) {
 				alg.Solution = ind.sol
 				improved = true
 			}
 		}
 	}
-	if improved && alg.ProgressLoggerProvider != nil {
-		alg.LogStep(algos.GAStep{
+	alg.ParetoFront = pareto
+	if improved {
+		alg.LogProgress(algos.GAStep{
 			Elapsed:     time.Since(alg.StartTimestamp),
+			Seed:        alg.RandSeed,
 			ParetoFront: pareto,
 			Solution:    alg.Solution,
-			Step:        alg.generation,
+			Step:        alg.Generation,
 		})
 	}
 }
@@ -150,13 +194,14 @@ func (alg *Algorithm) logParetoFront() {
 // reproduce creates the next population via binary tournament, crossover, and mutation.
 func (alg *Algorithm) reproduce() {
 	var nextP []Individual
+	pick := alg.selector()
 	for len(nextP) < alg.params.PopulationSize {
-		p1 := alg.tournamentSelect()
-		p2 := alg.tournamentSelect()
+		p1 := pick(alg.Rand)
+		p2 := pick(alg.Rand)
 
-		children := alg.params.CrossoverFunc(p1.sol, p2.sol)
+		children := algos.ApplyCrossover(alg.Rand, alg.params.CrossoverFunc, 1-alg.params.SkipCrossoverProb, p1.sol, p2.sol)
 		for _, child := range children {
-			child = alg.params.MutationFunc(child)
+			child = algos.ApplyMutation(alg.Rand, alg.params.MutationFunc, 1-alg.params.SkipMutationProb, child)
 			nextP = append(nextP, Individual{sol: child})
 			if len(nextP) >= alg.params.PopulationSize {
 				break
@@ -164,6 +209,16 @@ func (alg *Algorithm) reproduce() {
 		}
 	}
 	alg.population = nextP
+	alg.Evaluations += len(nextP)
+}
+
+// solutions extracts the candidate solutions of individuals.
+func solutions(pop []Individual) []problems.Solution {
+	sols := make([]problems.Solution, len(pop))
+	for i, ind := range pop {
+		sols[i] = ind.sol
+	}
+	return sols
 }
 
 // filter returns elements where keep returns true.
@@ -237,31 +292,34 @@ func computeKthDist(archive []Individual, i, k int) float64 {
 	return dists[k]
 }
 
+// selector returns the parent selection of a generation: binary tournaments on
+// the archive, unless Params.Selection is set.
+func (alg *Algorithm) selector() func(rng *rand.Rand) Individual {
+	if alg.params.Selection == nil {
+		return func(*rand.Rand) Individual { return alg.tournamentSelect() }
+	}
+	pick := alg.params.Selection.Prepare(archivePopulation(alg.archive))
+	return func(rng *rand.Rand) Individual { return alg.archive[pick(rng)] }
+}
+
+// archivePopulation is the view of the archive for selection, ordered by SPEA2 fitness.
+type archivePopulation []Individual
+
+func (a archivePopulation) Len() int                   { return len(a) }
+func (a archivePopulation) Better(i, j int) bool       { return a[i].fitness < a[j].fitness }
+func (a archivePopulation) Fitness(i int) float64      { return a[i].fitness }
+func (a archivePopulation) Objectives(i int) []float64 { return a[i].sol.Objectives() }
+
 // tournamentSelect chooses one archive member by binary tournament on fitness.
 func (alg *Algorithm) tournamentSelect() Individual {
-	i := rand.IntN(len(alg.archive))
-	j := rand.IntN(len(alg.archive))
+	i := alg.Rand.IntN(len(alg.archive))
+	j := alg.Rand.IntN(len(alg.archive))
 	if alg.archive[i].fitness < alg.archive[j].fitness {
 		return alg.archive[i]
 	}
 	return alg.archive[j]
 }
 
-// dominates checks Pareto dominance (minimize objectives).
-func dominates(a, b problems.Solution) bool {
-	ao, bo := a.Objectives(), b.Objectives()
-	strictly := false
-	for i := range ao {
-		if ao[i] > bo[i] {
-			return false
-		}
-		if ao[i] < bo[i] {
-			strictly = true
-		}
-	}
-	return strictly
-}
-
 // euclidean computes the Euclidean distance between two objective vectors.
 func euclidean(a, b []float64) float64 {
 	sum := 0.0
@@ -0,0 +1,58 @@
+package ssga
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	LoggedFitness float64 `json:"logged_fitness"`
+	Oldest        int     `json:"oldest,omitempty"`
+	// Order lists the slots of the population in the heap order of the
+	// replacement; without it Restore ranks the population anew, which may
+	// break ties between equally good solutions differently.
+	Order []int `json:"order,omitempty"`
+}
+
+// Checkpoint captures the full state of the run.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("ssga", alg.population)
+	if err != nil {
+		return nil, err
+	}
+	state := checkpointState{LoggedFitness: alg.loggedFitness, Oldest: alg.oldest}
+	if alg.order != nil {
+		state.Order = alg.order.heap
+	}
+	cp.State, err = json.Marshal(state)
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("ssga", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) != 0 && len(pop) != alg.params.PopulationSize {
+		return fmt.Errorf("checkpoint population has %d individuals, expected %d", len(pop), alg.params.PopulationSize)
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding ssga state: %w", err)
+		}
+	}
+	alg.population = pop
+	alg.order, alg.oldest = nil, state.Oldest
+	if state.Order != nil {
+		if alg.order = restoreWorstFirst(pop, alg.worse, state.Order); alg.order == nil {
+			return errors.New("checkpoint replacement order does not match its population")
+		}
+	}
+	alg.loggedFitness = state.LoggedFitness
+	return nil
+}
@@ -0,0 +1,95 @@
+package ssga
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+)
+
+// params runs SSGA on the sphere with the given replacement.
+func params(replacement Replacement) Params {
+	return Params{
+		PopulationSize: 20,
+		MutationFunc:   testutil.Nudge,
+		CrossoverFunc:  testutil.Midpoint,
+		Replacement:    replacement,
+		Window:         4,
+		Seed:           1,
+	}
+}
+
+func TestCheckpointResumesExactly(t *testing.T) {
+	sphere := testutil.Sphere{Dimensions: 2}
+	alg := NewAlgorithm(sphere, params(ReplaceWorst), 0, nil)
+	for range 50 {
+		alg.Step()
+	}
+	cp, err := alg.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a round trip through JSON, as in a checkpoint file
+	data, err := json.Marshal(cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp.State, cp.Population = nil, nil
+	if err := json.Unmarshal(data, cp); err != nil {
+		t.Fatal(err)
+	}
+
+	resumed := NewAlgorithm(sphere, params(ReplaceWorst), 0, nil)
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(resumed.order.heap, alg.order.heap) {
+		t.Fatalf("restored heap %v, want %v", resumed.order.heap, alg.order.heap)
+	}
+	for range 50 {
+		alg.Step()
+		resumed.Step()
+	}
+	for i, sol := range alg.GetPopulation() {
+		if got := resumed.GetPopulation()[i].Fitness(); got != sol.Fitness() {
+			t.Fatalf("slot %d has fitness %v after resuming, want %v", i, got, sol.Fitness())
+		}
+	}
+	if resumed.GetSteps() != 100 || resumed.Evaluations != alg.Evaluations {
+		t.Errorf("resumed run at step %d with %d evaluations, want 100 and %d", resumed.GetSteps(), resumed.Evaluations, alg.Evaluations)
+	}
+}
+
+func TestRestoreRejectsBrokenOrder(t *testing.T) {
+	sphere := testutil.Sphere{Dimensions: 2}
+	alg := NewAlgorithm(sphere, params(ReplaceWorst), 0, nil)
+	alg.Step()
+	tests := []struct {
+		name   string
+		mangle func(order []int) []int
+	}{
+		{"too short", func(order []int) []int { return order[1:] }},
+		{"repeated slot", func(order []int) []int { order[1] = order[2]; return order }},
+		{"best on top", func(order []int) []int { slices.Reverse(order); return order }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := alg.Checkpoint()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var state checkpointState
+			if err := json.Unmarshal(cp.State, &state); err != nil {
+				t.Fatal(err)
+			}
+			state.Order = tt.mangle(slices.Clone(state.Order))
+			if cp.State, err = json.Marshal(state); err != nil {
+				t.Fatal(err)
+			}
+			if err := NewAlgorithm(sphere, params(ReplaceWorst), 0, nil).Restore(cp); err == nil {
+				t.Error("Restore() accepted a broken replacement order")
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ssga
+
+import (
+	"slices"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// worstFirst is a binary heap over the slots of a population with the worst
+// solution on top. Restoring the order after the solution of a slot changed
+// costs O(log n), so a steady-state step never sorts the population.
+type worstFirst struct {
+	pop   []problems.Solution
+	worse func(a, b problems.Solution) bool
+	heap  []int // slots in heap order
+	pos   []int // pos[slot] is the index of slot in heap
+}
+
+func newWorstFirst(pop []problems.Solution, worse func(a, b problems.Solution) bool) *worstFirst {
+	h := &worstFirst{pop: pop, worse: worse, heap: make([]int, len(pop)), pos: make([]int, len(pop))}
+	for i := range pop {
+		h.heap[i], h.pos[i] = i, i
+	}
+	for i := len(pop)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// restoreWorstFirst rebuilds a heap from the slots in heap order saved by a
+// checkpoint, so slots that compare equal keep their places. It returns nil if
+// heap is not a valid heap over the slots of pop.
+func restoreWorstFirst(pop []problems.Solution, worse func(a, b problems.Solution) bool, heap []int) *worstFirst {
+	if len(heap) != len(pop) {
+		return nil
+	}
+	h := &worstFirst{pop: pop, worse: worse, heap: slices.Clone(heap), pos: make([]int, len(pop))}
+	seen := make([]bool, len(pop))
+	for i, slot := range heap {
+		if slot < 0 || slot >= len(pop) || seen[slot] {
+			return nil
+		}
+		seen[slot], h.pos[slot] = true, i
+		if i > 0 && h.above(i, (i-1)/2) {
+			return nil
+		}
+	}
+	return h
+}
+
+// top returns the slot of the worst solution.
+func (h *worstFirst) top() int {
+	return h.heap[0]
+}
+
+// worst returns the k worst slots, the worst first.
+func (h *worstFirst) worst(k int) []int {
+	slots := make([]int, 0, k)
+	frontier := []int{0}
+	for len(slots) < k && len(frontier) > 0 {
+		w := 0
+		for f := range frontier {
+			if h.above(frontier[f], frontier[w]) {
+				w = f
+			}
+		}
+		i := frontier[w]
+		frontier = append(frontier[:w], frontier[w+1:]...)
+		slots = append(slots, h.heap[i])
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < len(h.heap) {
+				frontier = append(frontier, child)
+			}
+		}
+	}
+	return slots
+}
+
+// fix restores the order after the solution of slot changed.
+func (h *worstFirst) fix(slot int) {
+	i := h.pos[slot]
+	if !h.up(i) {
+		h.down(i)
+	}
+}
+
+// above reports whether heap index i belongs above heap index j.
+func (h *worstFirst) above(i, j int) bool {
+	return h.worse(h.pop[h.heap[i]], h.pop[h.heap[j]])
+}
+
+func (h *worstFirst) swap(i, j int) {
+	h.heap[i], h.heap[j] = h.heap[j], h.heap[i]
+	h.pos[h.heap[i]], h.pos[h.heap[j]] = i, j
+}
+
+func (h *worstFirst) up(i int) bool {
+	moved := false
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.above(i, parent) {
+			break
+		}
+		h.swap(i, parent)
+		i, moved = parent, true
+	}
+	return moved
+}
+
+func (h *worstFirst) down(i int) {
+	n := len(h.heap)
+	for {
+		top := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < n && h.above(child, top) {
+				top = child
+			}
+		}
+		if top == i {
+			return
+		}
+		h.swap(i, top)
+		i = top
+	}
+}
@@ -0,0 +1,56 @@
+package ssga
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestWorstFirst(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	worse := func(a, b problems.Solution) bool { return a.Fitness() > b.Fitness() }
+	for _, n := range []int{1, 2, 7, 64} {
+		pop := make([]problems.Solution, n)
+		for i := range pop {
+			// few distinct values, so ties are common
+			pop[i] = testutil.Point{float64(rng.IntN(10))}
+		}
+		h := newWorstFirst(pop, worse)
+		for step := range 500 {
+			slot := rng.IntN(n)
+			pop[slot] = testutil.Point{float64(rng.IntN(10))}
+			h.fix(slot)
+
+			want := make([]float64, n)
+			for i, sol := range pop {
+				want[i] = sol.Fitness()
+			}
+			sort.Sort(sort.Reverse(sort.Float64Slice(want)))
+			if got := pop[h.top()].Fitness(); got != want[0] {
+				t.Fatalf("n = %d, step %d: top has fitness %v, want %v", n, step, got, want[0])
+			}
+			k := rng.IntN(n) + 1
+			worst := h.worst(k)
+			got := make([]float64, len(worst))
+			for i, slot := range worst {
+				got[i] = pop[slot].Fitness()
+			}
+			if !slices.Equal(got, want[:k]) {
+				t.Fatalf("n = %d, step %d: %d worst have fitness %v, want %v", n, step, k, got, want[:k])
+			}
+			slices.Sort(worst)
+			if len(slices.Compact(worst)) != k {
+				t.Fatalf("n = %d, step %d: worst(%d) repeats slots", n, step, k)
+			}
+			for i, slot := range h.heap {
+				if h.pos[slot] != i {
+					t.Fatalf("n = %d, step %d: pos of slot %d is %d, want %d", n, step, slot, h.pos[slot], i)
+				}
+			}
+		}
+	}
+}
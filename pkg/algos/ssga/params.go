@@ -1,9 +1,74 @@
 package ssga
 
-import "github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+import (
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/niching"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
 
 type Params struct {
-	PopulationSize int
-	MutationFunc   problems.MutationFunc
-	CrossoverFunc  problems.CrossoverFunc
+	PopulationSize    int
+	MutationFunc      problems.MutationFunc
+	CrossoverFunc     problems.CrossoverFunc
+	SkipCrossoverProb float64            // 1 - "crossover_prob": chance of copying a pair of parents instead of recombining them, so 0 always recombines
+	SkipMutationProb  float64            // 1 - "mutation_prob": chance of leaving a child unmutated, so 0 always mutates
+	Selection         selection.Selector // picks parents; nil runs binary tournaments
+	Replacement       Replacement
+	Window            int // individuals compared with a child by restricted tournament replacement
+	Constraints       algos.Constraints
+	// Niching also measures the distances of deterministic crowding and
+	// restricted tournament replacement; its crowding method is the same as
+	// DeterministicCrowding.
+	Niching niching.Niching
+	Seed    uint64 // seed of the run RNG; 0 picks a random seed
+	Workers int    // goroutines evaluating offspring; 0 or 1 evaluates serially
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.PopulationSize, err = m.Int("population_size", 100); err != nil {
+		return
+	}
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.CrossoverFunc, err = m.Crossover("crossover"); err != nil {
+		return
+	}
+	crossoverProb, err := m.Probability("crossover_prob", 1)
+	if err != nil {
+		return
+	}
+	mutationProb, err := m.Probability("mutation_prob", 1)
+	if err != nil {
+		return
+	}
+	// the zero value of Params recombines and mutates every time
+	params.SkipCrossoverProb, params.SkipMutationProb = 1-crossoverProb, 1-mutationProb
+	if params.Selection, err = selection.FromMap(m, "selection"); err != nil {
+		return
+	}
+	replacement, err := m.String("replacement", "worst")
+	if err != nil {
+		return
+	}
+	if params.Replacement, err = ReplacementByName(replacement); err != nil {
+		return
+	}
+	if params.Window, err = m.Int("window", 20); err != nil {
+		return
+	}
+	if params.Constraints, err = algos.ConstraintsFromMap(m); err != nil {
+		return
+	}
+	if params.Niching, err = niching.FromMap(m); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
 }
@@ -0,0 +1,116 @@
+package ssga
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/niching"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Replacement is the rule deciding which individuals the children of a step replace.
+type Replacement int
+
+const (
+	// ReplaceWorst puts the children in place of the worst individuals, even
+	// if they are worse.
+	ReplaceWorst Replacement = iota
+	// ReplaceIfBetter puts a child in place of the worst individual only if
+	// the child is better.
+	ReplaceIfBetter
+	// ReplaceOldest puts the children in place of the individuals that have
+	// been in the population the longest.
+	ReplaceOldest
+	// DeterministicCrowding (Mahfoud, 1995) pairs each child with the closer
+	// of its parents, which it replaces unless the parent is better.
+	DeterministicCrowding
+	// RestrictedTournament (Harik, 1995) compares each child with the closest
+	// of Window random individuals and replaces it if the child is better.
+	RestrictedTournament
+)
+
+// ReplacementByName resolves "worst", "if-better", "oldest", "crowding" and "rtr".
+func ReplacementByName(name string) (Replacement, error) {
+	switch name {
+	case "worst":
+		return ReplaceWorst, nil
+	case "if-better":
+		return ReplaceIfBetter, nil
+	case "oldest":
+		return ReplaceOldest, nil
+	case "crowding":
+		return DeterministicCrowding, nil
+	case "rtr":
+		return RestrictedTournament, nil
+	}
+	return 0, fmt.Errorf("unknown replacement %q", name)
+}
+
+// replace puts the children of the parents in the slots p1 and p2 into the
+// population under Params.Replacement.
+func (alg *Algorithm) replace(p1, p2 int, children []problems.Solution) {
+	replacement := alg.params.Replacement
+	if alg.params.Niching.Method == niching.Crowding {
+		replacement = DeterministicCrowding
+	}
+	switch replacement {
+	case ReplaceWorst:
+		for i, slot := range alg.order.worst(len(children)) {
+			alg.put(slot, children[i])
+		}
+	case ReplaceIfBetter:
+		for _, child := range children {
+			if slot := alg.order.top(); alg.better(child, alg.population[slot]) {
+				alg.put(slot, child)
+			}
+		}
+	case ReplaceOldest:
+		for _, child := range children {
+			alg.put(alg.oldest, child)
+			alg.oldest = (alg.oldest + 1) % len(alg.population)
+		}
+	case DeterministicCrowding:
+		parents := [2]int{p1, p2}
+		pairs := alg.params.Niching.Pair([2]problems.Solution{alg.population[p1], alg.population[p2]}, children)
+		for i, child := range children {
+			if slot := parents[pairs[i]]; !alg.better(alg.population[slot], child) {
+				alg.put(slot, child)
+			}
+		}
+	case RestrictedTournament:
+		window := min(alg.params.Window, len(alg.population))
+		for _, child := range children {
+			closest, closestDistance := 0, math.Inf(1)
+			for range window {
+				slot := alg.Rand.IntN(len(alg.population))
+				if d := alg.params.Niching.Measure(child, alg.population[slot]); d < closestDistance {
+					closest, closestDistance = slot, d
+				}
+			}
+			if alg.better(child, alg.population[closest]) {
+				alg.put(closest, child)
+			}
+		}
+	}
+}
+
+// put places sol in slot and restores the replacement order.
+func (alg *Algorithm) put(slot int, sol problems.Solution) {
+	alg.population[slot] = sol
+	alg.order.fix(slot)
+}
+
+// better ranks solutions for replacement under the constraint handling.
+// Stochastic ranking has no fixed order, so it ranks by constrained domination
+// here and applies only in the parent tournaments.
+func (alg *Algorithm) better(a, b problems.Solution) bool {
+	if alg.params.Constraints.Handling == algos.StochasticRanking {
+		return algos.Better(a, b)
+	}
+	return alg.params.Constraints.Less(nil, a, b)
+}
+
+func (alg *Algorithm) worse(a, b problems.Solution) bool {
+	return alg.better(b, a)
+}
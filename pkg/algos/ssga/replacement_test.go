@@ -0,0 +1,121 @@
+package ssga
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// line returns one-dimensional sphere points at x.
+func line(x ...float64) []problems.Solution {
+	pop := make([]problems.Solution, len(x))
+	for i, v := range x {
+		pop[i] = &testutil.SphereSolution{X: []float64{v}}
+	}
+	return pop
+}
+
+func coordinates(pop []problems.Solution) []float64 {
+	x := make([]float64, len(pop))
+	for i, sol := range pop {
+		x[i] = sol.(*testutil.SphereSolution).X[0]
+	}
+	return x
+}
+
+func TestReplacement(t *testing.T) {
+	tests := []struct {
+		name        string
+		replacement Replacement
+		children    []float64
+		want        []float64
+	}{
+		// the worse child takes the worst slot, the better one the next
+		{"worst", ReplaceWorst, []float64{10, 0.5}, []float64{1, 2, 3, 4, 0.5, 10}},
+		{"if better", ReplaceIfBetter, []float64{10, 0.5}, []float64{1, 2, 3, 4, 5, 0.5}},
+		{"oldest", ReplaceOldest, []float64{10, 0.5}, []float64{10, 0.5, 3, 4, 5, 6}},
+		// 0.8 beats its closer parent 1, 7 loses to its closer parent 6
+		{"deterministic crowding", DeterministicCrowding, []float64{0.8, 7}, []float64{0.8, 2, 3, 4, 5, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := params(tt.replacement)
+			p.PopulationSize, p.Window = 6, 100
+			alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, p, 0, nil)
+			alg.SetPopulation(line(1, 2, 3, 4, 5, 6))
+			alg.rank()
+			alg.replace(0, 5, line(tt.children...))
+			if got := coordinates(alg.population); !slices.Equal(got, tt.want) {
+				t.Errorf("population %v, want %v", got, tt.want)
+			}
+			if top := alg.population[alg.order.top()].Fitness(); top != slices.Max(squares(alg.population)) {
+				t.Errorf("worst slot has fitness %v after the replacement", top)
+			}
+		})
+	}
+}
+
+func TestRestrictedTournament(t *testing.T) {
+	p := params(RestrictedTournament)
+	p.PopulationSize, p.Window = 6, 6
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, p, 0, nil)
+	alg.SetPopulation(line(1, 1, 1, 6, 6, 6))
+	alg.rank()
+	// 5.9 beats the 6 it is closest to; 1.1 is better than any 6 but loses to its neighbor 1
+	alg.replace(0, 3, line(5.9, 1.1))
+	got := coordinates(alg.population)
+	if !slices.Contains(got, 5.9) || slices.Contains(got, 1.1) {
+		t.Errorf("population %v, want 5.9 in place of a 6 and no 1.1", got)
+	}
+	if slices.Index(got, 5.9) < 3 {
+		t.Errorf("5.9 replaced a distant individual: %v", got)
+	}
+}
+
+func squares(pop []problems.Solution) []float64 {
+	f := make([]float64, len(pop))
+	for i, sol := range pop {
+		f[i] = sol.Fitness()
+	}
+	return f
+}
+
+func TestReplaceOldestCycles(t *testing.T) {
+	p := params(ReplaceOldest)
+	p.PopulationSize = 3
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, p, 0, nil)
+	alg.SetPopulation(line(1, 2, 3))
+	alg.rank()
+	alg.replace(0, 1, line(7, 8))
+	alg.replace(0, 1, line(9, 10))
+	if got := coordinates(alg.population); !slices.Equal(got, []float64{10, 8, 9}) {
+		t.Errorf("population %v, want [10 8 9]", got)
+	}
+}
+
+func TestSetPopulationResetsBest(t *testing.T) {
+	p := params(ReplaceWorst)
+	p.PopulationSize = 3
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, p, 0, nil)
+	alg.SetPopulation(line(0.1, 2, 3))
+	alg.SetPopulation(line(4, 2, 3))
+	if got := alg.GetSolution().Fitness(); got != 4 {
+		t.Errorf("best fitness %v, want 4 from the new population", got)
+	}
+}
+
+func TestReplacementByName(t *testing.T) {
+	for name, want := range map[string]Replacement{
+		"worst": ReplaceWorst, "if-better": ReplaceIfBetter, "oldest": ReplaceOldest,
+		"crowding": DeterministicCrowding, "rtr": RestrictedTournament,
+	} {
+		if got, err := ReplacementByName(name); err != nil || got != want {
+			t.Errorf("ReplacementByName(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := ReplacementByName("best"); err == nil {
+		t.Error("ReplacementByName() accepted an unknown name")
+	}
+}
@@ -3,18 +3,36 @@ package ssga
 import (
 	"context"
 	"math/rand/v2"
-	"sort"
+	"slices"
 	"time"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/selection"
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	algos.Register("ssga", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
 type Algorithm struct {
 	algos.GeneticAlgorithm
-	params     Params
-	generation int
-	population []problems.Solution
+	params        Params
+	population    []problems.Solution
+	order         *worstFirst // replacement order of population; nil until ranked
+	oldest        int         // slot replaced next by ReplaceOldest
+	loggedFitness float64
 }
 
 func NewAlgorithm(
@@ -23,49 +41,68 @@ func NewAlgorithm(
 	generationLimit int,
 	logger algos.ProgressLoggerProvider,
 ) *Algorithm {
-	return &Algorithm{
-		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, logger),
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
 		params:           params,
-		generation:       0,
 	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
 }
 
 func (alg *Algorithm) Run(ctx context.Context) {
-	alg.InitPopulation()
-	fitness := 0.0
-	for alg.generation < alg.GenerationLimit {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
-		alg.Evolve()
-		alg.generation++
-		bestFitness := alg.Solution.Fitness()
-		if fitness != bestFitness {
-			fitness = bestFitness
-			if alg.ProgressLoggerProvider != nil {
-				alg.LogStep(algos.GAStep{Elapsed: time.Since(alg.StartTimestamp), Solution: alg.Solution, Step: alg.generation})
-			}
-		}
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step replaces one pair of individuals and logs the best solution if it changed.
+func (alg *Algorithm) Step() {
+	if len(alg.population) < alg.params.PopulationSize {
+		alg.InitPopulation()
 	}
+	alg.Evolve()
+	alg.Generation++
+	bestFitness := alg.Solution.Fitness()
+	if alg.loggedFitness != bestFitness {
+		alg.loggedFitness = bestFitness
+		alg.LogProgress(algos.GAStep{Elapsed: time.Since(alg.StartTimestamp), Seed: alg.RandSeed, Solution: alg.Solution, Step: alg.Generation})
+	}
+	alg.NotifyObservers()
 }
 
 func (alg *Algorithm) InitPopulation() {
 	pop := make([]problems.Solution, alg.params.PopulationSize)
 	for i := range pop {
-		pop[i] = alg.Problem.RandomSolution()
+		pop[i] = alg.Problem.RandomSolution(alg.Rand)
 	}
 	alg.population = pop
+	alg.order, alg.oldest = nil, 0
+	alg.Evaluations += len(pop)
 }
 
 func (alg *Algorithm) Seed(seedSolution problems.Solution) {
 	alg.population = make([]problems.Solution, alg.params.PopulationSize)
 	for i := range alg.params.PopulationSize {
-		alg.population[i] = alg.params.MutationFunc(seedSolution)
+		alg.population[i] = alg.params.MutationFunc(alg.Rand, seedSolution)
 	}
 	alg.population[0] = seedSolution
+	alg.order, alg.oldest = nil, 0
 	alg.Solution = seedSolution
+	alg.Evaluations += alg.params.PopulationSize
+}
+
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) != alg.params.PopulationSize {
+		panic("Wrong population size")
+	}
+	alg.population = make([]problems.Solution, alg.params.PopulationSize)
+	copy(alg.population, pop)
+	alg.order, alg.oldest = nil, 0
+	alg.Solution = pop[0]
+	for _, sol := range pop[1:] {
+		if algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+	}
 }
 
 func (alg *Algorithm) GetPopulation() []problems.Solution {
@@ -74,48 +111,81 @@ func (alg *Algorithm) GetPopulation() []problems.Solution {
 	return pop
 }
 
-func (alg *Algorithm) GetSteps() int {
-	return alg.generation
-}
-
+// Evolve breeds one pair of parents and lets the children replace members of
+// the population under Params.Replacement. With the default tournaments a step
+// costs O(log n) on top of the evaluations; a custom Params.Selection is
+// prepared on the whole population every step and duplicate elimination
+// compares every child with the whole population, so both cost O(n) or more.
 func (alg *Algorithm) Evolve() {
-	alg.evaluateGeneration()
-
-	replaced := false
-	for !replaced {
-		p1Ind := alg.tournamentSelect()
-		p2Ind := alg.tournamentSelect()
-		if p1Ind == p2Ind {
-			continue
-		}
-		parent1 := alg.population[p1Ind]
-		parent2 := alg.population[p2Ind]
+	if alg.order == nil {
+		alg.rank()
+	}
 
-		children := alg.params.CrossoverFunc(parent1, parent2)
+	pick := alg.selector()
+	p1Ind, p2Ind := pick(alg.Rand), pick(alg.Rand)
+	for p1Ind == p2Ind && alg.params.Selection == nil && len(alg.population) > 1 {
+		p1Ind, p2Ind = pick(alg.Rand), pick(alg.Rand)
+	}
+	parent1 := alg.population[p1Ind]
+	parent2 := alg.population[p2Ind]
 
-		for i := range children {
-			children[i] = alg.params.MutationFunc(children[i])
-			alg.population[alg.params.PopulationSize-i-1] = children[i]
+	children := algos.ApplyCrossover(alg.Rand, alg.params.CrossoverFunc, 1-alg.params.SkipCrossoverProb, parent1, parent2)
+	for i := range children {
+		children[i] = algos.ApplyMutation(alg.Rand, alg.params.MutationFunc, 1-alg.params.SkipMutationProb, children[i])
+	}
+	children = slices.DeleteFunc(children, func(child problems.Solution) bool {
+		return alg.params.Niching.Duplicate(child, alg.population)
+	})
+	alg.Evaluator.Evaluate(children)
+	alg.Evaluations += len(children)
+	for _, child := range children {
+		if algos.Better(child, alg.Solution) {
+			alg.Solution = child
 		}
-		replaced = true
 	}
+	alg.replace(p1Ind, p2Ind, children)
 }
 
-func (alg *Algorithm) evaluateGeneration() {
-	// descending fitness
-	sort.Slice(alg.population, func(i, j int) bool {
-		return alg.population[i].Fitness() < alg.population[j].Fitness()
+// rank evaluates a new population and builds its replacement order.
+func (alg *Algorithm) rank() {
+	alg.Evaluator.Evaluate(alg.population)
+	alg.order = newWorstFirst(alg.population, alg.worse)
+	// stochastic ranking may put an infeasible solution first
+	for _, sol := range alg.population {
+		if algos.Better(sol, alg.Solution) {
+			alg.Solution = sol
+		}
+	}
+}
+
+// selector returns the parent selection of a step: binary tournaments, unless
+// Params.Selection is set.
+func (alg *Algorithm) selector() func(rng *rand.Rand) int {
+	if alg.params.Selection == nil {
+		return func(*rand.Rand) int { return alg.tournamentSelect() }
+	}
+	return alg.params.Selection.Prepare(selection.Solutions{
+		Solutions:   alg.population,
+		Constraints: alg.params.Constraints,
+		Rand:        alg.Rand,
 	})
-	alg.Solution = alg.population[0]
 }
 
+// tournamentSelect runs a binary tournament, on the niched fitness under
+// sharing or clearing.
 func (alg *Algorithm) tournamentSelect() int {
-	ind1 := rand.IntN(alg.params.PopulationSize)
-	ind2 := rand.IntN(alg.params.PopulationSize)
+	ind1 := alg.Rand.IntN(alg.params.PopulationSize)
+	ind2 := alg.Rand.IntN(alg.params.PopulationSize)
 	if ind1 == ind2 {
 		return ind1
 	}
-	if alg.population[ind1].Fitness() < alg.population[ind2].Fitness() {
+	if alg.params.Niching.Reshapes() {
+		if alg.params.Niching.Less(alg.params.Constraints, alg.population, alg.population[ind1], alg.population[ind2]) {
+			return ind1
+		}
+		return ind2
+	}
+	if alg.params.Constraints.Less(alg.Rand, alg.population[ind1], alg.population[ind2]) {
 		return ind1
 	}
 	return ind2
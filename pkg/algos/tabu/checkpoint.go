@@ -0,0 +1,51 @@
+package tabu
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+type checkpointState struct {
+	Tabu          [][]float64 `json:"tabu"`
+	LoggedFitness float64     `json:"logged_fitness"`
+}
+
+// Checkpoint captures the full state of the run: the current solution and the tabu list.
+func (alg *Algorithm) Checkpoint() (*algos.Checkpoint, error) {
+	cp, err := alg.NewCheckpoint("tabu", alg.GetPopulation())
+	if err != nil {
+		return nil, err
+	}
+	cp.State, err = json.Marshal(checkpointState{Tabu: alg.recent, LoggedFitness: alg.loggedFitness})
+	return cp, err
+}
+
+// Restore resumes the run from a checkpoint made by an algorithm with the same problem and params.
+func (alg *Algorithm) Restore(cp *algos.Checkpoint) error {
+	pop, err := alg.RestoreCheckpoint("tabu", cp)
+	if err != nil {
+		return err
+	}
+	if len(pop) > 1 {
+		return fmt.Errorf("checkpoint has %d current solutions, expected 1", len(pop))
+	}
+	var state checkpointState
+	if len(cp.State) > 0 {
+		if err := json.Unmarshal(cp.State, &state); err != nil {
+			return fmt.Errorf("decoding tabu state: %w", err)
+		}
+	}
+	alg.current = nil
+	if len(pop) == 1 {
+		alg.current = pop[0]
+	}
+	alg.recent = nil
+	alg.tabu = make(map[string]int)
+	for _, objectives := range state.Tabu {
+		alg.remember(objectives)
+	}
+	alg.loggedFitness = state.LoggedFitness
+	return nil
+}
@@ -0,0 +1,43 @@
+package tabu
+
+import (
+	"fmt"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Params holds configurable parameters for tabu search.
+type Params struct {
+	// MutationFunc draws a neighbor of the current solution.
+	MutationFunc problems.MutationFunc
+	// Neighbors is the number of neighbors sampled per step.
+	Neighbors int
+	// Tenure is the number of steps a visited solution stays tabu.
+	Tenure  int
+	Seed    uint64 // seed of the run RNG; 0 picks a random seed
+	Workers int    // goroutines evaluating neighbors; 0 or 1 evaluates serially
+}
+
+// ParamsFromMap builds Params from a registry parameter map.
+func ParamsFromMap(m algos.ParamMap) (params Params, err error) {
+	if params.MutationFunc, err = m.Mutation("mutation"); err != nil {
+		return
+	}
+	if params.Neighbors, err = m.Int("neighbors", 20); err != nil {
+		return
+	}
+	if params.Neighbors < 1 {
+		err = fmt.Errorf("parameter %q: need at least one neighbor, got %d", "neighbors", params.Neighbors)
+		return
+	}
+	if params.Tenure, err = m.Int("tenure", 10); err != nil {
+		return
+	}
+	if params.Workers, err = m.Int("workers", 0); err != nil {
+		return
+	}
+	seed, err := m.Int("seed", 0)
+	params.Seed = uint64(seed)
+	return
+}
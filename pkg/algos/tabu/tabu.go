@@ -0,0 +1,167 @@
+package tabu
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func init() {
+	algos.Register("tabu", func(problem problems.Problem, m algos.ParamMap, generationLimit int, logger algos.ProgressLoggerProvider) (algos.Algorithm, error) {
+		params, err := ParamsFromMap(m)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlgorithm(problem, params, generationLimit, logger), nil
+	})
+}
+
+var (
+	_ algos.Algorithm    = (*Algorithm)(nil)
+	_ algos.Checkpointer = (*Algorithm)(nil)
+)
+
+// Algorithm implements tabu search on Fitness with the mutation operator as the
+// neighborhood. Every step moves to the best of Neighbors sampled neighbors, even
+// if it is worse than the current solution, unless it is tabu. The mutation
+// operators know nothing about move attributes, so solutions are told apart by
+// their objective vectors: the last Tenure visited vectors are tabu, except for
+// a neighbor better than the best solution found (the aspiration criterion).
+type Algorithm struct {
+	algos.GeneticAlgorithm
+	params  Params
+	current problems.Solution
+	// recent holds the objective vectors of the latest visited solutions, oldest first.
+	recent        [][]float64
+	tabu          map[string]int
+	loggedFitness float64
+}
+
+// NewAlgorithm creates a new tabu search instance.
+func NewAlgorithm(problem problems.Problem, params Params, generationLimit int, logger algos.ProgressLoggerProvider) *Algorithm {
+	alg := &Algorithm{
+		GeneticAlgorithm: *algos.NewGeneticAlgorithm(problem, generationLimit, params.Seed, logger),
+		params:           params,
+		tabu:             make(map[string]int),
+	}
+	alg.Evaluator = algos.NewEvaluator(params.Workers)
+	alg.ObservePopulation(alg.GetPopulation)
+	return alg
+}
+
+// Seed starts the search from seedSolution.
+func (alg *Algorithm) Seed(seedSolution problems.Solution) {
+	alg.moveTo(seedSolution)
+	alg.Solution = seedSolution
+	alg.Evaluations++
+}
+
+// SetPopulation starts the search from the fittest solution of pop.
+func (alg *Algorithm) SetPopulation(pop []problems.Solution) {
+	if len(pop) == 0 {
+		panic("Wrong population size")
+	}
+	start := pop[0]
+	for _, sol := range pop[1:] {
+		if algos.Better(sol, start) {
+			start = sol
+		}
+	}
+	alg.moveTo(start)
+	alg.Solution = start
+}
+
+// GetPopulation returns the current solution.
+func (alg *Algorithm) GetPopulation() []problems.Solution {
+	if alg.current == nil {
+		return nil
+	}
+	return []problems.Solution{alg.current}
+}
+
+// Run executes tabu search until timeout.
+func (alg *Algorithm) Run(ctx context.Context) {
+	alg.Loop(ctx, alg.Step)
+}
+
+// Step makes one move and logs the best solution if it changed. When every
+// sampled neighbor is tabu the search stays where it is.
+func (alg *Algorithm) Step() {
+	if alg.current == nil {
+		alg.moveTo(alg.Problem.RandomSolution(alg.Rand))
+		alg.Evaluations++
+	}
+
+	alg.Generation++
+
+	neighbors := make([]problems.Solution, alg.params.Neighbors)
+	for i := range neighbors {
+		neighbors[i] = alg.params.MutationFunc(alg.Rand, alg.current)
+	}
+	alg.Evaluator.Evaluate(neighbors)
+	alg.Evaluations += len(neighbors)
+
+	var next problems.Solution
+	for _, neighbor := range neighbors {
+		if alg.tabu[key(neighbor.Objectives())] > 0 && !algos.Better(neighbor, alg.Solution) {
+			continue
+		}
+		if next == nil || algos.Better(neighbor, next) {
+			next = neighbor
+		}
+	}
+	if next != nil {
+		alg.moveTo(next)
+	}
+	if algos.Better(alg.current, alg.Solution) {
+		alg.Solution = alg.current
+	}
+
+	if alg.Solution.Fitness() != alg.loggedFitness {
+		alg.loggedFitness = alg.Solution.Fitness()
+		alg.LogProgress(algos.GAStep{
+			Elapsed:  time.Since(alg.StartTimestamp),
+			Seed:     alg.RandSeed,
+			Step:     alg.Generation,
+			Solution: alg.Solution,
+		})
+	}
+	alg.NotifyObservers()
+}
+
+// moveTo makes sol the current solution and tabu, releasing the oldest tabu
+// solution once the list is longer than the tenure.
+func (alg *Algorithm) moveTo(sol problems.Solution) {
+	alg.current = sol
+	alg.remember(sol.Objectives())
+}
+
+func (alg *Algorithm) remember(objectives []float64) {
+	if alg.params.Tenure <= 0 {
+		return
+	}
+	alg.recent = append(alg.recent, objectives)
+	alg.tabu[key(objectives)]++
+	for len(alg.recent) > alg.params.Tenure {
+		oldest := key(alg.recent[0])
+		if alg.tabu[oldest]--; alg.tabu[oldest] == 0 {
+			delete(alg.tabu, oldest)
+		}
+		alg.recent = alg.recent[1:]
+	}
+}
+
+// key identifies an objective vector exactly.
+func key(objectives []float64) string {
+	var b strings.Builder
+	for _, v := range objectives {
+		b.WriteString(strconv.FormatUint(math.Float64bits(v), 16))
+		b.WriteByte(',')
+	}
+	return b.String()
+}
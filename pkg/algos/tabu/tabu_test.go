@@ -0,0 +1,97 @@
+package tabu
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// at is the point x of a walk on the integers minimizing |x|.
+func at(x float64) testutil.Point { return testutil.Point{math.Abs(x), x} }
+
+// steps draws the neighbors x-1 and x+1 of a walk in turn.
+func steps() problems.MutationFunc {
+	calls := 0
+	return func(_ *rand.Rand, individual problems.Solution) problems.Solution {
+		calls++
+		x := individual.(testutil.Point)[1]
+		if calls%2 == 1 {
+			return at(x - 1)
+		}
+		return at(x + 1)
+	}
+}
+
+func TestTenure(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{Tenure: 2}, 0, nil)
+	for _, x := range []float64{1, 2, 3} {
+		alg.remember(at(x).Objectives())
+	}
+	if alg.tabu[key(at(1).Objectives())] != 0 || len(alg.tabu) != 2 {
+		t.Errorf("tabu %v after visiting 1, 2, 3 with tenure 2, want 2 and 3", alg.tabu)
+	}
+	// a revisit keeps 2 tabu while the older visit expires
+	alg.remember(at(2).Objectives())
+	if alg.tabu[key(at(2).Objectives())] != 1 || alg.tabu[key(at(3).Objectives())] != 1 || len(alg.recent) != 2 {
+		t.Errorf("tabu %v after revisiting 2, want 2 and 3 once each", alg.tabu)
+	}
+
+	alg = NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{Tenure: 0}, 0, nil)
+	alg.remember(at(1).Objectives())
+	if len(alg.tabu) != 0 {
+		t.Errorf("tabu %v with tenure 0", alg.tabu)
+	}
+}
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		name   string
+		tenure int
+		want   float64
+	}{
+		// the walk leaves the optimum 0 and cannot go back to it
+		{"tabu", 10, -2},
+		// without a tabu list it falls back into the optimum
+		{"no tenure", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{MutationFunc: steps(), Neighbors: 2, Tenure: tt.tenure}, 0, nil)
+			alg.SetPopulation([]problems.Solution{at(2)})
+			for range 4 {
+				alg.Step()
+			}
+			if got := alg.GetPopulation()[0].(testutil.Point)[1]; got != tt.want {
+				t.Errorf("walk at %v, want %v", got, tt.want)
+			}
+			if got := alg.GetSolution().Fitness(); got != 0 {
+				t.Errorf("best fitness %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestAspiration(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{MutationFunc: steps(), Neighbors: 2, Tenure: 10}, 0, nil)
+	alg.SetPopulation([]problems.Solution{at(2)})
+	alg.remember(at(1).Objectives())
+	alg.Step()
+	if got := alg.GetPopulation()[0].(testutil.Point)[1]; got != 1 {
+		t.Errorf("walk at %v, want the tabu but best 1", got)
+	}
+}
+
+func TestSetPopulationResetsBest(t *testing.T) {
+	alg := NewAlgorithm(testutil.Sphere{Dimensions: 1}, Params{MutationFunc: steps(), Neighbors: 2, Tenure: 10}, 0, nil)
+	alg.SetPopulation([]problems.Solution{at(0)})
+	alg.SetPopulation([]problems.Solution{at(5), at(-3)})
+	if got := alg.GetSolution().Fitness(); got != 3 {
+		t.Errorf("best fitness %v, want 3 from the new population", got)
+	}
+	if got := alg.GetPopulation()[0].Fitness(); got != 3 {
+		t.Errorf("search starts from fitness %v, want 3", got)
+	}
+}
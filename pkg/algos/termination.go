@@ -0,0 +1,23 @@
+package algos
+
+import (
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// RunState is the progress of a run as seen by termination criteria.
+type RunState struct {
+	Generation  int
+	Evaluations int
+	Elapsed     time.Duration
+	Best        problems.Solution
+	ParetoFront [][]float64
+}
+
+// Criterion decides whether a run should stop. It is checked before every step
+// and reports a human readable reason when it fires. Implementations live in
+// the termination package.
+type Criterion interface {
+	Done(state RunState) (bool, string)
+}
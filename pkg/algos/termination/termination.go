@@ -0,0 +1,146 @@
+package termination
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/metrics"
+)
+
+// Func adapts an ordinary function to the algos.Criterion interface.
+type Func func(state algos.RunState) (bool, string)
+
+func (f Func) Done(state algos.RunState) (bool, string) {
+	return f(state)
+}
+
+// Any stops the run as soon as one of the criteria fires (logical OR).
+func Any(criteria ...algos.Criterion) algos.Criterion {
+	return Func(func(state algos.RunState) (bool, string) {
+		for _, c := range criteria {
+			if done, reason := c.Done(state); done {
+				return true, reason
+			}
+		}
+		return false, ""
+	})
+}
+
+// All stops the run only when every criterion fires (logical AND).
+// Every criterion is checked each time so stateful ones keep their history.
+func All(criteria ...algos.Criterion) algos.Criterion {
+	return Func(func(state algos.RunState) (bool, string) {
+		reasons := make([]string, 0, len(criteria))
+		for _, c := range criteria {
+			if done, reason := c.Done(state); done {
+				reasons = append(reasons, reason)
+			}
+		}
+		if len(criteria) == 0 || len(reasons) < len(criteria) {
+			return false, ""
+		}
+		return true, strings.Join(reasons, " and ")
+	})
+}
+
+// MaxEvaluations stops after n solutions have been created and evaluated.
+func MaxEvaluations(n int) algos.Criterion {
+	return Func(func(state algos.RunState) (bool, string) {
+		if state.Evaluations >= n {
+			return true, fmt.Sprintf("evaluation budget of %d reached", n)
+		}
+		return false, ""
+	})
+}
+
+// TimeBudget stops once the run has been going for d.
+func TimeBudget(d time.Duration) algos.Criterion {
+	return Func(func(state algos.RunState) (bool, string) {
+		if state.Elapsed >= d {
+			return true, fmt.Sprintf("time budget of %v exhausted", d)
+		}
+		return false, ""
+	})
+}
+
+// TargetFitness stops when the best fitness drops to target or below.
+func TargetFitness(target float64) algos.Criterion {
+	return Func(func(state algos.RunState) (bool, string) {
+		if state.Best != nil && state.Best.Fitness() <= target {
+			return true, fmt.Sprintf("target fitness %g reached", target)
+		}
+		return false, ""
+	})
+}
+
+// TargetObjective stops when objective index of the best solution drops to target or below,
+// e.g. TargetObjective(0, 0) stops a graphplane run once a layout without intersections is found.
+func TargetObjective(index int, target float64) algos.Criterion {
+	return Func(func(state algos.RunState) (bool, string) {
+		if state.Best != nil && state.Best.Objectives()[index] <= target {
+			return true, fmt.Sprintf("target %g of objective %d reached", target, index)
+		}
+		return false, ""
+	})
+}
+
+// Stagnation stops when the best fitness has not improved by more than epsilon for n generations.
+// It starts afresh when the generation goes back, as when it is reused for another run.
+func Stagnation(n int, epsilon float64) algos.Criterion {
+	best, since, last := 0.0, -1, 0
+	return Func(func(state algos.RunState) (bool, string) {
+		if state.Generation < last {
+			since = -1
+		}
+		last = state.Generation
+		if state.Best == nil {
+			return false, ""
+		}
+		fitness := state.Best.Fitness()
+		if since < 0 || fitness < best-epsilon {
+			best, since = fitness, state.Generation
+			return false, ""
+		}
+		if state.Generation-since >= n {
+			return true, fmt.Sprintf("no improvement for %d generations", n)
+		}
+		return false, ""
+	})
+}
+
+// HypervolumeStagnation stops a multi-objective run when the hypervolume of the
+// Pareto front (with respect to ref) improved by less than epsilon over the last window generations.
+// Single-objective runs are measured on the objectives of the best solution.
+// It starts afresh when the generation goes back, as when it is reused for another run.
+func HypervolumeStagnation(ref []float64, window int, epsilon float64) algos.Criterion {
+	var history []float64
+	last, measured := 0, -1
+	return Func(func(state algos.RunState) (bool, string) {
+		if state.Generation < last {
+			history, measured = history[:0], -1
+		}
+		last = state.Generation
+		front := state.ParetoFront
+		if len(front) == 0 && state.Best != nil {
+			front = [][]float64{state.Best.Objectives()}
+		}
+		if len(front) == 0 {
+			return false, ""
+		}
+		// a generation checked again, e.g. by a resumed Run, is measured once
+		if state.Generation == measured {
+			history = history[:len(history)-1]
+		}
+		history, measured = append(history, metrics.Hypervolume(front, ref)), state.Generation
+		if len(history) <= window {
+			return false, ""
+		}
+		history = history[len(history)-window-1:]
+		if improvement := history[window] - history[0]; improvement < epsilon {
+			return true, fmt.Sprintf("hypervolume improved by %g (< %g) in %d generations", improvement, epsilon, window)
+		}
+		return false, ""
+	})
+}
@@ -0,0 +1,123 @@
+package termination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+)
+
+func TestCriteria(t *testing.T) {
+	fires := Func(func(algos.RunState) (bool, string) { return true, "a" })
+	waits := Func(func(algos.RunState) (bool, string) { return false, "" })
+	tests := []struct {
+		name      string
+		criterion algos.Criterion
+		state     algos.RunState
+		want      bool
+		reason    string
+	}{
+		{"evaluations below budget", MaxEvaluations(100), algos.RunState{Evaluations: 99}, false, ""},
+		{"evaluations at budget", MaxEvaluations(100), algos.RunState{Evaluations: 100}, true, "evaluation budget of 100 reached"},
+		{"time left", TimeBudget(time.Second), algos.RunState{Elapsed: time.Millisecond}, false, ""},
+		{"time over", TimeBudget(time.Second), algos.RunState{Elapsed: time.Second}, true, "time budget of 1s exhausted"},
+		{"no best yet", TargetFitness(0), algos.RunState{}, false, ""},
+		{"fitness above target", TargetFitness(0), algos.RunState{Best: testutil.Point{0.5}}, false, ""},
+		{"fitness at target", TargetFitness(0), algos.RunState{Best: testutil.Point{0}}, true, "target fitness 0 reached"},
+		{"objective at target", TargetObjective(1, 2), algos.RunState{Best: testutil.Point{5, 2}}, true, "target 2 of objective 1 reached"},
+		{"any of none", Any(), algos.RunState{}, false, ""},
+		{"any fires", Any(waits, fires), algos.RunState{}, true, "a"},
+		{"all of none", All(), algos.RunState{}, false, ""},
+		{"all but one", All(fires, waits), algos.RunState{}, false, ""},
+		{"all fire", All(fires, fires), algos.RunState{}, true, "a and a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, reason := tt.criterion.Done(tt.state)
+			if done != tt.want || reason != tt.reason {
+				t.Errorf("Done() = %v, %q, want %v, %q", done, reason, tt.want, tt.reason)
+			}
+		})
+	}
+}
+
+func TestStagnation(t *testing.T) {
+	// fitness of the best solution in generations 0, 1, ...
+	tests := []struct {
+		name    string
+		fitness []float64
+		stopsAt int // -1 if the criterion never fires
+	}{
+		{"steady improvement", []float64{5, 4, 3, 2, 1}, -1},
+		{"flat from the start", []float64{5, 5, 5, 5}, 3},
+		{"improvements within epsilon", []float64{5, 4.95, 4.92, 4.91}, 3},
+		{"small steps adding up", []float64{5, 4.95, 4.9, 4.85, 4.8}, -1},
+		{"improvement resets the count", []float64{5, 5, 4, 4, 4, 4}, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criterion := Stagnation(3, 0.1)
+			stopped := -1
+			for gen, f := range tt.fitness {
+				if done, _ := criterion.Done(algos.RunState{Generation: gen, Best: testutil.Point{f}}); done {
+					stopped = gen
+					break
+				}
+			}
+			if stopped != tt.stopsAt {
+				t.Errorf("stopped at generation %d, want %d", stopped, tt.stopsAt)
+			}
+		})
+	}
+}
+
+func TestHypervolumeStagnation(t *testing.T) {
+	// hypervolumes with respect to (1, 1): 0.25, 0.3125, 0.3125
+	fronts := [][][]float64{
+		{{0.5, 0.5}},
+		{{0.5, 0.5}, {0.25, 0.75}},
+		{{0.5, 0.5}, {0.25, 0.75}},
+	}
+	criterion := HypervolumeStagnation([]float64{1, 1}, 1, 0.01)
+	for gen, front := range fronts {
+		done, reason := criterion.Done(algos.RunState{Generation: gen, ParetoFront: front})
+		if want := gen == 2; done != want {
+			t.Fatalf("generation %d: Done() = %v (%q), want %v", gen, done, reason, want)
+		}
+	}
+}
+
+func TestStagnationReuse(t *testing.T) {
+	criterion := Stagnation(3, 0.1)
+	// the first run stalls at fitness 1
+	for gen := range 4 {
+		criterion.Done(algos.RunState{Generation: gen, Best: testutil.Point{1}})
+	}
+	// the second run improves steadily, though never below 1
+	for gen, f := range []float64{5, 4, 3, 2} {
+		if done, reason := criterion.Done(algos.RunState{Generation: gen, Best: testutil.Point{f}}); done {
+			t.Fatalf("second run stopped at generation %d: %s", gen, reason)
+		}
+	}
+}
+
+func TestHypervolumeStagnationReuse(t *testing.T) {
+	fronts := [][][]float64{
+		{{0.5, 0.5}},
+		{{0.5, 0.5}, {0.25, 0.75}},
+		{{0.5, 0.5}, {0.25, 0.75}},
+	}
+	criterion := HypervolumeStagnation([]float64{1, 1}, 1, 0.01)
+	for run := range 2 {
+		for gen, front := range fronts {
+			// every generation is checked twice, as when Run is resumed
+			for range 2 {
+				done, reason := criterion.Done(algos.RunState{Generation: gen, ParetoFront: front})
+				if want := gen == 2; done != want {
+					t.Fatalf("run %d, generation %d: Done() = %v (%q), want %v", run, gen, done, reason, want)
+				}
+			}
+		}
+	}
+}
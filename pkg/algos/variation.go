@@ -0,0 +1,36 @@
+package algos
+
+import (
+	"math/rand/v2"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// ApplyCrossover recombines the parents with probability pc, otherwise the
+// children are the parents themselves. rng is only drawn from when pc is
+// strictly between 0 and 1, so runs with the default pc of 1 are unchanged.
+func ApplyCrossover(rng *rand.Rand, crossover problems.CrossoverFunc, pc float64, parentA, parentB problems.Solution) []problems.Solution {
+	if happens(rng, pc) {
+		return crossover(rng, parentA, parentB)
+	}
+	return []problems.Solution{parentA, parentB}
+}
+
+// ApplyMutation mutates individual with probability pm, otherwise it returns
+// individual. Like ApplyCrossover it draws from rng only when 0 < pm < 1.
+func ApplyMutation(rng *rand.Rand, mutation problems.MutationFunc, pm float64, individual problems.Solution) problems.Solution {
+	if happens(rng, pm) {
+		return mutation(rng, individual)
+	}
+	return individual
+}
+
+func happens(rng *rand.Rand, p float64) bool {
+	switch {
+	case p >= 1:
+		return true
+	case p <= 0:
+		return false
+	}
+	return rng.Float64() < p
+}
@@ -0,0 +1,58 @@
+package algos
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+func TestApplyVariation(t *testing.T) {
+	a, b := testutil.Point{1}, testutil.Point{2}
+	crossover := func(rng *rand.Rand, a, b problems.Solution) []problems.Solution {
+		return []problems.Solution{testutil.Point{3}}
+	}
+	mutation := func(rng *rand.Rand, individual problems.Solution) problems.Solution {
+		return testutil.Point{4}
+	}
+	tests := []struct {
+		name       string
+		p          float64
+		wantVaried int // out of 1000
+		tolerance  int
+		certain    bool // rng is not drawn from
+	}{
+		{"always", 1, 1000, 0, true},
+		{"never", 0, 0, 0, true},
+		{"above 1", 1.5, 1000, 0, true},
+		{"sometimes", 0.3, 300, 50, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(1, 1))
+			crossed, mutated := 0, 0
+			for range 1000 {
+				if children := ApplyCrossover(rng, crossover, tt.p, a, b); len(children) == 1 {
+					crossed++
+				} else if children[0].Fitness() != 1 || children[1].Fitness() != 2 {
+					t.Fatalf("skipped crossover gave %v, want the parents", children)
+				}
+				if child := ApplyMutation(rng, mutation, tt.p, a); child.Fitness() == 4 {
+					mutated++
+				} else if child.Fitness() != 1 {
+					t.Fatalf("skipped mutation gave %v, want the individual", child)
+				}
+			}
+			for _, got := range []int{crossed, mutated} {
+				if got < tt.wantVaried-tt.tolerance || got > tt.wantVaried+tt.tolerance {
+					t.Errorf("varied %d of 1000 times, want %d", got, tt.wantVaried)
+				}
+			}
+			fresh := rand.New(rand.NewPCG(1, 1))
+			if drawn := rng.Uint64() != fresh.Uint64(); drawn == tt.certain {
+				t.Errorf("rng drawn from = %v", drawn)
+			}
+		})
+	}
+}
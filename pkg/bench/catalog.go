@@ -0,0 +1,340 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/adaptive"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/schedule"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane/operators/crossover"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane/operators/mutation"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/knapsack"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/tsp"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/zdt"
+)
+
+// ProblemFactory builds an instance of the given size (vertices, items, cities or
+// dimensions, depending on the problem) from rng and optional parameters.
+type ProblemFactory func(rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error)
+
+// MutationFactory builds a mutation operator from its parameters.
+type MutationFactory func(params algos.ParamMap) (problems.MutationFunc, error)
+
+// CrossoverFactory builds a crossover operator from its parameters.
+type CrossoverFactory func(params algos.ParamMap) (problems.CrossoverFunc, error)
+
+var (
+	catalogMu  sync.RWMutex
+	problemsBy = make(map[string]ProblemFactory)
+	mutations  = make(map[string]MutationFactory)
+	crossovers = make(map[string]CrossoverFactory)
+)
+
+// RegisterProblem makes a problem available to experiment files under name.
+func RegisterProblem(name string, factory ProblemFactory) {
+	register(problemsBy, name, factory)
+}
+
+// RegisterMutation makes a mutation operator available to experiment files under name.
+func RegisterMutation(name string, factory MutationFactory) {
+	register(mutations, name, factory)
+}
+
+// RegisterCrossover makes a crossover operator available to experiment files under name.
+func RegisterCrossover(name string, factory CrossoverFactory) {
+	register(crossovers, name, factory)
+}
+
+func register[F any](m map[string]F, name string, factory F) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if _, dup := m[name]; dup {
+		panic("bench: registered twice: " + name)
+	}
+	m[name] = factory
+}
+
+func lookup[F any](m map[string]F, kind, name string) (F, error) {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+	f, ok := m[name]
+	if !ok {
+		names := make([]string, 0, len(m))
+		for n := range m {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return f, fmt.Errorf("unknown %s %q (registered: %v)", kind, name, names)
+	}
+	return f, nil
+}
+
+// NewProblem builds the problem registered under name.
+func NewProblem(name string, rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error) {
+	factory, err := lookup(problemsBy, "problem", name)
+	if err != nil {
+		return nil, err
+	}
+	return factory(rng, size, params)
+}
+
+// NewMutation builds the mutation registered under name.
+func NewMutation(name string, params algos.ParamMap) (problems.MutationFunc, error) {
+	factory, err := lookup(mutations, "mutation", name)
+	if err != nil {
+		return nil, err
+	}
+	return factory(params)
+}
+
+// newAdaptiveMutation builds an adaptive mutation over the registered mutations
+// listed in "operators", given by name or as objects with a "name" and their
+// parameters. An optional "label" tells apart operators of the same name in the log.
+func newAdaptiveMutation(params algos.ParamMap) (*adaptive.Mutation, error) {
+	var specs []any
+	switch list := params["operators"].(type) {
+	case []any:
+		specs = list
+	case []string:
+		for _, name := range list {
+			specs = append(specs, name)
+		}
+	}
+	if len(specs) == 0 {
+		return nil, errors.New(`adaptive mutation needs a list of "operators"`)
+	}
+	names := make([]string, len(specs))
+	operators := make([]problems.MutationFunc, len(specs))
+	for i, spec := range specs {
+		name, opParams, err := operatorSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("operator %d: %w", i+1, err)
+		}
+		if operators[i], err = NewMutation(name, opParams); err != nil {
+			return nil, fmt.Errorf("operator %d: %w", i+1, err)
+		}
+		if names[i], err = opParams.String("label", name); err != nil {
+			return nil, fmt.Errorf("operator %d: %w", i+1, err)
+		}
+	}
+	selection, err := adaptive.ParamsFromMap(params)
+	if err != nil {
+		return nil, err
+	}
+	return adaptive.NewMutation(names, operators, selection)
+}
+
+// NewCrossover builds the crossover registered under name.
+func NewCrossover(name string, params algos.ParamMap) (problems.CrossoverFunc, error) {
+	factory, err := lookup(crossovers, "crossover", name)
+	if err != nil {
+		return nil, err
+	}
+	return factory(params)
+}
+
+// newMutationOperator builds the mutation registered under name. A parameter
+// given as a schedule object (see schedule.FromMap) makes it a
+// *schedule.Mutation that follows the schedule over the run.
+func newMutationOperator(name string, params algos.ParamMap) (any, error) {
+	key, s, err := scheduledParam(params)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return NewMutation(name, params)
+	}
+	if _, err := NewMutation(name, withParam(params, key, s.Value(0))); err != nil {
+		return nil, err
+	}
+	return schedule.NewMutation(func(value float64) problems.MutationFunc {
+		op, err := NewMutation(name, withParam(params, key, value))
+		if err != nil {
+			panic(err) // the factory accepted the first value
+		}
+		return op
+	}, s), nil
+}
+
+// newCrossoverOperator is the crossover counterpart of newMutationOperator.
+func newCrossoverOperator(name string, params algos.ParamMap) (any, error) {
+	key, s, err := scheduledParam(params)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return NewCrossover(name, params)
+	}
+	if _, err := NewCrossover(name, withParam(params, key, s.Value(0))); err != nil {
+		return nil, err
+	}
+	return schedule.NewCrossover(func(value float64) problems.CrossoverFunc {
+		op, err := NewCrossover(name, withParam(params, key, value))
+		if err != nil {
+			panic(err) // the factory accepted the first value
+		}
+		return op
+	}, s), nil
+}
+
+// scheduledParam returns the operator parameter given as an object with a
+// "schedule", if any. An operator may have one scheduled parameter.
+func scheduledParam(params algos.ParamMap) (string, schedule.Schedule, error) {
+	var key string
+	var s schedule.Schedule
+	for _, k := range slices.Sorted(maps.Keys(params)) {
+		var m algos.ParamMap
+		switch v := params[k].(type) {
+		case map[string]any:
+			m = v
+		case algos.ParamMap:
+			m = v
+		}
+		if _, ok := m["schedule"]; !ok {
+			continue
+		}
+		if s != nil {
+			return "", nil, fmt.Errorf("parameters %q and %q are both scheduled", key, k)
+		}
+		var err error
+		if s, err = schedule.FromMap(m); err != nil {
+			return "", nil, fmt.Errorf("parameter %q: %w", k, err)
+		}
+		key = k
+	}
+	return key, s, nil
+}
+
+func withParam(params algos.ParamMap, key string, value float64) algos.ParamMap {
+	p := maps.Clone(params)
+	p[key] = value
+	return p
+}
+
+func init() {
+	RegisterProblem("PlanarGraphPlane", func(rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error) {
+		return graphplane.NewPlanarGraphPlaneProblem(rng, size), nil
+	})
+	RegisterProblem("GraphPlane", func(rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error) {
+		edgesPerVertex, err := params.Float("edges_per_vertex", 3)
+		if err != nil {
+			return nil, err
+		}
+		return graphplane.NewGraphPlaneProblem(rng, size, int(float64(size)*edgesPerVertex)), nil
+	})
+	RegisterProblem("Knapsack", func(rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error) {
+		p := knapsack.KnapsackProblemParams{ItemsNum: size}
+		var err error
+		if p.Dimensions, err = params.Int("dimensions", 2); err != nil {
+			return nil, err
+		}
+		if p.InitialMaxValue, err = params.Int("initial_max_value", 100); err != nil {
+			return nil, err
+		}
+		if p.InitialMaxResource, err = params.Int("initial_max_resource", 100); err != nil {
+			return nil, err
+		}
+		// by default about half of the items fit
+		constraint, err := params.Int("constraint", size*p.InitialMaxResource/4)
+		if err != nil {
+			return nil, err
+		}
+		p.Constraints = make([]int, p.Dimensions-1)
+		for i := range p.Constraints {
+			p.Constraints[i] = constraint
+		}
+		return knapsack.NewKnapsackProblem(rng, p), nil
+	})
+	RegisterProblem("TSP", func(rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error) {
+		return tsp.NewTSProblem(rng, tsp.TSProblemParameters{CitiesNum: size}), nil
+	})
+	for name, problem := range map[string]func(int) problems.Problem{
+		"ZDT1": zdt.NewZDT1Problem,
+		"ZDT2": zdt.NewZDT2Problem,
+		"ZDT3": zdt.NewZDT3Problem,
+		"ZDT4": zdt.NewZDT4Problem,
+		"ZDT6": zdt.NewZDT6Problem,
+	} {
+		RegisterProblem(name, func(rng *rand.Rand, size int, params algos.ParamMap) (problems.Problem, error) {
+			return problem(size), nil
+		})
+	}
+
+	registerGraphPlaneOperators()
+
+	RegisterMutation("knapsack", func(algos.ParamMap) (problems.MutationFunc, error) { return knapsack.MutationFunc(), nil })
+	RegisterCrossover("knapsack", func(algos.ParamMap) (problems.CrossoverFunc, error) { return knapsack.CrossoverFunc(), nil })
+	RegisterMutation("tsp", func(algos.ParamMap) (problems.MutationFunc, error) { return tsp.MutationFunc(), nil })
+	RegisterCrossover("tsp", func(algos.ParamMap) (problems.CrossoverFunc, error) { return tsp.CrossoverFunc(), nil })
+	for name, ops := range map[string]struct {
+		mutation  func() problems.MutationFunc
+		crossover func() problems.CrossoverFunc
+	}{
+		"zdt1": {zdt.ZDT1MutationFunc, zdt.ZDT1CrossoverFunc},
+		"zdt2": {zdt.ZDT2MutationFunc, zdt.ZDT2CrossoverFunc},
+		"zdt3": {zdt.ZDT3MutationFunc, zdt.ZDT3CrossoverFunc},
+		"zdt4": {zdt.ZDT4MutationFunc, zdt.ZDT4CrossoverFunc},
+		"zdt6": {zdt.ZDT6MutationFunc, zdt.ZDT6CrossoverFunc},
+	} {
+		RegisterMutation(name, func(algos.ParamMap) (problems.MutationFunc, error) { return ops.mutation(), nil })
+		RegisterCrossover(name, func(algos.ParamMap) (problems.CrossoverFunc, error) { return ops.crossover(), nil })
+	}
+}
+
+func registerGraphPlaneOperators() {
+	RegisterCrossover("graphplane.uniform", func(params algos.ParamMap) (problems.CrossoverFunc, error) {
+		swapProb, err := params.Float("swap_prob", 0.5)
+		return crossover.Uniform(swapProb), err
+	})
+
+	withoutParams := map[string]func() problems.MutationFunc{
+		"graphplane.uniform":          mutation.Uniform,
+		"graphplane.fixed_uniform":    mutation.FixedUniform,
+		"graphplane.mirror":           mutation.Mirror,
+		"graphplane.percentage":       mutation.Percentage,
+		"graphplane.fixed_percentage": mutation.FixedPercentage,
+	}
+	for name, m := range withoutParams {
+		RegisterMutation(name, func(algos.ParamMap) (problems.MutationFunc, error) { return m(), nil })
+	}
+
+	withK := map[string]func(k float64) problems.MutationFunc{
+		"graphplane.norm":              mutation.Norm,
+		"graphplane.fixed_norm":        mutation.FixedNorm,
+		"graphplane.conservative_norm": mutation.ConservativeNorm,
+	}
+	for name, m := range withK {
+		RegisterMutation(name, func(params algos.ParamMap) (problems.MutationFunc, error) {
+			k, err := params.Float("k", 0.1)
+			return m(k), err
+		})
+	}
+
+	withEpsilon := map[string]func(epsilon float64) problems.MutationFunc{
+		"graphplane.tension_vector":       mutation.TensionVector,
+		"graphplane.fixed_tension_vector": mutation.FixedTensionVector,
+	}
+	for name, m := range withEpsilon {
+		RegisterMutation(name, func(params algos.ParamMap) (problems.MutationFunc, error) {
+			epsilon, err := params.Float("epsilon", 0.1)
+			return m(epsilon), err
+		})
+	}
+
+	RegisterMutation("graphplane.adaptive_norm", func(params algos.ParamMap) (problems.MutationFunc, error) {
+		maxSteps, err := params.Int("max_steps", 10)
+		if err != nil {
+			return nil, err
+		}
+		k, err := params.Float("k", 0.1)
+		return mutation.AdaptiveNorm(maxSteps, k), err
+	})
+}
@@ -0,0 +1,211 @@
+package bench
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/stats"
+)
+
+// Comparison is a pairwise test of the final fitness of methods A and B on one problem size.
+type Comparison struct {
+	Problem   string
+	Size      int
+	A, B      string
+	P         float64 // two-sided Wilcoxon rank-sum p-value
+	A12       float64 // probability that A ends with a higher (worse) fitness than B
+	Magnitude string
+	// Better names the method with the lower fitness if the difference is significant.
+	Better string
+}
+
+// Compare runs a rank-sum test and computes A12 for every pair of methods on every problem size.
+func Compare(results []RunResult, alpha float64) ([]Comparison, error) {
+	type key struct {
+		problem string
+		size    int
+	}
+	samples := make(map[key]map[string][]float64)
+	var order []key
+	for _, r := range results {
+		k := key{r.Problem, r.Size}
+		if samples[k] == nil {
+			samples[k] = make(map[string][]float64)
+			order = append(order, k)
+		}
+		samples[k][r.Method] = append(samples[k][r.Method], r.Fitness)
+	}
+
+	var comparisons []Comparison
+	for _, k := range order {
+		methods := make([]string, 0, len(samples[k]))
+		for m := range samples[k] {
+			methods = append(methods, m)
+		}
+		sort.Strings(methods)
+		for i, a := range methods {
+			for _, b := range methods[i+1:] {
+				test, err := stats.RankSum(samples[k][a], samples[k][b])
+				if err != nil {
+					return nil, fmt.Errorf("%s/%d %s vs %s: %w", k.problem, k.size, a, b, err)
+				}
+				c := Comparison{
+					Problem: k.problem,
+					Size:    k.size,
+					A:       a,
+					B:       b,
+					P:       test.P,
+					A12:     stats.A12(samples[k][a], samples[k][b]),
+				}
+				c.Magnitude = stats.Magnitude(c.A12)
+				if test.P < alpha {
+					c.Better = a
+					if c.A12 > 0.5 {
+						c.Better = b
+					}
+				}
+				comparisons = append(comparisons, c)
+			}
+		}
+	}
+	return comparisons, nil
+}
+
+// Ranking is a Friedman test over all instances with the Nemenyi post-hoc analysis,
+// which is the data behind a critical-difference diagram.
+type Ranking struct {
+	Methods   []string
+	MeanRanks []float64
+	ChiSquare float64
+	P         float64
+	Blocks    int
+	// CriticalDifference is zero if no Nemenyi critical value is tabulated for the setting.
+	CriticalDifference float64
+	Pairs              []stats.NemenyiPair
+}
+
+// Rank treats every instance (problem, size and repeat, shared by all methods
+// thanks to common seeds) as a block. Instances missing a method are skipped.
+func Rank(results []RunResult, alpha float64) (Ranking, error) {
+	type key struct {
+		problem string
+		size    int
+		repeat  int
+	}
+	blocks := make(map[key]map[string]float64)
+	var order []key
+	methodSet := make(map[string]bool)
+	for _, r := range results {
+		k := key{r.Problem, r.Size, r.Repeat}
+		if blocks[k] == nil {
+			blocks[k] = make(map[string]float64)
+			order = append(order, k)
+		}
+		blocks[k][r.Method] = r.Fitness
+		methodSet[r.Method] = true
+	}
+	methods := make([]string, 0, len(methodSet))
+	for m := range methodSet {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+
+	var data [][]float64
+	for _, k := range order {
+		if len(blocks[k]) != len(methods) {
+			continue
+		}
+		row := make([]float64, len(methods))
+		for j, m := range methods {
+			row[j] = blocks[k][m]
+		}
+		data = append(data, row)
+	}
+
+	test, err := stats.Friedman(data)
+	if err != nil {
+		return Ranking{}, err
+	}
+	ranking := Ranking{
+		Methods:   methods,
+		MeanRanks: test.MeanRanks,
+		ChiSquare: test.ChiSquare,
+		P:         test.P,
+		Blocks:    test.Blocks,
+	}
+	if pairs, cd, err := stats.Nemenyi(test, alpha); err == nil {
+		ranking.Pairs, ranking.CriticalDifference = pairs, cd
+	}
+	return ranking, nil
+}
+
+// PrintComparisons writes the pairwise comparisons and the ranking as text tables.
+func PrintComparisons(w io.Writer, comparisons []Comparison, ranking Ranking) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "problem\tsize\tA\tB\tp\tA12\teffect\tbetter\t")
+	for _, c := range comparisons {
+		better := c.Better
+		if better == "" {
+			better = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%.4g\t%.3f\t%s\t%s\t\n", c.Problem, c.Size, c.A, c.B, c.P, c.A12, c.Magnitude, better)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nFriedman over %d instances: chi2 = %.4g, p = %.4g", ranking.Blocks, ranking.ChiSquare, ranking.P)
+	if ranking.CriticalDifference > 0 {
+		fmt.Fprintf(w, ", Nemenyi CD = %.4g", ranking.CriticalDifference)
+	}
+	fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "method\tmean rank\t")
+	for i, m := range ranking.Methods {
+		fmt.Fprintf(tw, "%s\t%.3f\t\n", m, ranking.MeanRanks[i])
+	}
+	return tw.Flush()
+}
+
+// WriteComparisonFiles writes comparisons.csv and ranks.csv into dir. The latter holds
+// the mean ranks and the critical difference needed to draw a critical-difference diagram.
+func WriteComparisonFiles(dir string, comparisons []Comparison, ranking Ranking) error {
+	rows := [][]string{{"problem", "size", "a", "b", "p", "a12", "magnitude", "better"}}
+	for _, c := range comparisons {
+		rows = append(rows, []string{
+			c.Problem, strconv.Itoa(c.Size), c.A, c.B,
+			formatFloat(c.P), formatFloat(c.A12), c.Magnitude, c.Better,
+		})
+	}
+	if err := writeCSV(filepath.Join(dir, "comparisons.csv"), rows); err != nil {
+		return err
+	}
+
+	rows = [][]string{{"method", "mean_rank", "critical_difference", "friedman_p", "blocks"}}
+	for i, m := range ranking.Methods {
+		rows = append(rows, []string{
+			m, formatFloat(ranking.MeanRanks[i]), formatFloat(ranking.CriticalDifference),
+			formatFloat(ranking.P), strconv.Itoa(ranking.Blocks),
+		})
+	}
+	return writeCSV(filepath.Join(dir, "ranks.csv"), rows)
+}
+
+func writeCSV(path string, rows [][]string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.WriteAll(rows); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
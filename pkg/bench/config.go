@@ -0,0 +1,207 @@
+package bench
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+)
+
+// Experiment describes a benchmark: every method runs on every problem size Repeats times.
+// Experiment files are JSON, or YAML with the same keys if they end in .yaml or .yml.
+type Experiment struct {
+	Name    string `json:"name"`
+	Seed    uint64 `json:"seed"`    // base seed, every run derives its own from it
+	Repeats int    `json:"repeats"` // default number of runs per method, problem and size
+	// Parallel is the number of runs executed at once; 0 uses one per CPU.
+	Parallel int `json:"parallel"`
+	// LogDir optionally keeps the progress log of every run.
+	LogDir   string        `json:"log_dir"`
+	Problems []ProblemSpec `json:"problems"`
+	Methods  []MethodSpec  `json:"methods"`
+	// MethodFiles adds methods stored in their own files, such as the output of
+	// the tuner. Relative paths are resolved against the experiment file.
+	MethodFiles []string `json:"method_files"`
+}
+
+// ProblemSpec selects a registered problem and its instance sizes.
+type ProblemSpec struct {
+	Name string `json:"name"`
+	// Label names the problem in reports, defaults to Name.
+	Label         string         `json:"label"`
+	Sizes         []int          `json:"sizes"`
+	Params        algos.ParamMap `json:"params"`
+	Repeats       int            `json:"repeats"`
+	RepeatsBySize map[string]int `json:"repeats_by_size"`
+}
+
+// MethodSpec is a named chain of stages; a plain algorithm is a single stage.
+type MethodSpec struct {
+	Name   string      `json:"name"`
+	Stages []StageSpec `json:"stages"`
+}
+
+// StageSpec configures one pipeline stage.
+type StageSpec struct {
+	// Type is "ga" (the default), "fr" for the force-directed layout or "ls" for local search.
+	Type        string `json:"type,omitempty"`
+	Algorithm   string `json:"algorithm,omitempty"`
+	Generations int    `json:"generations,omitempty"`
+	// Budgets that stop the stage before Generations; "fr" and "ls" stages only take TimeBudget.
+	TimeBudget     Duration `json:"time_budget,omitempty"`
+	MaxEvaluations int      `json:"max_evaluations,omitempty"`
+	TargetFitness  *float64 `json:"target_fitness,omitempty"`
+	// Handover is "best" (the default) or "population".
+	Handover string `json:"handover,omitempty"`
+	// Params go to the algorithm; "mutation" and "crossover" are operator specs,
+	// either a registered name or an object with a "name" key and the operator parameters.
+	Params algos.ParamMap `json:"params,omitempty"`
+	// BySize overrides Params for particular instances, keyed by "<size>"
+	// or, taking precedence, by "<problem label>/<size>".
+	BySize map[string]algos.ParamMap `json:"by_size,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "90s" in experiment files.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"10s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	*d = Duration(parsed)
+	return err
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadExperiment reads and validates an experiment file.
+func LoadExperiment(path string) (*Experiment, error) {
+	exp := &Experiment{}
+	if err := decodeFile(path, exp); err != nil {
+		return nil, err
+	}
+	for _, file := range exp.MethodFiles {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(path), file)
+		}
+		method, err := LoadMethod(file)
+		if err != nil {
+			return nil, err
+		}
+		exp.Methods = append(exp.Methods, method)
+	}
+	if err := exp.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return exp, nil
+}
+
+// LoadMethod reads a method stored in a file of its own.
+func LoadMethod(path string) (MethodSpec, error) {
+	var method MethodSpec
+	err := decodeFile(path, &method)
+	return method, err
+}
+
+// WriteMethod stores method in a file that LoadMethod and experiment method_files accept.
+func WriteMethod(path string, method MethodSpec) error {
+	data, err := json.MarshalIndent(method, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+func decodeFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		if data, err = yamlToJSON(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// yamlToJSON converts a YAML document to JSON, so that YAML files are decoded
+// with the same field names, durations and checks as JSON ones.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("YAML keys must be strings: %w", err)
+	}
+	return out, nil
+}
+
+// Validate checks the experiment without running it.
+func (exp *Experiment) Validate() error {
+	if len(exp.Problems) == 0 || len(exp.Methods) == 0 {
+		return errors.New("experiment needs at least one problem and one method")
+	}
+	for _, p := range exp.Problems {
+		if len(p.Sizes) == 0 {
+			return fmt.Errorf("problem %s has no sizes", p.Name)
+		}
+		if _, err := lookup(problemsBy, "problem", p.Name); err != nil {
+			return err
+		}
+		for _, size := range p.Sizes {
+			if exp.repeats(p, size) < 1 {
+				return fmt.Errorf("problem %s size %d has no repeats", p.Name, size)
+			}
+		}
+	}
+	for _, m := range exp.Methods {
+		if m.Name == "" || len(m.Stages) == 0 {
+			return errors.New("every method needs a name and at least one stage")
+		}
+		for _, p := range exp.Problems {
+			for _, size := range p.Sizes {
+				if _, err := m.stages(p.label(), size); err != nil {
+					return fmt.Errorf("method %s: %w", m.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (exp *Experiment) repeats(p ProblemSpec, size int) int {
+	if n, ok := p.RepeatsBySize[strconv.Itoa(size)]; ok {
+		return n
+	}
+	if p.Repeats > 0 {
+		return p.Repeats
+	}
+	return exp.Repeats
+}
+
+func (p ProblemSpec) label() string {
+	if p.Label != "" {
+		return p.Label
+	}
+	return p.Name
+}
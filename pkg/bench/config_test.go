@@ -0,0 +1,100 @@
+package bench
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const experimentJSON = `{
+  "name": "small",
+  "seed": 7,
+  "repeats": 2,
+  "problems": [{"name": "PlanarGraphPlane", "label": "P", "sizes": [10, 20]}],
+  "methods": [{
+    "name": "FR-SSGA",
+    "stages": [
+      {"type": "fr", "params": {"steps": 100, "temp": 0.005}, "by_size": {"P/10": {"k": 0.7}}},
+      {
+        "algorithm": "ssga",
+        "generations": 1000,
+        "time_budget": "90s",
+        "params": {"population_size": 50, "mutation": {"name": "graphplane.conservative_norm", "k": 0.1}}
+      }
+    ]
+  }],
+  "method_files": ["tuned.yml"]
+}
+`
+
+const experimentYAML = `
+name: small
+seed: 7
+repeats: 2
+problems:
+  - {name: PlanarGraphPlane, label: P, sizes: [10, 20]}
+methods:
+  - name: FR-SSGA
+    stages:
+      - type: fr
+        params: {steps: 100, temp: 0.005}
+        by_size:
+          P/10: {k: 0.7}
+      - algorithm: ssga
+        generations: 1000
+        time_budget: 90s
+        params:
+          population_size: 50
+          mutation: {name: graphplane.conservative_norm, k: 0.1}
+method_files: [tuned.yml]
+`
+
+const methodYAML = `
+name: tuned
+stages:
+  - algorithm: sga
+    generations: 10
+    params: {mutation: graphplane.norm, crossover: graphplane.uniform}
+`
+
+func TestLoadExperiment(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("tuned.yml", methodYAML)
+
+	fromJSON, err := LoadExperiment(write("small.json", experimentJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromYAML, err := LoadExperiment(write("small.yaml", experimentYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Errorf("YAML experiment %+v, want %+v", fromYAML, fromJSON)
+	}
+	if len(fromYAML.Methods) != 2 || fromYAML.Methods[1].Name != "tuned" {
+		t.Errorf("methods %+v, want FR-SSGA and the tuned one", fromYAML.Methods)
+	}
+	if budget := time.Duration(fromYAML.Methods[0].Stages[1].TimeBudget); budget != 90*time.Second {
+		t.Errorf("time budget %v, want 90s", budget)
+	}
+
+	for name, content := range map[string]string{
+		"unknown.yaml":  experimentYAML + "repeat: 3\n",
+		"malformed.yml": "name: [small\n",
+		"keys.yaml":     experimentYAML + "1: one\n",
+	} {
+		if _, err := LoadExperiment(write(name, content)); err == nil {
+			t.Errorf("LoadExperiment() accepted %s", name)
+		}
+	}
+}
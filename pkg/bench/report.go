@@ -0,0 +1,197 @@
+package bench
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Summary aggregates the final fitness of the runs of one method on one problem size.
+type Summary struct {
+	Problem string
+	Size    int
+	Method  string
+	Runs    int
+	Mean    float64
+	Std     float64
+	Median  float64
+	Q1      float64
+	Q3      float64
+	IQR     float64
+	Min     float64
+	Max     float64
+	// ZeroRate is the share of runs whose first objective is zero,
+	// e.g. layouts without edge intersections for graphplane.
+	ZeroRate    float64
+	MeanElapsed time.Duration
+}
+
+// Summarize groups results by problem, size and method.
+func Summarize(results []RunResult) []Summary {
+	type key struct {
+		problem string
+		size    int
+		method  string
+	}
+	groups := make(map[key][]RunResult)
+	var order []key
+	for _, r := range results {
+		k := key{r.Problem, r.Size, r.Method}
+		if _, ok := groups[k]; !ok {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], r)
+	}
+
+	summaries := make([]Summary, 0, len(order))
+	for _, k := range order {
+		runs := groups[k]
+		fitness := make([]float64, len(runs))
+		zeros := 0
+		var elapsed time.Duration
+		for i, r := range runs {
+			fitness[i] = r.Fitness
+			if len(r.Objectives) > 0 && r.Objectives[0] == 0 {
+				zeros++
+			}
+			elapsed += r.Elapsed
+		}
+		sort.Float64s(fitness)
+		s := Summary{
+			Problem:     k.problem,
+			Size:        k.size,
+			Method:      k.method,
+			Runs:        len(runs),
+			Median:      stat.Quantile(0.5, stat.LinInterp, fitness, nil),
+			Q1:          stat.Quantile(0.25, stat.LinInterp, fitness, nil),
+			Q3:          stat.Quantile(0.75, stat.LinInterp, fitness, nil),
+			Min:         fitness[0],
+			Max:         fitness[len(fitness)-1],
+			ZeroRate:    float64(zeros) / float64(len(runs)),
+			MeanElapsed: elapsed / time.Duration(len(runs)),
+		}
+		s.Mean, s.Std = stat.MeanStdDev(fitness, nil)
+		if len(runs) < 2 {
+			s.Std = 0
+		}
+		s.IQR = s.Q3 - s.Q1
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
+
+// WriteRunsCSV writes one row per run, with as many objective columns as the widest result.
+func WriteRunsCSV(w io.Writer, results []RunResult) error {
+	objectives := 0
+	for _, r := range results {
+		objectives = max(objectives, len(r.Objectives))
+	}
+	writer := csv.NewWriter(w)
+	header := []string{"problem", "size", "method", "repeat", "seed", "fitness", "elapsed"}
+	for i := range objectives {
+		header = append(header, fmt.Sprintf("objective_%d", i))
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, r := range results {
+		row := []string{
+			r.Problem,
+			strconv.Itoa(r.Size),
+			r.Method,
+			strconv.Itoa(r.Repeat),
+			strconv.FormatUint(r.Seed, 10),
+			formatFloat(r.Fitness),
+			formatFloat(r.Elapsed.Seconds()),
+		}
+		for i := range objectives {
+			value := ""
+			if i < len(r.Objectives) {
+				value = formatFloat(r.Objectives[i])
+			}
+			row = append(row, value)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteSummaryCSV writes one row per summary.
+func WriteSummaryCSV(w io.Writer, summaries []Summary) error {
+	writer := csv.NewWriter(w)
+	header := []string{"problem", "size", "method", "runs", "mean", "std", "median", "q1", "q3", "iqr", "min", "max", "zero_rate", "mean_elapsed"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, s := range summaries {
+		row := []string{
+			s.Problem,
+			strconv.Itoa(s.Size),
+			s.Method,
+			strconv.Itoa(s.Runs),
+			formatFloat(s.Mean),
+			formatFloat(s.Std),
+			formatFloat(s.Median),
+			formatFloat(s.Q1),
+			formatFloat(s.Q3),
+			formatFloat(s.IQR),
+			formatFloat(s.Min),
+			formatFloat(s.Max),
+			formatFloat(s.ZeroRate),
+			formatFloat(s.MeanElapsed.Seconds()),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// PrintSummary writes summaries as an aligned text table.
+func PrintSummary(w io.Writer, summaries []Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "problem\tsize\tmethod\truns\tmean\tstd\tmedian\tIQR\t0-rate\ttime\t")
+	for _, s := range summaries {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%.4f\t%.4f\t%.4f\t%.4f\t%.2f%%\t%s\t\n",
+			s.Problem, s.Size, s.Method, s.Runs, s.Mean, s.Std, s.Median, s.IQR, s.ZeroRate*100, s.MeanElapsed.Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
+
+// WriteFiles writes runs.csv and summary.csv into dir.
+func WriteFiles(dir string, results []RunResult, summaries []Summary) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	write := func(name string, fill func(w io.Writer) error) error {
+		file, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := fill(file); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	}
+	if err := write("runs.csv", func(w io.Writer) error { return WriteRunsCSV(w, results) }); err != nil {
+		return err
+	}
+	return write("summary.csv", func(w io.Writer) error { return WriteSummaryCSV(w, summaries) })
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,382 @@
+package bench
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/termination"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/pipeline"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane"
+
+	// register the algorithms experiment files refer to by name
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/cmaes"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/de"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/hillclimb"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/ibea"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/island"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/moead"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/nsga2"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/nsga3"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/sa"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/sga"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/smsemoa"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/spea2"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/ssga"
+	_ "github.com/GregoryKogan/genetic-algorithms/pkg/algos/tabu"
+)
+
+// RunResult is the outcome of one run of a method on one problem instance.
+type RunResult struct {
+	Problem    string
+	Size       int
+	Method     string
+	Repeat     int
+	Seed       uint64
+	Fitness    float64
+	Objectives []float64
+	Elapsed    time.Duration
+}
+
+type runSpec struct {
+	problem ProblemSpec
+	size    int
+	method  MethodSpec
+	repeat  int
+	seed    uint64
+}
+
+// Run executes every run of the experiment, Parallel at a time, and returns
+// the results ordered by problem, size, method and repeat. Runs with the same
+// problem, size and repeat share the seed, so all methods face the same instance.
+func Run(ctx context.Context, exp *Experiment) ([]RunResult, error) {
+	if err := exp.Validate(); err != nil {
+		return nil, err
+	}
+	if exp.LogDir != "" {
+		if err := os.MkdirAll(exp.LogDir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	var specs []runSpec
+	for _, p := range exp.Problems {
+		for _, size := range p.Sizes {
+			for repeat := range exp.repeats(p, size) {
+				seed := runSeed(exp.Seed, p.label(), size, repeat)
+				for _, m := range exp.Methods {
+					specs = append(specs, runSpec{problem: p, size: size, method: m, repeat: repeat, seed: seed})
+				}
+			}
+		}
+	}
+
+	workers := exp.Parallel
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]RunResult, len(specs))
+	errs := make([]error, len(specs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(specs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = run(ctx, specs[i], exp.LogDir)
+				if errs[i] != nil {
+					cancel()
+				}
+			}
+		}()
+	}
+	for i := range specs {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sortResults(results)
+	return results, nil
+}
+
+// RunOnce runs the method once on the instance of the problem with the given size
+// and seed, without a progress log. Tools that schedule runs themselves, such as
+// tuners, use it instead of Run.
+func RunOnce(ctx context.Context, problem ProblemSpec, size int, method MethodSpec, seed uint64) (RunResult, error) {
+	return run(ctx, runSpec{problem: problem, size: size, method: method, seed: seed}, "")
+}
+
+func run(ctx context.Context, spec runSpec, logDir string) (RunResult, error) {
+	result := RunResult{
+		Problem: spec.problem.label(),
+		Size:    spec.size,
+		Method:  spec.method.Name,
+		Repeat:  spec.repeat,
+		Seed:    spec.seed,
+	}
+	fail := func(err error) (RunResult, error) {
+		return result, fmt.Errorf("%s %s/%d run %d: %w", result.Method, result.Problem, result.Size, result.Repeat, err)
+	}
+
+	problem, err := NewProblem(spec.problem.Name, problems.NewRand(spec.seed), spec.size, spec.problem.Params)
+	if err != nil {
+		return fail(err)
+	}
+	stages, err := spec.method.stages(spec.problem.label(), spec.size)
+	if err != nil {
+		return fail(err)
+	}
+
+	var logger algos.ProgressLoggerProvider
+	if logDir != "" {
+		name := fmt.Sprintf("%s_%d_%s_%d.jsonl", result.Problem, result.Size, result.Method, result.Repeat)
+		fileLogger := algos.NewProgressLogger(filepath.Join(logDir, name))
+		// keep the logs of earlier runs of the experiment
+		fileLogger.Existing = algos.RotateExisting
+		if err := fileLogger.InitLogging(); err != nil {
+			return fail(err)
+		}
+		defer fileLogger.Close()
+		if err := fileLogger.LogProblem(problem); err != nil {
+			return fail(err)
+		}
+		logger = fileLogger
+	}
+
+	res, err := pipeline.New(problem, spec.seed, logger, stages...).Run(ctx)
+	if err != nil {
+		return fail(err)
+	}
+	result.Fitness = res.Best.Fitness()
+	result.Objectives = res.Best.Objectives()
+	result.Elapsed = res.Elapsed
+	return result, nil
+}
+
+// runSeed mixes the base seed with the instance coordinates.
+func runSeed(base uint64, problem string, size, repeat int) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], base)
+	h.Write(buf[:])
+	fmt.Fprintf(h, "%s/%d/%d", problem, size, repeat)
+	// keep seeds exactly representable in JSON logs and parameter maps
+	return h.Sum64()>>12 | 1
+}
+
+func sortResults(results []RunResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Problem != b.Problem {
+			return a.Problem < b.Problem
+		}
+		if a.Size != b.Size {
+			return a.Size < b.Size
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		return a.Repeat < b.Repeat
+	})
+}
+
+// stages builds the pipeline stages of the method for an instance.
+func (m MethodSpec) stages(problem string, size int) ([]pipeline.Stage, error) {
+	stages := make([]pipeline.Stage, 0, len(m.Stages))
+	for i, spec := range m.Stages {
+		stage, err := spec.build(problem, size)
+		if err != nil {
+			return nil, fmt.Errorf("stage %d: %w", i+1, err)
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+func (s StageSpec) build(problem string, size int) (pipeline.Stage, error) {
+	params := make(algos.ParamMap, len(s.Params))
+	for k, v := range s.Params {
+		params[k] = v
+	}
+	for _, key := range []string{strconv.Itoa(size), fmt.Sprintf("%s/%d", problem, size)} {
+		for k, v := range s.BySize[key] {
+			params[k] = v
+		}
+	}
+	if err := resolveOperators(params); err != nil {
+		return nil, err
+	}
+
+	switch s.Type {
+	case "", "ga":
+		return s.gaStage(params)
+	case "fr":
+		fds := graphplane.FDSParams{}
+		var err error
+		if fds.Steps, err = params.Int("steps", 2000); err != nil {
+			return nil, err
+		}
+		if fds.Temp, err = params.Float("temp", 0.005); err != nil {
+			return nil, err
+		}
+		if fds.K, err = params.Float("k", 1.0); err != nil {
+			return nil, err
+		}
+		return pipeline.ForceDirectedStage{Params: fds, TimeBudget: time.Duration(s.TimeBudget)}, nil
+	case "ls":
+		mutation, err := params.Mutation("mutation")
+		if err != nil {
+			return nil, err
+		}
+		steps, err := params.Int("steps", 1000)
+		if err != nil {
+			return nil, err
+		}
+		return pipeline.LocalSearchStage{Mutation: mutation, Steps: steps, TimeBudget: time.Duration(s.TimeBudget)}, nil
+	}
+	return nil, fmt.Errorf("unknown stage type %q", s.Type)
+}
+
+func (s StageSpec) gaStage(params algos.ParamMap) (pipeline.Stage, error) {
+	if s.Algorithm == "" {
+		return nil, errors.New("ga stage needs an algorithm")
+	}
+	if !isRegistered(s.Algorithm) {
+		return nil, fmt.Errorf("unknown algorithm %q (registered: %v)", s.Algorithm, algos.Registered())
+	}
+
+	var criteria []algos.Criterion
+	if s.MaxEvaluations > 0 {
+		criteria = append(criteria, termination.MaxEvaluations(s.MaxEvaluations))
+	}
+	if s.TargetFitness != nil {
+		criteria = append(criteria, termination.TargetFitness(*s.TargetFitness))
+	}
+	generations := s.Generations
+	if generations <= 0 {
+		if len(criteria) == 0 && s.TimeBudget <= 0 {
+			return nil, fmt.Errorf("%s stage has no budget", s.Algorithm)
+		}
+		generations = math.MaxInt
+	}
+
+	handover := pipeline.SeedBest
+	switch s.Handover {
+	case "", "best":
+	case "population":
+		handover = pipeline.SeedPopulation
+	default:
+		return nil, fmt.Errorf("unknown handover %q", s.Handover)
+	}
+
+	stage := pipeline.GAStage{
+		Algorithm:   s.Algorithm,
+		Params:      params,
+		Generations: generations,
+		TimeBudget:  time.Duration(s.TimeBudget),
+		Handover:    handover,
+	}
+	if len(criteria) > 0 {
+		stage.Termination = termination.Any(criteria...)
+	}
+	return stage, nil
+}
+
+func isRegistered(name string) bool {
+	for _, registered := range algos.Registered() {
+		if registered == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveOperators replaces the "mutation" and "crossover" specs of params with operators,
+// also in the "island_params" that the island model passes on to its islands.
+func resolveOperators(params algos.ParamMap) error {
+	if _, ok := params["island_params"]; ok {
+		nested, err := params.Params("island_params")
+		if err != nil {
+			return err
+		}
+		if err := resolveOperators(nested); err != nil {
+			return fmt.Errorf("island_params: %w", err)
+		}
+		params["island_params"] = nested
+	}
+	if spec, ok := params["mutation"]; ok && !isOperator(spec) {
+		name, opParams, err := operatorSpec(spec)
+		if err != nil {
+			return fmt.Errorf("mutation: %w", err)
+		}
+		if name == "adaptive" {
+			if params["mutation"], err = newAdaptiveMutation(opParams); err != nil {
+				return fmt.Errorf("mutation: %w", err)
+			}
+		} else if params["mutation"], err = newMutationOperator(name, opParams); err != nil {
+			return err
+		}
+	}
+	if spec, ok := params["crossover"]; ok && !isOperator(spec) {
+		name, opParams, err := operatorSpec(spec)
+		if err != nil {
+			return fmt.Errorf("crossover: %w", err)
+		}
+		if params["crossover"], err = newCrossoverOperator(name, opParams); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isOperator reports whether spec is an operator built in code rather than read from a file.
+func isOperator(spec any) bool {
+	switch spec.(type) {
+	case problems.MutationFunc, problems.CrossoverFunc, algos.MutationOperator, algos.CrossoverOperator:
+		return true
+	}
+	return false
+}
+
+func operatorSpec(spec any) (string, algos.ParamMap, error) {
+	switch s := spec.(type) {
+	case string:
+		return s, algos.ParamMap{}, nil
+	case algos.ParamMap:
+		return operatorSpec(map[string]any(s))
+	case map[string]any:
+		params := algos.ParamMap(s)
+		name, err := params.String("name", "")
+		if err != nil || name == "" {
+			return "", nil, errors.New(`operator object needs a "name"`)
+		}
+		return name, params, nil
+	}
+	return "", nil, fmt.Errorf("expected an operator name or object, got %T", spec)
+}
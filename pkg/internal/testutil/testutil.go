@@ -0,0 +1,93 @@
+// Package testutil holds the solutions and problems shared by the tests of the
+// algorithm packages.
+package testutil
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+var (
+	_ problems.ConstrainedSolution = Constrained{}
+	_ problems.RealVector          = (*SphereSolution)(nil)
+	_ problems.SolutionDecoder     = Sphere{}
+)
+
+// Point is a solution whose objectives are its coordinates and whose fitness is the first one.
+type Point []float64
+
+func (p Point) Objectives() []float64 { return p }
+func (p Point) Fitness() float64      { return p[0] }
+
+// Constrained is a point that violates a single constraint by Violation.
+type Constrained struct {
+	Point
+	Violation float64
+}
+
+func (c Constrained) Violations() []float64 { return []float64{c.Violation} }
+
+// Sphere minimizes the sum of squares over [-5, 5]ⁿ; the optimum is the origin.
+type Sphere struct{ Dimensions int }
+
+func (p Sphere) Name() string { return "sphere" }
+
+func (p Sphere) RandomSolution(rng *rand.Rand) problems.Solution {
+	x := make([]float64, p.Dimensions)
+	for i := range x {
+		x[i] = rng.Float64()*10 - 5
+	}
+	return &SphereSolution{X: x}
+}
+
+func (p Sphere) DecodeSolution(data []byte) (problems.Solution, error) {
+	sol := &SphereSolution{}
+	return sol, json.Unmarshal(data, sol)
+}
+
+// Nudge moves one coordinate of a sphere point by up to ±0.5.
+func Nudge(rng *rand.Rand, individual problems.Solution) problems.Solution {
+	x := slices.Clone(individual.(*SphereSolution).X)
+	x[rng.IntN(len(x))] += rng.Float64() - 0.5
+	return &SphereSolution{X: x}
+}
+
+// Midpoint crosses two sphere points into their midpoint.
+func Midpoint(rng *rand.Rand, a, b problems.Solution) []problems.Solution {
+	x, y := a.(*SphereSolution).X, b.(*SphereSolution).X
+	mid := make([]float64, len(x))
+	for i := range x {
+		mid[i] = (x[i] + y[i]) / 2
+	}
+	return []problems.Solution{&SphereSolution{X: mid}}
+}
+
+// SphereSolution is a point of the sphere problem.
+type SphereSolution struct{ X []float64 }
+
+func (s *SphereSolution) Objectives() []float64 { return []float64{s.Fitness()} }
+
+func (s *SphereSolution) Fitness() float64 {
+	sum := 0.0
+	for _, v := range s.X {
+		sum += v * v
+	}
+	return sum
+}
+
+func (s *SphereSolution) Vector() []float64 { return s.X }
+
+func (s *SphereSolution) Bounds() (lower, upper []float64) {
+	lower, upper = make([]float64, len(s.X)), make([]float64, len(s.X))
+	for i := range s.X {
+		lower[i], upper[i] = -5, 5
+	}
+	return lower, upper
+}
+
+func (s *SphereSolution) WithVector(x []float64) problems.RealVector {
+	return &SphereSolution{X: x}
+}
@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"slices"
+	"sort"
+)
+
+// Hypervolume returns the volume of objective space dominated by front and
+// bounded by the reference point ref (all objectives are minimized).
+// Points that do not strictly dominate ref contribute nothing.
+func Hypervolume(front [][]float64, ref []float64) float64 {
+	pts := make([][]float64, 0, len(front))
+	for _, p := range front {
+		if strictlyBetter(p, ref) {
+			pts = append(pts, p)
+		}
+	}
+	if len(pts) == 0 {
+		return 0
+	}
+	return hvSlice(pts, ref)
+}
+
+// Contributions returns the exclusive hypervolume contribution of every point of
+// front: the volume lost if that point alone were removed. Dominated points and
+// duplicates contribute nothing.
+func Contributions(front [][]float64, ref []float64) []float64 {
+	total := Hypervolume(front, ref)
+	contributions := make([]float64, len(front))
+	rest := make([][]float64, 0, len(front))
+	for i := range front {
+		rest = append(append(rest[:0], front[:i]...), front[i+1:]...)
+		contributions[i] = max(total-Hypervolume(rest, ref), 0)
+	}
+	return contributions
+}
+
+// hvSlice dispatches to the exact algorithm for the number of objectives:
+// a sweep for 2 and 3 objectives and WFG for more.
+func hvSlice(pts [][]float64, ref []float64) float64 {
+	switch len(ref) {
+	case 1:
+		best := pts[0][0]
+		for _, p := range pts[1:] {
+			best = min(best, p[0])
+		}
+		return ref[0] - best
+	case 2:
+		return hv2D(pts, ref)
+	case 3:
+		return hv3D(pts, ref)
+	}
+	return wfg(nonDominated(pts), ref)
+}
+
+// hv2D computes the exact 2-D hypervolume with a sweep over the first objective.
+func hv2D(pts [][]float64, ref []float64) float64 {
+	sorted := slices.Clone(pts)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i][0] != sorted[j][0] {
+			return sorted[i][0] < sorted[j][0]
+		}
+		return sorted[i][1] < sorted[j][1]
+	})
+	volume := 0.0
+	prevY := ref[1]
+	for _, p := range sorted {
+		if p[1] < prevY {
+			volume += (ref[0] - p[0]) * (prevY - p[1])
+			prevY = p[1]
+		}
+	}
+	return volume
+}
+
+// hv3D sweeps the points in ascending order of the last objective, keeping
+// the 2-D staircase of the points seen so far and its area.
+func hv3D(pts [][]float64, ref []float64) float64 {
+	sorted := slices.Clone(pts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][2] < sorted[j][2] })
+
+	// staircase is sorted by ascending x (and so descending y), no point dominates another
+	staircase := make([][]float64, 0, len(sorted))
+	area := 0.0
+	volume := 0.0
+	for i, p := range sorted {
+		pos := sort.Search(len(staircase), func(k int) bool { return staircase[k][0] >= p[0] })
+		dominated := (pos > 0 && staircase[pos-1][1] <= p[1]) ||
+			(pos < len(staircase) && staircase[pos][0] == p[0] && staircase[pos][1] <= p[1])
+		if !dominated {
+			end := pos
+			for end < len(staircase) && staircase[end][1] >= p[1] {
+				end++
+			}
+			staircase = slices.Replace(staircase, pos, end, p[:2])
+			area = hv2DSorted(staircase, ref)
+		}
+
+		upper := ref[2]
+		if i+1 < len(sorted) {
+			upper = sorted[i+1][2]
+		}
+		volume += area * (upper - p[2])
+	}
+	return volume
+}
+
+// hv2DSorted is the area of a staircase sorted by ascending x.
+func hv2DSorted(staircase [][]float64, ref []float64) float64 {
+	area := 0.0
+	prevY := ref[1]
+	for _, p := range staircase {
+		area += (ref[0] - p[0]) * (prevY - p[1])
+		prevY = p[1]
+	}
+	return area
+}
+
+// wfg computes hypervolume of a non-dominated set as the sum of exclusive
+// contributions (While, Bradstreet, Barone 2012). Points are processed in
+// descending order of the last objective, which keeps the limit sets small.
+func wfg(pts [][]float64, ref []float64) float64 {
+	switch {
+	case len(pts) == 0:
+		return 0
+	case len(pts) == 1:
+		return inclusive(pts[0], ref)
+	case len(ref) == 3:
+		return hv3D(pts, ref)
+	}
+
+	d := len(ref)
+	sorted := slices.Clone(pts)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][d-1] > sorted[j][d-1] })
+
+	volume := 0.0
+	for k, p := range sorted {
+		limited := make([][]float64, 0, len(sorted)-k-1)
+		for _, q := range sorted[k+1:] {
+			limit := make([]float64, d)
+			for i := range limit {
+				limit[i] = max(p[i], q[i])
+			}
+			limited = append(limited, limit)
+		}
+		volume += inclusive(p, ref) - wfg(nonDominated(limited), ref)
+	}
+	return volume
+}
+
+// inclusive is the volume dominated by a single point.
+func inclusive(p, ref []float64) float64 {
+	volume := 1.0
+	for i := range ref {
+		volume *= ref[i] - p[i]
+	}
+	return volume
+}
+
+// nonDominated drops dominated points and duplicates.
+func nonDominated(pts [][]float64) [][]float64 {
+	front := make([][]float64, 0, len(pts))
+	for i, p := range pts {
+		keep := true
+		for j, q := range pts {
+			if i != j && (Dominates(q, p) || (j < i && slices.Equal(p, q))) {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			front = append(front, p)
+		}
+	}
+	return front
+}
+
+// Dominates reports whether a is no worse than b in every objective and better in one.
+func Dominates(a, b []float64) bool {
+	better := false
+	for i := range a {
+		if a[i] > b[i] {
+			return false
+		}
+		if a[i] < b[i] {
+			better = true
+		}
+	}
+	return better
+}
+
+func strictlyBetter(p, ref []float64) bool {
+	for i := range ref {
+		if p[i] >= ref[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func TestHypervolume(t *testing.T) {
+	tests := []struct {
+		name  string
+		front [][]float64
+		ref   []float64
+		want  float64
+	}{
+		{"empty front", nil, []float64{1, 1}, 0},
+		{"one objective", [][]float64{{2}, {3}}, []float64{5}, 3},
+		{"single point", [][]float64{{1, 2}}, []float64{4, 4}, 6},
+		{"staircase", [][]float64{{1, 3}, {2, 2}, {3, 1}}, []float64{4, 4}, 6},
+		{"dominated and duplicate points", [][]float64{{1, 3}, {2, 2}, {3, 1}, {3, 3}, {2, 2}}, []float64{4, 4}, 6},
+		{"points beyond the reference", [][]float64{{1, 3}, {5, 0}, {0, 4}}, []float64{4, 4}, 3},
+		// inclusion-exclusion: 3·9 - 3·3 + 1
+		{"three objectives", [][]float64{{1, 1, 3}, {3, 1, 1}, {1, 3, 1}}, []float64{4, 4, 4}, 19},
+		{"three objectives with a dominated point", [][]float64{{1, 1, 3}, {3, 1, 1}, {1, 3, 1}, {3, 3, 3}}, []float64{4, 4, 4}, 19},
+		// boxes of 8 and 2 sharing a unit hypercube
+		{"four objectives", [][]float64{{1, 1, 1, 2}, {2, 2, 2, 1}}, []float64{3, 3, 3, 3}, 9},
+		{"four objectives, flat last one", [][]float64{{1, 1, 3, 0}, {3, 1, 1, 0}, {1, 3, 1, 0}}, []float64{4, 4, 4, 1}, 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Hypervolume(tt.front, tt.ref); math.Abs(got-tt.want) > tolerance {
+				t.Errorf("Hypervolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHypervolumeAlgorithmsAgree checks the 2-D and 3-D sweeps against WFG on random fronts.
+func TestHypervolumeAlgorithmsAgree(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, d := range []int{2, 3} {
+		ref := make([]float64, d)
+		for i := range ref {
+			ref[i] = 1
+		}
+		for range 50 {
+			pts := make([][]float64, 1+rng.IntN(12))
+			for i := range pts {
+				pts[i] = make([]float64, d)
+				for j := range pts[i] {
+					pts[i][j] = rng.Float64()
+				}
+			}
+			sweep := hvSlice(pts, ref)
+			exact := wfgAnyDimension(nonDominated(pts), ref)
+			if math.Abs(sweep-exact) > tolerance {
+				t.Fatalf("%d objectives, %v: sweep %v, WFG %v", d, pts, sweep, exact)
+			}
+		}
+	}
+}
+
+// wfgAnyDimension is wfg without its shortcut to the 3-D sweep.
+func wfgAnyDimension(pts [][]float64, ref []float64) float64 {
+	if len(pts) == 0 {
+		return 0
+	}
+	p, rest := pts[0], pts[1:]
+	limited := make([][]float64, 0, len(rest))
+	for _, q := range rest {
+		limit := make([]float64, len(ref))
+		for i := range limit {
+			limit[i] = max(p[i], q[i])
+		}
+		limited = append(limited, limit)
+	}
+	return inclusive(p, ref) - wfgAnyDimension(nonDominated(limited), ref) + wfgAnyDimension(rest, ref)
+}
+
+func TestContributions(t *testing.T) {
+	// total 6.5; without each point 5.5, 5, 6 and 6.5
+	front := [][]float64{{1, 3}, {2, 1.5}, {3, 1}, {3, 3}}
+	want := []float64{1, 1.5, 0.5, 0}
+	got := Contributions(front, []float64{4, 4})
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("Contributions() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestDominates(t *testing.T) {
+	tests := []struct {
+		a, b []float64
+		want bool
+	}{
+		{[]float64{1, 1}, []float64{2, 2}, true},
+		{[]float64{1, 2}, []float64{1, 3}, true},
+		{[]float64{1, 1}, []float64{1, 1}, false},
+		{[]float64{1, 3}, []float64{2, 2}, false},
+	}
+	for _, tt := range tests {
+		if got := Dominates(tt.a, tt.b); got != tt.want {
+			t.Errorf("Dominates(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
+// GD is the generational distance: the mean Euclidean distance from every point
+// of front to its nearest point of the reference front.
+func GD(front, reference [][]float64) float64 {
+	return meanNearest(front, reference, euclidean)
+}
+
+// IGD is the inverted generational distance: the mean Euclidean distance from every
+// point of the reference front to its nearest point of front.
+func IGD(front, reference [][]float64) float64 {
+	return meanNearest(reference, front, euclidean)
+}
+
+// IGDPlus is IGD with the dominance-compliant distance of Ishibuchi et al. (2015),
+// which only counts how much a point of front is worse than a reference point.
+func IGDPlus(front, reference [][]float64) float64 {
+	return meanNearest(reference, front, func(z, a []float64) float64 {
+		sum := 0.0
+		for i := range z {
+			if d := a[i] - z[i]; d > 0 {
+				sum += d * d
+			}
+		}
+		return math.Sqrt(sum)
+	})
+}
+
+// Spacing is Schott's spacing: the standard deviation of the Manhattan distances
+// from every point of front to its nearest neighbor. Zero means evenly spaced points.
+func Spacing(front [][]float64) float64 {
+	n := len(front)
+	if n < 2 {
+		return 0
+	}
+	distances := make([]float64, n)
+	for i, p := range front {
+		distances[i] = math.Inf(1)
+		for j, q := range front {
+			if i != j {
+				distances[i] = min(distances[i], manhattan(p, q))
+			}
+		}
+	}
+	mean := 0.0
+	for _, d := range distances {
+		mean += d
+	}
+	mean /= float64(n)
+	sum := 0.0
+	for _, d := range distances {
+		sum += (d - mean) * (d - mean)
+	}
+	return math.Sqrt(sum / float64(n-1))
+}
+
+// Spread is Deb's Δ for two objectives: it combines the distances between
+// consecutive points of front with the gaps to the extreme points of the reference
+// front. Zero means an evenly spread front reaching both extremes.
+func Spread(front, reference [][]float64) float64 {
+	if len(front) > 0 && len(front[0]) != 2 {
+		panic("Spread is defined for two objectives")
+	}
+	if len(front) < 2 || len(reference) == 0 {
+		return 0
+	}
+
+	byFirst := func(pts [][]float64) [][]float64 {
+		sorted := slices.Clone(pts)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+		return sorted
+	}
+	sorted := byFirst(front)
+	ref := byFirst(reference)
+
+	df := euclidean(ref[0], sorted[0])
+	dl := euclidean(ref[len(ref)-1], sorted[len(sorted)-1])
+
+	gaps := make([]float64, len(sorted)-1)
+	mean := 0.0
+	for i := range gaps {
+		gaps[i] = euclidean(sorted[i], sorted[i+1])
+		mean += gaps[i]
+	}
+	mean /= float64(len(gaps))
+
+	deviation := 0.0
+	for _, g := range gaps {
+		deviation += math.Abs(g - mean)
+	}
+	denominator := df + dl + float64(len(gaps))*mean
+	if denominator == 0 {
+		return 0
+	}
+	return (df + dl + deviation) / denominator
+}
+
+// meanNearest averages, over the points of from, the distance to the nearest point of to.
+func meanNearest(from, to [][]float64, distance func(a, b []float64) float64) float64 {
+	if len(from) == 0 || len(to) == 0 {
+		return math.Inf(1)
+	}
+	sum := 0.0
+	for _, p := range from {
+		nearest := math.Inf(1)
+		for _, q := range to {
+			nearest = min(nearest, distance(p, q))
+		}
+		sum += nearest
+	}
+	return sum / float64(len(from))
+}
+
+func euclidean(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(sum)
+}
+
+func manhattan(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += math.Abs(a[i] - b[i])
+	}
+	return sum
+}
+
+// Indicators returns a function computing every indicator of a front against
+// the reference point ref (for hypervolume) and the reference front, keyed by
+// "hv", "gd", "igd", "igd+", "spacing" and, for two objectives, "spread".
+// Either reference may be nil to skip the indicators that need it. The distance
+// indicators are left out for an empty front, where they are infinite and cannot
+// be written to JSON.
+func Indicators(ref []float64, reference [][]float64) func(front [][]float64) map[string]float64 {
+	return func(front [][]float64) map[string]float64 {
+		values := map[string]float64{"spacing": Spacing(front)}
+		if ref != nil {
+			values["hv"] = Hypervolume(front, ref)
+		}
+		if reference != nil && len(front) > 0 {
+			values["gd"] = GD(front, reference)
+			values["igd"] = IGD(front, reference)
+			values["igd+"] = IGDPlus(front, reference)
+			if len(front) > 0 && len(front[0]) == 2 {
+				values["spread"] = Spread(front, reference)
+			}
+		}
+		return values
+	}
+}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"testing"
+)
+
+func TestDistanceIndicators(t *testing.T) {
+	reference := [][]float64{{0, 0}, {1, 0}}
+	tests := []struct {
+		name      string
+		indicator func(front, reference [][]float64) float64
+		front     [][]float64
+		want      float64
+	}{
+		{"GD", GD, [][]float64{{0, 0.5}}, 0.5},
+		{"GD on the reference", GD, reference, 0},
+		{"GD of an empty front", GD, nil, math.Inf(1)},
+		// (0.5 + √1.25) / 2
+		{"IGD", IGD, [][]float64{{0, 0.5}}, (0.5 + math.Sqrt(1.25)) / 2},
+		{"IGD of a subset", IGD, [][]float64{{0, 0}}, 0.5},
+		// only the worse coordinates count, so (1, 0) is 0.5 away as well
+		{"IGD+", IGDPlus, [][]float64{{0, 0.5}}, 0.5},
+		{"IGD+ of a dominating front", IGDPlus, [][]float64{{-1, -1}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.indicator(tt.front, reference)
+			if math.IsInf(tt.want, 1) != math.IsInf(got, 1) || (!math.IsInf(got, 1) && math.Abs(got-tt.want) > tolerance) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpacing(t *testing.T) {
+	tests := []struct {
+		name  string
+		front [][]float64
+		want  float64
+	}{
+		{"single point", [][]float64{{0, 1}}, 0},
+		{"evenly spaced", [][]float64{{0, 2}, {1, 1}, {2, 0}}, 0},
+		// nearest Manhattan distances 2, 2 and 4
+		{"uneven", [][]float64{{0, 3}, {1, 2}, {3, 0}}, math.Sqrt(4.0 / 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Spacing(tt.front); math.Abs(got-tt.want) > tolerance {
+				t.Errorf("Spacing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpread(t *testing.T) {
+	reference := [][]float64{{1, 0}, {0, 1}}
+	tests := []struct {
+		name  string
+		front [][]float64
+		want  float64
+	}{
+		{"even front reaching the extremes", [][]float64{{1, 0}, {0, 1}, {0.5, 0.5}}, 0},
+		// df = √0.125, dl = √0.5 and one gap of √0.125
+		{"front short of the extremes", [][]float64{{0.25, 0.75}, {0.5, 0.5}}, 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Spread(tt.front, reference); math.Abs(got-tt.want) > tolerance {
+				t.Errorf("Spread() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndicators(t *testing.T) {
+	values := Indicators([]float64{2, 2}, [][]float64{{0, 1}, {1, 0}})([][]float64{{0, 1}, {1, 0}})
+	want := map[string]float64{"hv": 3, "gd": 0, "igd": 0, "igd+": 0, "spacing": 0, "spread": 0}
+	if len(values) != len(want) {
+		t.Fatalf("Indicators() = %v, want keys of %v", values, want)
+	}
+	for key, v := range want {
+		if math.Abs(values[key]-v) > tolerance {
+			t.Errorf("%s = %v, want %v", key, values[key], v)
+		}
+	}
+}
+
+func TestIndicatorsOfEmptyFront(t *testing.T) {
+	values := Indicators([]float64{2, 2}, [][]float64{{0, 1}, {1, 0}})(nil)
+	if want := map[string]float64{"hv": 0, "spacing": 0}; !maps.Equal(values, want) {
+		t.Errorf("Indicators() = %v, want %v", values, want)
+	}
+	if _, err := json.Marshal(values); err != nil {
+		t.Errorf("indicators of an empty front cannot be logged: %v", err)
+	}
+}
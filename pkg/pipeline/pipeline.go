@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// Handoff is what a stage passes to the next one.
+type Handoff struct {
+	Best       problems.Solution
+	Population []problems.Solution
+}
+
+// Env is shared by all stages of a pipeline run.
+type Env struct {
+	Problem problems.Problem
+	// Rand seeds every stage, so a pipeline is reproducible from its seed.
+	Rand   *rand.Rand
+	Logger algos.ProgressLoggerProvider
+}
+
+// Stage is one phase of a hybrid method, e.g. a force-directed layout or a GA.
+type Stage interface {
+	Name() string
+	Run(ctx context.Context, env Env, in Handoff) (Handoff, error)
+}
+
+// StageResult summarizes a finished stage.
+type StageResult struct {
+	Name    string
+	Best    problems.Solution
+	Elapsed time.Duration
+}
+
+// Result is the outcome of a pipeline run.
+type Result struct {
+	Handoff
+	Stages  []StageResult
+	Elapsed time.Duration
+}
+
+// Pipeline chains stages: the best solution or population of each stage seeds the next.
+type Pipeline struct {
+	Problem problems.Problem
+	Seed    uint64
+	Logger  algos.ProgressLoggerProvider
+	Stages  []Stage
+}
+
+func New(problem problems.Problem, seed uint64, logger algos.ProgressLoggerProvider, stages ...Stage) *Pipeline {
+	return &Pipeline{Problem: problem, Seed: seed, Logger: logger, Stages: stages}
+}
+
+// Run executes the stages in order. All stages log into the pipeline logger,
+// with each record tagged by the stage name and elapsed time counted from the pipeline start.
+func (p *Pipeline) Run(ctx context.Context) (Result, error) {
+	start := time.Now()
+	rng := problems.NewRand(p.Seed)
+	result := Result{Stages: make([]StageResult, 0, len(p.Stages))}
+
+	for _, stage := range p.Stages {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		stageStart := time.Now()
+		env := Env{Problem: p.Problem, Rand: rng}
+		if p.Logger != nil {
+			env.Logger = &stageLogger{ProgressLoggerProvider: p.Logger, stage: stage.Name(), offset: stageStart.Sub(start)}
+		}
+
+		out, err := stage.Run(ctx, env, result.Handoff)
+		if err != nil {
+			return result, fmt.Errorf("stage %s: %w", stage.Name(), err)
+		}
+		result.Handoff = out
+		result.Stages = append(result.Stages, StageResult{
+			Name:    stage.Name(),
+			Best:    out.Best,
+			Elapsed: time.Since(stageStart),
+		})
+	}
+
+	result.Elapsed = time.Since(start)
+	if p.Logger != nil {
+		return result, p.Logger.Flush()
+	}
+	return result, nil
+}
+
+// StageStep is a GAStep tagged with the pipeline stage that produced it.
+type StageStep struct {
+	algos.GAStep
+	Stage string `json:"stage"`
+}
+
+type stageLogger struct {
+	algos.ProgressLoggerProvider
+	stage  string
+	offset time.Duration
+}
+
+// GAStepRecord lets sinks that only understand GAStep write staged steps.
+func (s StageStep) GAStepRecord() algos.GAStep {
+	return s.GAStep
+}
+
+func (l *stageLogger) LogStep(step any) error {
+	if s, ok := step.(algos.GAStep); ok {
+		s.Elapsed += l.offset
+		step = StageStep{GAStep: s, Stage: l.stage}
+	}
+	return l.ProgressLoggerProvider.LogStep(step)
+}
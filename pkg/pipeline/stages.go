@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos/hillclimb"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane"
+)
+
+// Handover selects what a GA stage takes from the previous stage.
+type Handover int
+
+const (
+	// SeedBest seeds the population with mutants of the previous best solution.
+	SeedBest Handover = iota
+	// SeedPopulation reuses the previous population as is.
+	SeedPopulation
+)
+
+// GAStage runs a registered algorithm.
+type GAStage struct {
+	Label       string         // stage name, defaults to Algorithm
+	Algorithm   string         // registry name, e.g. "nsga2"
+	Params      algos.ParamMap // "seed" is filled from the pipeline RNG unless set
+	Generations int
+	TimeBudget  time.Duration // 0 means no time limit
+	Termination algos.Criterion
+	Handover    Handover
+}
+
+func (s GAStage) Name() string {
+	if s.Label != "" {
+		return s.Label
+	}
+	return s.Algorithm
+}
+
+func (s GAStage) Run(ctx context.Context, env Env, in Handoff) (Handoff, error) {
+	params := make(algos.ParamMap, len(s.Params)+1)
+	for k, v := range s.Params {
+		params[k] = v
+	}
+	if _, ok := params["seed"]; !ok {
+		// keep the seed exactly representable if the params are ever written as JSON
+		params["seed"] = int(env.Rand.Uint64() >> 12)
+	}
+
+	alg, err := algos.New(s.Algorithm, env.Problem, params, s.Generations, env.Logger)
+	if err != nil {
+		return Handoff{}, err
+	}
+	if s.Termination != nil {
+		alg.SetTermination(s.Termination)
+	}
+
+	switch {
+	case s.Handover == SeedPopulation && len(in.Population) > 0:
+		size, err := params.Int("population_size", len(in.Population))
+		if err != nil {
+			return Handoff{}, err
+		}
+		if size != len(in.Population) {
+			return Handoff{}, fmt.Errorf("previous stage handed over %d individuals, %s expects %d", len(in.Population), s.Algorithm, size)
+		}
+		alg.SetPopulation(in.Population)
+	case in.Best != nil:
+		alg.Seed(in.Best)
+	}
+
+	ctx, cancel := withBudget(ctx, s.TimeBudget)
+	defer cancel()
+	alg.Run(ctx)
+	return Handoff{Best: alg.GetSolution(), Population: alg.GetPopulation()}, alg.Err()
+}
+
+// ForceDirectedStage applies a Fruchterman–Reingold layout to the previous best
+// graphplane solution, or to a random one when it is the first stage.
+type ForceDirectedStage struct {
+	Params     graphplane.FDSParams
+	TimeBudget time.Duration // 0 means no time limit
+}
+
+func (s ForceDirectedStage) Name() string {
+	return "FR"
+}
+
+func (s ForceDirectedStage) Run(ctx context.Context, env Env, in Handoff) (Handoff, error) {
+	initial := in.Best
+	if initial == nil {
+		initial = env.Problem.RandomSolution(env.Rand)
+	}
+	gpSol, ok := initial.(*graphplane.GraphPlaneSolution)
+	if !ok {
+		return Handoff{}, fmt.Errorf("force-directed stage needs a graphplane solution, got %T", initial)
+	}
+	// the solver moves vertices in place, work on a copy to keep the previous stage intact
+	layout := &graphplane.GraphPlaneSolution{
+		Graph:         gpSol.Graph,
+		Width:         gpSol.Width,
+		Height:        gpSol.Height,
+		VertPositions: slices.Clone(gpSol.VertPositions),
+	}
+
+	ctx, cancel := withBudget(ctx, s.TimeBudget)
+	defer cancel()
+	solver := graphplane.NewForceDirectedSolver(layout, s.Params, env.Logger)
+	best := solver.Solve(ctx).Solution
+	return Handoff{Best: best, Population: in.Population}, solver.Err()
+}
+
+// LocalSearchStage improves the previous best solution by first-improvement
+// hill climbing (the hillclimb package), drawing one neighbor per step with
+// Mutation as the neighborhood operator.
+type LocalSearchStage struct {
+	Mutation   problems.MutationFunc
+	Steps      int
+	TimeBudget time.Duration // 0 means no time limit
+}
+
+func (s LocalSearchStage) Name() string {
+	return "LS"
+}
+
+func (s LocalSearchStage) Run(ctx context.Context, env Env, in Handoff) (Handoff, error) {
+	params := hillclimb.Params{
+		MutationFunc: s.Mutation,
+		Neighbors:    1,
+		Strategy:     hillclimb.FirstImprovement,
+		Seed:         env.Rand.Uint64(),
+	}
+	alg := hillclimb.NewAlgorithm(env.Problem, params, s.Steps, env.Logger)
+	if in.Best != nil {
+		alg.Seed(in.Best)
+	}
+
+	ctx, cancel := withBudget(ctx, s.TimeBudget)
+	defer cancel()
+	alg.Run(ctx)
+	return Handoff{Best: alg.GetSolution(), Population: in.Population}, alg.Err()
+}
+
+// withBudget limits ctx to budget, if it is positive.
+func withBudget(ctx context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	if budget > 0 {
+		return context.WithTimeout(ctx, budget)
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/internal/testutil"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems/graphplane"
+)
+
+func env(problem problems.Problem) Env {
+	return Env{Problem: problem, Rand: rand.New(rand.NewPCG(1, 2))}
+}
+
+func TestForceDirectedStage(t *testing.T) {
+	problem := graphplane.NewGraphPlaneProblem(rand.New(rand.NewPCG(1, 2)), 10, 15)
+	in := Handoff{Best: problem.RandomSolution(rand.New(rand.NewPCG(3, 4)))}
+	positions := slices.Clone(in.Best.(*graphplane.GraphPlaneSolution).VertPositions)
+	params := graphplane.FDSParams{Steps: 1 << 30, Temp: 0.005, K: 1}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	out, err := ForceDirectedStage{Params: params}.Run(canceled, env(problem), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.Best.(*graphplane.GraphPlaneSolution).VertPositions; !slices.Equal(got, positions) {
+		t.Error("a canceled stage moved vertices")
+	}
+
+	start := time.Now()
+	out, err = ForceDirectedStage{Params: params, TimeBudget: 20 * time.Millisecond}.Run(context.Background(), env(problem), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("stage with a 20ms budget ran for %v", elapsed)
+	}
+	if slices.Equal(out.Best.(*graphplane.GraphPlaneSolution).VertPositions, positions) {
+		t.Error("stage did not move any vertex")
+	}
+	if !slices.Equal(in.Best.(*graphplane.GraphPlaneSolution).VertPositions, positions) {
+		t.Error("stage moved the vertices of the previous best solution")
+	}
+}
+
+func TestLocalSearchStage(t *testing.T) {
+	sphere := testutil.Sphere{Dimensions: 2}
+	in := Handoff{
+		Best:       &testutil.SphereSolution{X: []float64{4, 4}},
+		Population: []problems.Solution{&testutil.SphereSolution{X: []float64{1, 1}}},
+	}
+	stage := LocalSearchStage{Mutation: testutil.Nudge, Steps: 500}
+	out, err := stage.Run(context.Background(), env(sphere), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.Best.Fitness(); got > 1 {
+		t.Errorf("best fitness %v after 500 steps from 32", got)
+	}
+	if len(out.Population) != 1 || out.Population[0] != in.Population[0] {
+		t.Error("stage did not pass the population on")
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if out, err = stage.Run(canceled, env(sphere), in); err != nil || out.Best != in.Best {
+		t.Errorf("canceled stage returned %v, %v, want the previous best", out.Best, err)
+	}
+}
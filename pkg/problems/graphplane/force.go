@@ -1,6 +1,7 @@
 package graphplane
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
@@ -24,6 +25,7 @@ type ForceDirectedSolver struct {
 	k           float64
 	temp        float64
 	coolingStep float64
+	err         error
 }
 
 func NewForceDirectedSolver(initialSolution problems.Solution, params FDSParams, logger algos.ProgressLoggerProvider) ForceDirectedSolver {
@@ -39,8 +41,9 @@ func NewForceDirectedSolver(initialSolution problems.Solution, params FDSParams,
 	}
 }
 
-// Solve runs the spring-electrical simulation and returns a solution.
-func (s *ForceDirectedSolver) Solve() problems.AlgorithmicSolution {
+// Solve runs the spring-electrical simulation and returns a solution. It stops
+// early, with the layout reached so far, once ctx is done.
+func (s *ForceDirectedSolver) Solve(ctx context.Context) problems.AlgorithmicSolution {
 	start := time.Now()
 
 	n := s.Graph.NumVertices
@@ -56,19 +59,29 @@ func (s *ForceDirectedSolver) Solve() problems.AlgorithmicSolution {
 	}
 
 	for step := range s.params.Steps {
+		if ctx.Err() != nil {
+			break
+		}
 		s.Iterate()
 
 		s.CachedObjectives = nil
 		s.Fitness()
 
 		if s.logger != nil {
-			s.logger.LogStep(algos.GAStep{Elapsed: time.Since(start), Solution: s.GraphPlaneSolution, Step: step + 1})
+			if s.err = s.logger.LogStep(algos.GAStep{Elapsed: time.Since(start), Solution: s.GraphPlaneSolution, Step: step + 1}); s.err != nil {
+				break
+			}
 		}
 	}
 
 	return problems.AlgorithmicSolution{Solution: s.GraphPlaneSolution, TimeTook: time.Since(start)}
 }
 
+// Err returns the logging error that stopped Solve early, if any.
+func (s *ForceDirectedSolver) Err() error {
+	return s.err
+}
+
 func (s *ForceDirectedSolver) Iterate() {
 	n := s.Graph.NumVertices
 
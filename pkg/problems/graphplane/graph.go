@@ -3,6 +3,7 @@ package graphplane
 import (
 	"fmt"
 	"math/rand/v2"
+	"slices"
 
 	"github.com/fogleman/delaunay"
 )
@@ -19,7 +20,7 @@ type Edge struct {
 	To   int `json:"to"`
 }
 
-func NewRandomGraph(numVertices, numEdges int) *Graph {
+func NewRandomGraph(rng *rand.Rand, numVertices, numEdges int) *Graph {
 	if numEdges < 0 || numEdges > numVertices*(numVertices-1)/2 {
 		panic("edgeNum is out of valid range")
 	}
@@ -27,8 +28,8 @@ func NewRandomGraph(numVertices, numEdges int) *Graph {
 	edges := make([]Edge, 0, numEdges)
 	edgeSet := make(map[string]bool)
 	for len(edges) < numEdges {
-		u := rand.IntN(numVertices)
-		v := rand.IntN(numVertices)
+		u := rng.IntN(numVertices)
+		v := rng.IntN(numVertices)
 		if u == v {
 			continue // skip self-loops
 		}
@@ -49,11 +50,11 @@ func NewRandomGraph(numVertices, numEdges int) *Graph {
 }
 
 // NewRandomPlanarGraph builds a planar graph using Delaunay triangulation.
-func NewRandomPlanarGraph(numVertices int) *Graph {
+func NewRandomPlanarGraph(rng *rand.Rand, numVertices int) *Graph {
 	// 1) Sample random points in unit square
 	pts := make([]delaunay.Point, numVertices)
 	for i := range pts {
-		pts[i] = delaunay.Point{X: rand.Float64(), Y: rand.Float64()}
+		pts[i] = delaunay.Point{X: rng.Float64(), Y: rng.Float64()}
 	}
 
 	// 2) Compute Delaunay triangulation (planar maximal graph)
@@ -78,6 +79,13 @@ func NewRandomPlanarGraph(numVertices int) *Graph {
 	for e := range edgeMap {
 		edges = append(edges, Edge{From: e[0], To: e[1]})
 	}
+	// map iteration order is random, sort to keep graphs reproducible from a seed
+	slices.SortFunc(edges, func(a, b Edge) int {
+		if a.From != b.From {
+			return a.From - b.From
+		}
+		return a.To - b.To
+	})
 
 	// 6) Build and return Graph
 	return &Graph{
@@ -8,7 +8,7 @@ import (
 )
 
 func Uniform(swapProb float64) problems.CrossoverFunc {
-	return func(parentA, parentB problems.Solution) []problems.Solution {
+	return func(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
 		a, aOk := parentA.(*graphplane.GraphPlaneSolution)
 		b, bOk := parentB.(*graphplane.GraphPlaneSolution)
 		if !aOk || !bOk || len(a.VertPositions) != len(b.VertPositions) {
@@ -20,7 +20,7 @@ func Uniform(swapProb float64) problems.CrossoverFunc {
 		c1.VertPositions = make([]graphplane.VertexPos, len(a.VertPositions))
 		c2.VertPositions = make([]graphplane.VertexPos, len(a.VertPositions))
 		for i := range a.VertPositions {
-			if rand.Float64() < swapProb {
+			if rng.Float64() < swapProb {
 				c1.VertPositions[i] = b.VertPositions[i]
 				c2.VertPositions[i] = a.VertPositions[i]
 			} else {
@@ -8,7 +8,7 @@ import (
 )
 
 func AdaptiveNorm(maxSteps int, k float64) problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -16,7 +16,7 @@ func AdaptiveNorm(maxSteps int, k float64) problems.MutationFunc {
 
 		tangled := s.TangledVertexes()
 		if len(tangled) > 0 {
-			return FixedNorm(k)(s)
+			return FixedNorm(k)(rng, s)
 		}
 
 		m := &graphplane.GraphPlaneSolution{Graph: s.Graph, Width: s.Width, Height: s.Height}
@@ -24,13 +24,13 @@ func AdaptiveNorm(maxSteps int, k float64) problems.MutationFunc {
 		copy(m.VertPositions, s.VertPositions)
 
 		for range maxSteps {
-			i := rand.IntN(len(m.VertPositions))
+			i := rng.IntN(len(m.VertPositions))
 
 			oldX := m.VertPositions[i].X
 			oldY := m.VertPositions[i].Y
 
-			dx := rand.NormFloat64() * s.Width * k
-			dy := rand.NormFloat64() * s.Height * k
+			dx := rng.NormFloat64() * s.Width * k
+			dy := rng.NormFloat64() * s.Height * k
 			m.VertPositions[i].X = clamp(m.VertPositions[i].X+dx, 0, s.Width)
 			m.VertPositions[i].Y = clamp(m.VertPositions[i].Y+dy, 0, s.Height)
 
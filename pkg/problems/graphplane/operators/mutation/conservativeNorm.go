@@ -8,7 +8,7 @@ import (
 )
 
 func ConservativeNorm(k float64) problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -18,14 +18,14 @@ func ConservativeNorm(k float64) problems.MutationFunc {
 		m.VertPositions = make([]graphplane.VertexPos, len(s.VertPositions))
 		copy(m.VertPositions, s.VertPositions)
 
-		i := rand.IntN(len(m.VertPositions))
+		i := rng.IntN(len(m.VertPositions))
 
 		oldIntersections := m.CountIntersections()
 		oldX := m.VertPositions[i].X
 		oldY := m.VertPositions[i].Y
 
-		dx := rand.NormFloat64() * s.Width * k
-		dy := rand.NormFloat64() * s.Height * k
+		dx := rng.NormFloat64() * s.Width * k
+		dy := rng.NormFloat64() * s.Height * k
 		m.VertPositions[i].X = clamp(m.VertPositions[i].X+dx, 0, s.Width)
 		m.VertPositions[i].Y = clamp(m.VertPositions[i].Y+dy, 0, s.Height)
 
@@ -8,7 +8,7 @@ import (
 )
 
 func FixedNorm(k float64) problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -21,9 +21,9 @@ func FixedNorm(k float64) problems.MutationFunc {
 		if len(tangled) == 0 {
 			return m
 		}
-		i := tangled[rand.IntN(len(tangled))]
-		dx := rand.NormFloat64() * s.Width * k
-		dy := rand.NormFloat64() * s.Height * k
+		i := tangled[rng.IntN(len(tangled))]
+		dx := rng.NormFloat64() * s.Width * k
+		dy := rng.NormFloat64() * s.Height * k
 		m.VertPositions[i].X = clamp(m.VertPositions[i].X+dx, 0, s.Width)
 		m.VertPositions[i].Y = clamp(m.VertPositions[i].Y+dy, 0, s.Height)
 
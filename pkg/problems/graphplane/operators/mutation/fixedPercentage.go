@@ -8,7 +8,7 @@ import (
 )
 
 func FixedPercentage() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -21,11 +21,11 @@ func FixedPercentage() problems.MutationFunc {
 		if len(tangled) == 0 {
 			return m
 		}
-		i := tangled[rand.IntN(len(tangled))]
-		if rand.Float64() < 0.5 {
-			m.VertPositions[i].X = clamp(m.VertPositions[i].X*(0.8+rand.Float64()*0.4), 0, s.Width)
+		i := tangled[rng.IntN(len(tangled))]
+		if rng.Float64() < 0.5 {
+			m.VertPositions[i].X = clamp(m.VertPositions[i].X*(0.8+rng.Float64()*0.4), 0, s.Width)
 		} else {
-			m.VertPositions[i].Y = clamp(m.VertPositions[i].Y*(0.8+rand.Float64()*0.4), 0, s.Height)
+			m.VertPositions[i].Y = clamp(m.VertPositions[i].Y*(0.8+rng.Float64()*0.4), 0, s.Height)
 		}
 
 		return m
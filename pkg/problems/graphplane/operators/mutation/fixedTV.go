@@ -9,7 +9,7 @@ import (
 )
 
 func FixedTensionVector(epsilon float64) problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -23,7 +23,7 @@ func FixedTensionVector(epsilon float64) problems.MutationFunc {
 		if len(tangled) == 0 {
 			return m
 		}
-		u := tangled[rand.IntN(len(tangled))]
+		u := tangled[rng.IntN(len(tangled))]
 
 		disp := graphplane.VertexPos{X: 0, Y: 0}
 
@@ -8,7 +8,7 @@ import (
 )
 
 func FixedUniform() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -21,9 +21,9 @@ func FixedUniform() problems.MutationFunc {
 		if len(tangled) == 0 {
 			return m
 		}
-		i := tangled[rand.IntN(len(tangled))]
-		m.VertPositions[i].X = rand.Float64() * s.Width
-		m.VertPositions[i].Y = rand.Float64() * s.Height
+		i := tangled[rng.IntN(len(tangled))]
+		m.VertPositions[i].X = rng.Float64() * s.Width
+		m.VertPositions[i].Y = rng.Float64() * s.Height
 
 		return m
 	}
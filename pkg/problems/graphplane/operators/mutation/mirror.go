@@ -8,7 +8,7 @@ import (
 )
 
 func Mirror() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -17,8 +17,8 @@ func Mirror() problems.MutationFunc {
 		m.VertPositions = make([]graphplane.VertexPos, len(s.VertPositions))
 		copy(m.VertPositions, s.VertPositions)
 
-		i := rand.IntN(len(m.VertPositions))
-		if rand.Float64() < 0.5 {
+		i := rng.IntN(len(m.VertPositions))
+		if rng.Float64() < 0.5 {
 			m.VertPositions[i].X = s.Width - m.VertPositions[i].X
 		} else {
 			m.VertPositions[i].Y = s.Height - m.VertPositions[i].Y
@@ -8,7 +8,7 @@ import (
 )
 
 func Norm(k float64) problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -17,9 +17,9 @@ func Norm(k float64) problems.MutationFunc {
 		m.VertPositions = make([]graphplane.VertexPos, len(s.VertPositions))
 		copy(m.VertPositions, s.VertPositions)
 
-		i := rand.IntN(len(m.VertPositions))
-		dx := rand.NormFloat64() * s.Width * k
-		dy := rand.NormFloat64() * s.Height * k
+		i := rng.IntN(len(m.VertPositions))
+		dx := rng.NormFloat64() * s.Width * k
+		dy := rng.NormFloat64() * s.Height * k
 		m.VertPositions[i].X = clamp(m.VertPositions[i].X+dx, 0, s.Width)
 		m.VertPositions[i].Y = clamp(m.VertPositions[i].Y+dy, 0, s.Height)
 
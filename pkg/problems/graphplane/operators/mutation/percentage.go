@@ -8,7 +8,7 @@ import (
 )
 
 func Percentage() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -17,11 +17,11 @@ func Percentage() problems.MutationFunc {
 		m.VertPositions = make([]graphplane.VertexPos, len(s.VertPositions))
 		copy(m.VertPositions, s.VertPositions)
 
-		i := rand.IntN(len(m.VertPositions))
-		if rand.Float64() < 0.5 {
-			m.VertPositions[i].X = clamp(m.VertPositions[i].X*(0.8+rand.Float64()*0.4), 0, s.Width)
+		i := rng.IntN(len(m.VertPositions))
+		if rng.Float64() < 0.5 {
+			m.VertPositions[i].X = clamp(m.VertPositions[i].X*(0.8+rng.Float64()*0.4), 0, s.Width)
 		} else {
-			m.VertPositions[i].Y = clamp(m.VertPositions[i].Y*(0.8+rand.Float64()*0.4), 0, s.Height)
+			m.VertPositions[i].Y = clamp(m.VertPositions[i].Y*(0.8+rng.Float64()*0.4), 0, s.Height)
 		}
 
 		return m
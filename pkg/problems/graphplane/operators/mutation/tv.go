@@ -9,7 +9,7 @@ import (
 )
 
 func TensionVector(epsilon float64) problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -19,7 +19,7 @@ func TensionVector(epsilon float64) problems.MutationFunc {
 		copy(m.VertPositions, s.VertPositions)
 
 		n := s.Graph.NumVertices
-		u := rand.IntN(n)
+		u := rng.IntN(n)
 
 		var neighbors []int
 		for _, e := range m.Graph.Edges {
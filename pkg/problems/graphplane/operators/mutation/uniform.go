@@ -8,7 +8,7 @@ import (
 )
 
 func Uniform() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*graphplane.GraphPlaneSolution)
 		if !ok {
 			panic("invalid individual")
@@ -17,9 +17,9 @@ func Uniform() problems.MutationFunc {
 		m.VertPositions = make([]graphplane.VertexPos, len(s.VertPositions))
 		copy(m.VertPositions, s.VertPositions)
 
-		i := rand.IntN(len(m.VertPositions))
-		m.VertPositions[i].X = rand.Float64() * s.Width
-		m.VertPositions[i].Y = rand.Float64() * s.Height
+		i := rng.IntN(len(m.VertPositions))
+		m.VertPositions[i].X = rng.Float64() * s.Width
+		m.VertPositions[i].Y = rng.Float64() * s.Height
 
 		return m
 	}
@@ -1,9 +1,28 @@
 package graphplane
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	for _, name := range []string{"GraphPlane", "PlanarGraphPlane"} {
+		problems.RegisterProblem(name, func(data []byte) (problems.Problem, error) {
+			p := &GraphPlaneProblem{name: name}
+			if err := json.Unmarshal(data, p); err != nil {
+				return nil, err
+			}
+			if p.Graph == nil {
+				return nil, fmt.Errorf("%s problem has no graph", name)
+			}
+			return p, nil
+		})
+	}
+}
+
 type GraphPlaneProblem struct {
 	name   string
 	Graph  *Graph  `json:"graph"`
@@ -11,18 +30,33 @@ type GraphPlaneProblem struct {
 	Height float64 `json:"height"`
 }
 
-func NewGraphPlaneProblem(numVertices, numEdges int) problems.Problem {
-	return &GraphPlaneProblem{"GraphPlane", NewRandomGraph(numVertices, numEdges), 1.0, 1.0}
+// NewGraphPlaneProblem generates a random graph using rng.
+func NewGraphPlaneProblem(rng *rand.Rand, numVertices, numEdges int) problems.Problem {
+	return &GraphPlaneProblem{"GraphPlane", NewRandomGraph(rng, numVertices, numEdges), 1.0, 1.0}
 }
 
-func NewPlanarGraphPlaneProblem(numVertices int) problems.Problem {
-	return &GraphPlaneProblem{"PlanarGraphPlane", NewRandomPlanarGraph(numVertices), 1.0, 1.0}
+// NewPlanarGraphPlaneProblem generates a random planar graph using rng.
+func NewPlanarGraphPlaneProblem(rng *rand.Rand, numVertices int) problems.Problem {
+	return &GraphPlaneProblem{"PlanarGraphPlane", NewRandomPlanarGraph(rng, numVertices), 1.0, 1.0}
 }
 
 func (p *GraphPlaneProblem) Name() string {
 	return p.name
 }
 
-func (p *GraphPlaneProblem) RandomSolution() problems.Solution {
-	return RandomGraphPlaneSolution(p.Graph, p.Width, p.Height)
+func (p *GraphPlaneProblem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomGraphPlaneSolution(rng, p.Graph, p.Width, p.Height)
+}
+
+// DecodeSolution restores a solution of this problem, reattaching the graph and plane size.
+func (p *GraphPlaneProblem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &GraphPlaneSolution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if len(s.VertPositions) != p.Graph.NumVertices {
+		return nil, fmt.Errorf("solution places %d vertices, graph has %d", len(s.VertPositions), p.Graph.NumVertices)
+	}
+	s.Graph, s.Width, s.Height = p.Graph, p.Width, p.Height
+	return s, nil
 }
@@ -2,7 +2,8 @@ package graphplane
 
 import (
 	"math"
-	"math/rand"
+	"math/rand/v2"
+	"slices"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 	"gonum.org/v1/gonum/stat"
@@ -14,6 +15,8 @@ type VertexPos struct {
 	Y float64 `json:"y"`
 }
 
+var _ problems.MeasurableSolution = (*GraphPlaneSolution)(nil)
+
 // GraphPlaneSolution represents a placement of Graph vertices in the plane.
 type GraphPlaneSolution struct {
 	Graph            *Graph      `json:"-"`
@@ -25,11 +28,11 @@ type GraphPlaneSolution struct {
 }
 
 // RandomGraphPlaneSolution initializes vertices randomly in [0,width]×[0,height].
-func RandomGraphPlaneSolution(g *Graph, width, height float64) problems.Solution {
+func RandomGraphPlaneSolution(rng *rand.Rand, g *Graph, width, height float64) problems.Solution {
 	s := &GraphPlaneSolution{Graph: g, Width: width, Height: height}
 	s.VertPositions = make([]VertexPos, g.NumVertices)
 	for i := range s.VertPositions {
-		s.VertPositions[i] = VertexPos{X: rand.Float64() * width, Y: rand.Float64() * height}
+		s.VertPositions[i] = VertexPos{X: rng.Float64() * width, Y: rng.Float64() * height}
 	}
 	return s
 }
@@ -50,13 +53,14 @@ func (s *GraphPlaneSolution) Objectives() []float64 {
 	dispersion *= (intersections + 1.0)
 	angle *= (intersections + 1.0)
 	s.CachedObjectives = []float64{intersections, dispersion, angle}
+	s.CachedFitness = intersections + dispersion + angle
 	return s.CachedObjectives
 }
 
-// Fitness for single-objective algorithms
+// Fitness for single-objective algorithms.
+// It only writes the cache on first evaluation, so an evaluated solution can be read concurrently.
 func (s *GraphPlaneSolution) Fitness() float64 {
-	o := s.Objectives()
-	s.CachedFitness = o[0] + o[1] + o[2]
+	s.Objectives()
 	return s.CachedFitness
 }
 
@@ -163,6 +167,8 @@ func (s *GraphPlaneSolution) TangledVertexes() []int {
 	for v := range isTangled {
 		vertexes = append(vertexes, v)
 	}
+	// sorted so that operators picking a random tangled vertex stay reproducible
+	slices.Sort(vertexes)
 
 	return vertexes
 }
@@ -179,3 +185,15 @@ func segmentsIntersect(a, b, c, d VertexPos) bool {
 	}
 	return ccw(a, c, d) != ccw(b, c, d) && ccw(a, b, c) != ccw(a, b, d)
 }
+
+// Distance returns the root-mean-square distance between the positions of the
+// same vertices in s and other.
+func (s *GraphPlaneSolution) Distance(other problems.Solution) float64 {
+	o := other.(*GraphPlaneSolution)
+	sum := 0.0
+	for i, p := range s.VertPositions {
+		dx, dy := p.X-o.VertPositions[i].X, p.Y-o.VertPositions[i].Y
+		sum += dx*dx + dy*dy
+	}
+	return math.Sqrt(sum / float64(max(len(s.VertPositions), 1)))
+}
@@ -7,10 +7,10 @@ type Item struct {
 	Resources []int `json:"resources"`
 }
 
-func NewRandomItem(params KnapsackProblemParams) Item {
+func NewRandomItem(rng *rand.Rand, params KnapsackProblemParams) Item {
 	resources := make([]int, params.Dimensions-1)
 	for i := range params.Dimensions - 1 {
-		resources[i] = 1 + rand.IntN(params.InitialMaxResource-1)
+		resources[i] = 1 + rng.IntN(params.InitialMaxResource-1)
 	}
-	return Item{Value: rand.IntN(params.InitialMaxValue), Resources: resources}
+	return Item{Value: rng.IntN(params.InitialMaxValue), Resources: resources}
 }
@@ -1,11 +1,27 @@
 package knapsack
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
 	"time"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	problems.RegisterProblem("Knapsack", func(data []byte) (problems.Problem, error) {
+		p := &KnapsackProblem{}
+		if err := json.Unmarshal(data, p); err != nil {
+			return nil, err
+		}
+		if len(p.Items) != p.Params.ItemsNum {
+			return nil, fmt.Errorf("knapsack problem lists %d items, expected %d", len(p.Items), p.Params.ItemsNum)
+		}
+		return p, nil
+	})
+}
+
 type KnapsackProblem struct {
 	Params KnapsackProblemParams `json:"parameters"`
 	Items  []Item                `json:"items"`
@@ -31,12 +47,13 @@ func (pp *KnapsackProblemParams) validate() {
 	}
 }
 
-func NewKnapsackProblem(params KnapsackProblemParams) problems.AlgorithmicProblem {
+// NewKnapsackProblem generates random items using rng.
+func NewKnapsackProblem(rng *rand.Rand, params KnapsackProblemParams) problems.AlgorithmicProblem {
 	params.validate()
 
 	items := make([]Item, 0, params.ItemsNum)
 	for range params.ItemsNum {
-		items = append(items, NewRandomItem(params))
+		items = append(items, NewRandomItem(rng, params))
 	}
 	return &KnapsackProblem{Params: params, Items: items}
 }
@@ -45,8 +62,21 @@ func (p *KnapsackProblem) Name() string {
 	return "Knapsack"
 }
 
-func (p *KnapsackProblem) RandomSolution() problems.Solution {
-	return RandomKnapsackSolution(p.Params, p.Items)
+func (p *KnapsackProblem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomKnapsackSolution(rng, p.Params, p.Items)
+}
+
+// DecodeSolution restores a solution of this problem, reattaching the items.
+func (p *KnapsackProblem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &KnapsackSolution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if len(s.Bits) != p.Params.ItemsNum {
+		return nil, fmt.Errorf("solution has %d bits, problem has %d items", len(s.Bits), p.Params.ItemsNum)
+	}
+	s.problemParams, s.items = p.Params, p.Items
+	return s, nil
 }
 
 func (p *KnapsackProblem) AlgorithmicSolution() problems.AlgorithmicSolution {
@@ -2,49 +2,54 @@ package knapsack
 
 import (
 	"math/rand/v2"
-	"slices"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+var (
+	_ problems.ConstrainedSolution = (*KnapsackSolution)(nil)
+	_ problems.MeasurableSolution  = (*KnapsackSolution)(nil)
+)
+
 type KnapsackSolution struct {
 	problemParams    KnapsackProblemParams
 	items            []Item
 	Bits             []bool    `json:"bits"`
 	CachedObjectives []float64 `json:"objectives"`
+	CachedViolations []float64 `json:"violations"`
 	CachedFitness    float64   `json:"fitness"`
 }
 
-func RandomKnapsackSolution(problemParams KnapsackProblemParams, items []Item) problems.Solution {
+func RandomKnapsackSolution(rng *rand.Rand, problemParams KnapsackProblemParams, items []Item) problems.Solution {
 	Bits := make([]bool, problemParams.ItemsNum)
 	for i := range problemParams.ItemsNum {
-		Bits[i] = rand.IntN(2) == 1
+		Bits[i] = rng.IntN(2) == 1
 	}
 	return &KnapsackSolution{problemParams: problemParams, items: items, Bits: Bits}
 }
 
 func CrossoverFunc() problems.CrossoverFunc {
-	return func(parentA, parentB problems.Solution) []problems.Solution {
+	return func(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
 		a, aOk := parentA.(*KnapsackSolution)
 		b, bOk := parentB.(*KnapsackSolution)
 		if !aOk || !bOk {
 			panic("invalid parents")
 		}
-		return a.crossover(b)
+		return a.crossover(rng, b)
 	}
 }
 
 func MutationFunc() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*KnapsackSolution)
 		if !ok {
 			panic("invalid individual")
 		}
-		return s.mutate()
+		return s.mutate(rng)
 	}
 }
 
-func (s *KnapsackSolution) crossover(other problems.Solution) []problems.Solution {
+func (s *KnapsackSolution) crossover(rng *rand.Rand, other problems.Solution) []problems.Solution {
 	otherKSS, ok := other.(*KnapsackSolution)
 	if !ok {
 		return []problems.Solution{s}
@@ -55,7 +60,7 @@ func (s *KnapsackSolution) crossover(other problems.Solution) []problems.Solutio
 
 	// uniform crossover
 	for i := range s.problemParams.ItemsNum {
-		if rand.Float64() < 0.5 {
+		if rng.Float64() < 0.5 {
 			child1Bits[i] = s.Bits[i]
 			child2Bits[i] = otherKSS.Bits[i]
 		} else {
@@ -70,23 +75,39 @@ func (s *KnapsackSolution) crossover(other problems.Solution) []problems.Solutio
 	}
 }
 
-func (s *KnapsackSolution) mutate() problems.Solution {
+func (s *KnapsackSolution) mutate(rng *rand.Rand) problems.Solution {
 	mutantBits := make([]bool, s.problemParams.ItemsNum)
 	copy(mutantBits, s.Bits)
 
 	for range max(s.problemParams.ItemsNum/100, 1) {
-		bitInd := rand.IntN(s.problemParams.ItemsNum)
+		bitInd := rng.IntN(s.problemParams.ItemsNum)
 		mutantBits[bitInd] = !s.Bits[bitInd]
 	}
 
 	return &KnapsackSolution{problemParams: s.problemParams, items: s.items, Bits: mutantBits}
 }
 
+// Objectives returns the single objective 1 / (1 + total value) of the selected
+// items, which decreases as the value grows and stays finite for an empty selection.
+// Exceeding the capacities does not change it, see Violations.
 func (s *KnapsackSolution) Objectives() []float64 {
-	if len(s.CachedObjectives) > 0 {
-		return s.CachedObjectives
+	if len(s.CachedObjectives) != 1 || len(s.CachedViolations) != s.problemParams.Dimensions-1 {
+		s.evaluate()
 	}
+	return s.CachedObjectives
+}
+
+// Violations returns by how much the selected items exceed every resource capacity.
+func (s *KnapsackSolution) Violations() []float64 {
+	s.Objectives()
+	return s.CachedViolations
+}
+
+func (s *KnapsackSolution) Fitness() float64 {
+	return s.Objectives()[0]
+}
 
+func (s *KnapsackSolution) evaluate() {
 	totalValue := 0
 	resources := make([]int, s.problemParams.Dimensions-1)
 	for i := range s.problemParams.ItemsNum {
@@ -98,19 +119,24 @@ func (s *KnapsackSolution) Objectives() []float64 {
 		}
 	}
 
-	objectives := make([]float64, s.problemParams.Dimensions)
-	objectives[0] = 1.0 / float64(totalValue)
-	for ri := range s.problemParams.Dimensions - 1 {
-		if resources[ri] > s.problemParams.Constraints[ri] {
-			objectives[ri+1] = 1.0
-		}
+	violations := make([]float64, s.problemParams.Dimensions-1)
+	for ri := range violations {
+		violations[ri] = float64(max(resources[ri]-s.problemParams.Constraints[ri], 0))
 	}
 
-	s.CachedObjectives = objectives
-	return s.CachedObjectives
+	s.CachedViolations = violations
+	s.CachedFitness = 1.0 / float64(1+totalValue)
+	s.CachedObjectives = []float64{s.CachedFitness}
 }
 
-func (s *KnapsackSolution) Fitness() float64 {
-	s.CachedFitness = slices.Max(s.Objectives())
-	return s.CachedFitness
+// Distance returns the Hamming distance between the item selections of s and other.
+func (s *KnapsackSolution) Distance(other problems.Solution) float64 {
+	o := other.(*KnapsackSolution)
+	d := 0
+	for i, bit := range s.Bits {
+		if bit != o.Bits[i] {
+			d++
+		}
+	}
+	return float64(d)
 }
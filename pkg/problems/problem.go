@@ -1,10 +1,21 @@
 package problems
 
-import "time"
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
 
 type Problem interface {
 	Name() string
-	RandomSolution() Solution
+	RandomSolution(rng *rand.Rand) Solution
+}
+
+// SolutionDecoder is implemented by problems whose solutions can be restored from
+// their JSON form. Solutions do not serialize the problem data they point to
+// (graph, items, cities), so the problem reattaches it while decoding.
+type SolutionDecoder interface {
+	DecodeSolution(data []byte) (Solution, error)
 }
 
 type AlgorithmicSolution struct {
@@ -17,8 +28,9 @@ type AlgorithmicProblem interface {
 	AlgorithmicSolution() AlgorithmicSolution
 }
 
-type CrossoverFunc func(parentA, parentB Solution) []Solution
-type MutationFunc func(individual Solution) Solution
+// Operators draw all randomness from rng so that a run can be replayed from its seed.
+type CrossoverFunc func(rng *rand.Rand, parentA, parentB Solution) []Solution
+type MutationFunc func(rng *rand.Rand, individual Solution) Solution
 
 type Solution interface {
 	// Multi-objective genetic algorithms (like NSGA-II, SPEA2) use Objectives() method
@@ -29,3 +41,86 @@ type Solution interface {
 	Objectives() []float64
 	Fitness() float64
 }
+
+// NewSource returns the PCG source used for a given seed throughout the library.
+func NewSource(seed uint64) *rand.PCG {
+	return rand.NewPCG(seed, seed)
+}
+
+// NewRand returns a generator seeded deterministically with seed.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(NewSource(seed))
+}
+
+// RealVector is implemented by solutions encoded as a bounded vector of reals.
+// Real-coded algorithms (CMA-ES, differential evolution) work on any such
+// solution without problem-specific operators.
+type RealVector interface {
+	Solution
+	// Vector returns the decision variables; callers must not modify it.
+	Vector() []float64
+	// Bounds returns the lower and upper bound of every variable.
+	Bounds() (lower, upper []float64)
+	// WithVector returns a new, not yet evaluated solution of the same problem
+	// with the decision variables x, which must lie within Bounds.
+	WithVector(x []float64) RealVector
+}
+
+// ConstrainedSolution is implemented by solutions of problems with constraints.
+// Fitness and Objectives describe the solution as if it were feasible; algorithms
+// supporting constraints (see algos.Constraints) weigh them against Violations.
+type ConstrainedSolution interface {
+	Solution
+	// Violations returns by how much every constraint is violated, 0 if it is satisfied.
+	Violations() []float64
+}
+
+// Violation returns the total constraint violation of sol, which is 0 for
+// feasible and unconstrained solutions.
+func Violation(sol Solution) float64 {
+	constrained, ok := sol.(ConstrainedSolution)
+	if !ok {
+		return 0
+	}
+	total := 0.0
+	for _, v := range constrained.Violations() {
+		total += max(v, 0)
+	}
+	return total
+}
+
+// Feasible reports whether sol satisfies all its constraints.
+func Feasible(sol Solution) bool {
+	return Violation(sol) == 0
+}
+
+// MeasurableSolution is implemented by solutions that can measure how far they
+// are from another solution of the same problem, in terms of their encoding.
+// Diversity preservation (niching, duplicate elimination) relies on it.
+type MeasurableSolution interface {
+	Solution
+	// Distance returns a non-negative distance to other, 0 if they are equal.
+	Distance(other Solution) float64
+}
+
+// Distance returns the distance between two solutions of the same problem: the
+// one the solutions measure themselves if they are MeasurableSolutions, the
+// Euclidean distance between their variables if they are RealVectors, and the
+// Euclidean distance between their objectives otherwise.
+func Distance(a, b Solution) float64 {
+	if m, ok := a.(MeasurableSolution); ok {
+		return m.Distance(b)
+	}
+	var x, y []float64
+	if va, ok := a.(RealVector); ok {
+		x, y = va.Vector(), b.(RealVector).Vector()
+	} else {
+		x, y = a.Objectives(), b.Objectives()
+	}
+	sum := 0.0
+	for i := range x {
+		d := x[i] - y[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
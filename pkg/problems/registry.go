@@ -0,0 +1,74 @@
+package problems
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ProblemDecoder restores a problem from the JSON it was logged as.
+type ProblemDecoder func(data []byte) (Problem, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = make(map[string]ProblemDecoder)
+)
+
+// RegisterProblem makes problems named name decodable by DecodeProblem.
+// Problem packages call it from init.
+func RegisterProblem(name string, decoder ProblemDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	if decoder == nil {
+		panic("problems: RegisterProblem decoder is nil")
+	}
+	if _, dup := decoders[name]; dup {
+		panic("problems: RegisterProblem called twice for " + name)
+	}
+	decoders[name] = decoder
+}
+
+// DecodeProblem restores a problem of the registered kind name from data.
+func DecodeProblem(name string, data []byte) (Problem, error) {
+	decodersMu.RLock()
+	decoder, ok := decoders[name]
+	decodersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown problem %q (registered: %v)", name, RegisteredProblems())
+	}
+	return decoder(data)
+}
+
+// RegisteredProblems returns the sorted names of all decodable problems.
+func RegisteredProblems() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	names := make([]string, 0, len(decoders))
+	for name := range decoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MarshalProblem encodes p as JSON with an extra leading "name" key holding p.Name(),
+// which tells readers of a progress log how to decode the header.
+func MarshalProblem(p Problem) ([]byte, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return nil, fmt.Errorf("problem %s is not encoded as a JSON object", p.Name())
+	}
+	name, err := json.Marshal(p.Name())
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte(`{"name":`), name...)
+	if string(data) != "{}" {
+		header = append(header, ',')
+	}
+	return append(header, data[1:]...), nil
+}
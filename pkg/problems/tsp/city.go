@@ -10,10 +10,10 @@ type City struct {
 	Longitude float64 `json:"lon"`
 }
 
-func NewRandomCity() City {
+func NewRandomCity(rng *rand.Rand) City {
 	return City{
-		Latitude:  rand.Float64() * 100,
-		Longitude: rand.Float64() * 100,
+		Latitude:  rng.Float64() * 100,
+		Longitude: rng.Float64() * 100,
 	}
 }
 
@@ -1,13 +1,29 @@
 package tsp
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
 	"math"
+	"math/rand/v2"
 	"time"
 
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	problems.RegisterProblem("TSP", func(data []byte) (problems.Problem, error) {
+		p := &TSProblem{}
+		if err := json.Unmarshal(data, p); err != nil {
+			return nil, err
+		}
+		if len(p.Cities) != p.Params.CitiesNum {
+			return nil, fmt.Errorf("TSP problem lists %d cities, expected %d", len(p.Cities), p.Params.CitiesNum)
+		}
+		return p, nil
+	})
+}
+
 type TSProblem struct {
 	Params TSProblemParameters `json:"parameters"`
 	Cities []City              `json:"cities"`
@@ -23,12 +39,13 @@ func (p *TSProblemParameters) validate() {
 	}
 }
 
-func NewTSProblem(params TSProblemParameters) problems.AlgorithmicProblem {
+// NewTSProblem generates random cities using rng.
+func NewTSProblem(rng *rand.Rand, params TSProblemParameters) problems.AlgorithmicProblem {
 	params.validate()
 
 	cities := make([]City, 0, params.CitiesNum)
 	for range params.CitiesNum {
-		cities = append(cities, NewRandomCity())
+		cities = append(cities, NewRandomCity(rng))
 	}
 	return &TSProblem{Params: params, Cities: cities}
 }
@@ -37,8 +54,21 @@ func (p *TSProblem) Name() string {
 	return "TSP"
 }
 
-func (p *TSProblem) RandomSolution() problems.Solution {
-	return RandomTSPSolution(p.Params, p.Cities)
+func (p *TSProblem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomTSPSolution(rng, p.Params, p.Cities)
+}
+
+// DecodeSolution restores a solution of this problem, reattaching the cities.
+func (p *TSProblem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &TSPSolution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if len(s.VisitingOrder) != p.Params.CitiesNum-1 {
+		return nil, fmt.Errorf("solution visits %d cities, problem has %d", len(s.VisitingOrder)+1, p.Params.CitiesNum)
+	}
+	s.problemParams, s.cities = p.Params, p.Cities
+	return s, nil
 }
 
 // Branch and bound
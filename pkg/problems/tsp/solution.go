@@ -6,6 +6,8 @@ import (
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+var _ problems.MeasurableSolution = (*TSPSolution)(nil)
+
 type TSPSolution struct {
 	problemParams TSProblemParameters
 	cities        []City
@@ -13,26 +15,48 @@ type TSPSolution struct {
 	CachedFitness float64 `json:"fitness"`
 }
 
-func RandomTSPSolution(problemParams TSProblemParameters, cities []City) problems.Solution {
-	order := rand.Perm(problemParams.CitiesNum - 1)
+func RandomTSPSolution(rng *rand.Rand, problemParams TSProblemParameters, cities []City) problems.Solution {
+	order := rng.Perm(problemParams.CitiesNum - 1)
 	for i := range problemParams.CitiesNum - 1 {
 		order[i]++
 	}
 	return &TSPSolution{problemParams: problemParams, cities: cities, VisitingOrder: order}
 }
 
-func (s *TSPSolution) Mutate() problems.Solution {
+// CrossoverFunc adapts Crossover to the algorithms' operator interface.
+func CrossoverFunc() problems.CrossoverFunc {
+	return func(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
+		a, ok := parentA.(*TSPSolution)
+		if !ok {
+			panic("invalid parents")
+		}
+		return a.Crossover(rng, parentB)
+	}
+}
+
+// MutationFunc adapts Mutate (a swap of two cities) to the algorithms' operator interface.
+func MutationFunc() problems.MutationFunc {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
+		s, ok := individual.(*TSPSolution)
+		if !ok {
+			panic("invalid individual")
+		}
+		return s.Mutate(rng)
+	}
+}
+
+func (s *TSPSolution) Mutate(rng *rand.Rand) problems.Solution {
 	newOrder := make([]int, s.problemParams.CitiesNum-1)
 	copy(newOrder, s.VisitingOrder)
 
-	i := rand.IntN(s.problemParams.CitiesNum - 1)
-	j := rand.IntN(s.problemParams.CitiesNum - 1)
+	i := rng.IntN(s.problemParams.CitiesNum - 1)
+	j := rng.IntN(s.problemParams.CitiesNum - 1)
 	newOrder[i], newOrder[j] = newOrder[j], newOrder[i]
 
 	return &TSPSolution{problemParams: s.problemParams, cities: s.cities, VisitingOrder: newOrder}
 }
 
-func (s *TSPSolution) Crossover(other problems.Solution) []problems.Solution {
+func (s *TSPSolution) Crossover(rng *rand.Rand, other problems.Solution) []problems.Solution {
 	otherTSS, ok := other.(*TSPSolution)
 	if !ok || s.problemParams.CitiesNum != otherTSS.problemParams.CitiesNum {
 		return []problems.Solution{s}
@@ -43,7 +67,7 @@ func (s *TSPSolution) Crossover(other problems.Solution) []problems.Solution {
 	set1 := make(map[int]bool, s.problemParams.CitiesNum-1)
 	set2 := make(map[int]bool, s.problemParams.CitiesNum-1)
 	for i := range s.problemParams.CitiesNum - 1 {
-		if rand.IntN(2) == 1 {
+		if rng.IntN(2) == 1 {
 			order1[i] = s.VisitingOrder[i]
 			set1[s.VisitingOrder[i]] = true
 		} else {
@@ -102,3 +126,31 @@ func (s *TSPSolution) Fitness() float64 {
 func (s *TSPSolution) Objectives() []float64 {
 	return []float64{s.Fitness()}
 }
+
+// Distance returns the number of edges of the tour of s that the tour of other
+// does not use, in either direction.
+func (s *TSPSolution) Distance(other problems.Solution) float64 {
+	edges := make(map[[2]int]bool, len(s.VisitingOrder)+1)
+	for _, e := range tourEdges(other.(*TSPSolution).VisitingOrder) {
+		edges[e] = true
+	}
+	d := 0
+	for _, e := range tourEdges(s.VisitingOrder) {
+		if !edges[e] {
+			d++
+		}
+	}
+	return float64(d)
+}
+
+// tourEdges returns the undirected edges of the tour starting and ending at
+// city 0, each with the smaller city first.
+func tourEdges(order []int) [][2]int {
+	edges := make([][2]int, 0, len(order)+1)
+	prev := 0
+	for _, city := range order {
+		edges = append(edges, [2]int{min(prev, city), max(prev, city)})
+		prev = city
+	}
+	return append(edges, [2]int{0, prev})
+}
@@ -0,0 +1,85 @@
+package zdt
+
+import (
+	"math"
+)
+
+// Analytical Pareto fronts of the ZDT problems, sampled at points evenly spaced in f1.
+// They serve as reference fronts for quality indicators such as IGD.
+
+// ZDT1Front samples f2 = 1 - √f1, f1 ∈ [0, 1].
+func ZDT1Front(points int) [][]float64 {
+	return sampleFront(points, [][2]float64{{0, 1}}, func(f1 float64) float64 {
+		return 1 - math.Sqrt(f1)
+	})
+}
+
+// ZDT2Front samples f2 = 1 - f1², f1 ∈ [0, 1].
+func ZDT2Front(points int) [][]float64 {
+	return sampleFront(points, [][2]float64{{0, 1}}, func(f1 float64) float64 {
+		return 1 - f1*f1
+	})
+}
+
+// zdt3Segments are the f1 intervals of the disconnected ZDT3 front.
+var zdt3Segments = [][2]float64{
+	{0, 0.0830015349},
+	{0.1822287280, 0.2577623634},
+	{0.4093136748, 0.4538821041},
+	{0.6183967944, 0.6525117038},
+	{0.8233317983, 0.8518328654},
+}
+
+// ZDT3Front samples f2 = 1 - √f1 - f1·sin(10πf1) on its five disconnected segments.
+func ZDT3Front(points int) [][]float64 {
+	return sampleFront(points, zdt3Segments, func(f1 float64) float64 {
+		return 1 - math.Sqrt(f1) - f1*math.Sin(10*math.Pi*f1)
+	})
+}
+
+// ZDT4Front is the ZDT1 front; ZDT4 only differs by its many local fronts.
+func ZDT4Front(points int) [][]float64 {
+	return ZDT1Front(points)
+}
+
+// ZDT5Front returns the 31 points f2 = 10/f1, f1 ∈ {1, …, 31}, of the binary ZDT5
+// with the standard 11 variables. The problem itself is not part of this package.
+func ZDT5Front() [][]float64 {
+	front := make([][]float64, 0, 31)
+	for f1 := 1; f1 <= 31; f1++ {
+		front = append(front, []float64{float64(f1), 10 / float64(f1)})
+	}
+	return front
+}
+
+// ZDT6Front samples f2 = 1 - f1², f1 ∈ [0.2807753191, 1].
+func ZDT6Front(points int) [][]float64 {
+	return sampleFront(points, [][2]float64{{0.2807753191, 1}}, func(f1 float64) float64 {
+		return 1 - f1*f1
+	})
+}
+
+// sampleFront spreads points over the segments in proportion to their lengths.
+func sampleFront(points int, segments [][2]float64, f2 func(f1 float64) float64) [][]float64 {
+	total := 0.0
+	for _, s := range segments {
+		total += s[1] - s[0]
+	}
+	front := make([][]float64, 0, points)
+	remaining := points
+	for i, s := range segments {
+		n := int(math.Round(float64(points) * (s[1] - s[0]) / total))
+		if i == len(segments)-1 || n > remaining {
+			n = remaining
+		}
+		remaining -= n
+		for j := range n {
+			f1 := s[0]
+			if n > 1 {
+				f1 += (s[1] - s[0]) * float64(j) / float64(n-1)
+			}
+			front = append(front, []float64{f1, f2(f1)})
+		}
+	}
+	return front
+}
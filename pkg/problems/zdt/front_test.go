@@ -0,0 +1,64 @@
+package zdt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFronts(t *testing.T) {
+	tests := []struct {
+		name  string
+		front [][]float64
+		f2    func(f1 float64) float64
+		first float64 // f1 of the first point
+	}{
+		{"ZDT1", ZDT1Front(11), func(f1 float64) float64 { return 1 - math.Sqrt(f1) }, 0},
+		{"ZDT2", ZDT2Front(11), func(f1 float64) float64 { return 1 - f1*f1 }, 0},
+		{"ZDT3", ZDT3Front(100), func(f1 float64) float64 { return 1 - math.Sqrt(f1) - f1*math.Sin(10*math.Pi*f1) }, 0},
+		{"ZDT4", ZDT4Front(11), func(f1 float64) float64 { return 1 - math.Sqrt(f1) }, 0},
+		{"ZDT6", ZDT6Front(11), func(f1 float64) float64 { return 1 - f1*f1 }, 0.2807753191},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.front[0][0] != tt.first || tt.front[len(tt.front)-1][0] > 1 {
+				t.Errorf("front spans f1 from %v to %v", tt.front[0][0], tt.front[len(tt.front)-1][0])
+			}
+			for _, p := range tt.front {
+				if math.Abs(p[1]-tt.f2(p[0])) > 1e-12 {
+					t.Fatalf("point %v is off the front", p)
+				}
+			}
+		})
+	}
+}
+
+func TestFrontSizes(t *testing.T) {
+	tests := []struct {
+		name  string
+		front [][]float64
+		want  int
+	}{
+		{"ZDT1", ZDT1Front(100), 100},
+		{"ZDT1 single point", ZDT1Front(1), 1},
+		{"ZDT3 split over segments", ZDT3Front(100), 100},
+		{"ZDT3 fewer points than segments", ZDT3Front(3), 3},
+		{"ZDT5", ZDT5Front(), 31},
+	}
+	for _, tt := range tests {
+		if len(tt.front) != tt.want {
+			t.Errorf("%s: %d points, want %d", tt.name, len(tt.front), tt.want)
+		}
+	}
+}
+
+func TestZDT3FrontSegments(t *testing.T) {
+	for _, p := range ZDT3Front(200) {
+		inside := false
+		for _, s := range zdt3Segments {
+			inside = inside || (p[0] >= s[0] && p[0] <= s[1])
+		}
+		if !inside {
+			t.Fatalf("f1 = %v lies between the segments", p[0])
+		}
+	}
+}
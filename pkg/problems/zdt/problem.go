@@ -1,9 +1,35 @@
 package zdt
 
 import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+
 	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
 )
 
+func init() {
+	register := func(name string, problem func(dimensions int) problems.Problem) {
+		problems.RegisterProblem(name, func(data []byte) (problems.Problem, error) {
+			var header struct {
+				Dimensions int `json:"dimensions"`
+			}
+			if err := json.Unmarshal(data, &header); err != nil {
+				return nil, err
+			}
+			if header.Dimensions < 2 {
+				return nil, fmt.Errorf("%s problem has %d dimensions", name, header.Dimensions)
+			}
+			return problem(header.Dimensions), nil
+		})
+	}
+	register("ZDT1", NewZDT1Problem)
+	register("ZDT2", NewZDT2Problem)
+	register("ZDT3", NewZDT3Problem)
+	register("ZDT4", NewZDT4Problem)
+	register("ZDT6", NewZDT6Problem)
+}
+
 // --------------------
 // ZDT1 Problem
 // --------------------
@@ -23,8 +49,19 @@ func (p *ZDT1Problem) Name() string {
 	return "ZDT1"
 }
 
-func (p *ZDT1Problem) RandomSolution() problems.Solution {
-	return RandomZDT1Solution(p.Dimensions)
+func (p *ZDT1Problem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomZDT1Solution(rng, p.Dimensions)
+}
+
+func (p *ZDT1Problem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &ZDT1Solution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(len(s.X), p.Dimensions); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // --------------------
@@ -46,8 +83,19 @@ func (p *ZDT2Problem) Name() string {
 	return "ZDT2"
 }
 
-func (p *ZDT2Problem) RandomSolution() problems.Solution {
-	return RandomZDT2Solution(p.Dimensions)
+func (p *ZDT2Problem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomZDT2Solution(rng, p.Dimensions)
+}
+
+func (p *ZDT2Problem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &ZDT2Solution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(len(s.X), p.Dimensions); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // --------------------
@@ -69,8 +117,19 @@ func (p *ZDT3Problem) Name() string {
 	return "ZDT3"
 }
 
-func (p *ZDT3Problem) RandomSolution() problems.Solution {
-	return RandomZDT3Solution(p.Dimensions)
+func (p *ZDT3Problem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomZDT3Solution(rng, p.Dimensions)
+}
+
+func (p *ZDT3Problem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &ZDT3Solution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(len(s.X), p.Dimensions); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // --------------------
@@ -93,8 +152,19 @@ func (p *ZDT4Problem) Name() string {
 	return "ZDT4"
 }
 
-func (p *ZDT4Problem) RandomSolution() problems.Solution {
-	return RandomZDT4Solution(p.Dimensions)
+func (p *ZDT4Problem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomZDT4Solution(rng, p.Dimensions)
+}
+
+func (p *ZDT4Problem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &ZDT4Solution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(len(s.X), p.Dimensions); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // --------------------
@@ -116,6 +186,25 @@ func (p *ZDT6Problem) Name() string {
 	return "ZDT6"
 }
 
-func (p *ZDT6Problem) RandomSolution() problems.Solution {
-	return RandomZDT6Solution(p.Dimensions)
+func (p *ZDT6Problem) RandomSolution(rng *rand.Rand) problems.Solution {
+	return RandomZDT6Solution(rng, p.Dimensions)
+}
+
+func (p *ZDT6Problem) DecodeSolution(data []byte) (problems.Solution, error) {
+	s := &ZDT6Solution{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(len(s.X), p.Dimensions); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkDimensions validates the size of a decoded decision vector.
+func checkDimensions(got, want int) error {
+	if got != want {
+		return fmt.Errorf("solution has %d decision variables, problem has %d", got, want)
+	}
+	return nil
 }
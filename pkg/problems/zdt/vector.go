@@ -0,0 +1,59 @@
+package zdt
+
+import "github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+
+var (
+	_ problems.RealVector = (*ZDT1Solution)(nil)
+	_ problems.RealVector = (*ZDT2Solution)(nil)
+	_ problems.RealVector = (*ZDT3Solution)(nil)
+	_ problems.RealVector = (*ZDT4Solution)(nil)
+	_ problems.RealVector = (*ZDT6Solution)(nil)
+)
+
+func (s *ZDT1Solution) Vector() []float64                { return s.X }
+func (s *ZDT1Solution) Bounds() (lower, upper []float64) { return unitBounds(s.Dimensions) }
+func (s *ZDT1Solution) WithVector(x []float64) problems.RealVector {
+	return &ZDT1Solution{Dimensions: len(x), X: x}
+}
+
+func (s *ZDT2Solution) Vector() []float64                { return s.X }
+func (s *ZDT2Solution) Bounds() (lower, upper []float64) { return unitBounds(s.Dimensions) }
+func (s *ZDT2Solution) WithVector(x []float64) problems.RealVector {
+	return &ZDT2Solution{Dimensions: len(x), X: x}
+}
+
+func (s *ZDT3Solution) Vector() []float64                { return s.X }
+func (s *ZDT3Solution) Bounds() (lower, upper []float64) { return unitBounds(s.Dimensions) }
+func (s *ZDT3Solution) WithVector(x []float64) problems.RealVector {
+	return &ZDT3Solution{Dimensions: len(x), X: x}
+}
+
+func (s *ZDT4Solution) Vector() []float64 { return s.X }
+
+// Bounds of ZDT4: x₀ in [0,1], the other variables in [-5,5].
+func (s *ZDT4Solution) Bounds() (lower, upper []float64) {
+	lower, upper = make([]float64, s.Dimensions), make([]float64, s.Dimensions)
+	upper[0] = 1
+	for i := 1; i < s.Dimensions; i++ {
+		lower[i], upper[i] = -5, 5
+	}
+	return lower, upper
+}
+
+func (s *ZDT4Solution) WithVector(x []float64) problems.RealVector {
+	return &ZDT4Solution{Dimensions: len(x), X: x}
+}
+
+func (s *ZDT6Solution) Vector() []float64                { return s.X }
+func (s *ZDT6Solution) Bounds() (lower, upper []float64) { return unitBounds(s.Dimensions) }
+func (s *ZDT6Solution) WithVector(x []float64) problems.RealVector {
+	return &ZDT6Solution{Dimensions: len(x), X: x}
+}
+
+func unitBounds(dimensions int) (lower, upper []float64) {
+	lower, upper = make([]float64, dimensions), make([]float64, dimensions)
+	for i := range upper {
+		upper[i] = 1
+	}
+	return lower, upper
+}
@@ -15,10 +15,10 @@ type ZDT1Solution struct {
 }
 
 // RandomZDT1Solution returns a new random solution for a given dimensionality.
-func RandomZDT1Solution(dimensions int) problems.Solution {
+func RandomZDT1Solution(rng *rand.Rand, dimensions int) problems.Solution {
 	x := make([]float64, dimensions)
 	for i := range x {
-		x[i] = rand.Float64() // uniformly in [0,1]
+		x[i] = rng.Float64() // uniformly in [0,1]
 	}
 	return &ZDT1Solution{
 		Dimensions: dimensions,
@@ -53,28 +53,28 @@ func (s *ZDT1Solution) Fitness() float64 {
 }
 
 func ZDT1CrossoverFunc() problems.CrossoverFunc {
-	return func(parentA, parentB problems.Solution) []problems.Solution {
+	return func(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
 		a, aOk := parentA.(*ZDT1Solution)
 		b, bOk := parentB.(*ZDT1Solution)
 		if !aOk || !bOk {
 			panic("invalid parents")
 		}
-		return a.crossover(b)
+		return a.crossover(rng, b)
 	}
 }
 
 func ZDT1MutationFunc() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*ZDT1Solution)
 		if !ok {
 			panic("invalid individual")
 		}
-		return s.mutate()
+		return s.mutate(rng)
 	}
 }
 
 // Crossover applies a uniform crossover between two ZDT solutions and returns two offspring.
-func (s *ZDT1Solution) crossover(other problems.Solution) []problems.Solution {
+func (s *ZDT1Solution) crossover(rng *rand.Rand, other problems.Solution) []problems.Solution {
 	otherZDT, ok := other.(*ZDT1Solution)
 	if !ok || s.Dimensions != otherZDT.Dimensions {
 		return []problems.Solution{s}
@@ -83,7 +83,7 @@ func (s *ZDT1Solution) crossover(other problems.Solution) []problems.Solution {
 	child1X := make([]float64, s.Dimensions)
 	child2X := make([]float64, s.Dimensions)
 	for i := range s.Dimensions {
-		if rand.Float64() < 0.5 {
+		if rng.Float64() < 0.5 {
 			child1X[i] = s.X[i]
 			child2X[i] = otherZDT.X[i]
 		} else {
@@ -98,12 +98,12 @@ func (s *ZDT1Solution) crossover(other problems.Solution) []problems.Solution {
 
 // mutate applies mutation by perturbing each decision variable with a small probability.
 // The mutated value is clamped to remain within [0, 1].
-func (s *ZDT1Solution) mutate() problems.Solution {
+func (s *ZDT1Solution) mutate(rng *rand.Rand) problems.Solution {
 	mutantX := make([]float64, s.Dimensions)
 	copy(mutantX, s.X)
 	for i := range s.Dimensions {
 		// Add a normally distributed perturbation (scale factor is arbitrary).
-		delta := rand.NormFloat64() * 0.1
+		delta := rng.NormFloat64() * 0.1
 		mutantX[i] += delta
 		// Clamp to [0, 1].
 		if mutantX[i] < 0 {
@@ -17,15 +17,15 @@ type ZDT4Solution struct {
 	CachedObjectives []float64 `json:"objectives"`
 }
 
-func RandomZDT4Solution(dimensions int) problems.Solution {
+func RandomZDT4Solution(rng *rand.Rand, dimensions int) problems.Solution {
 	if dimensions < 2 {
 		panic("ZDT4 requires at least 2 dimensions")
 	}
 	x := make([]float64, dimensions)
-	x[0] = rand.Float64() // in [0,1]
+	x[0] = rng.Float64() // in [0,1]
 	for i := 1; i < dimensions; i++ {
 		// For ZDT4, remaining variables are in [-5,5]
-		x[i] = -5 + rand.Float64()*10
+		x[i] = -5 + rng.Float64()*10
 	}
 	return &ZDT4Solution{Dimensions: dimensions, X: x}
 }
@@ -52,27 +52,27 @@ func (s *ZDT4Solution) Fitness() float64 {
 }
 
 func ZDT4CrossoverFunc() problems.CrossoverFunc {
-	return func(parentA, parentB problems.Solution) []problems.Solution {
+	return func(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
 		a, aOk := parentA.(*ZDT4Solution)
 		b, bOk := parentB.(*ZDT4Solution)
 		if !aOk || !bOk {
 			panic("invalid parents")
 		}
-		return a.crossover(b)
+		return a.crossover(rng, b)
 	}
 }
 
 func ZDT4MutationFunc() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*ZDT4Solution)
 		if !ok {
 			panic("invalid individual")
 		}
-		return s.mutate()
+		return s.mutate(rng)
 	}
 }
 
-func (s *ZDT4Solution) crossover(other problems.Solution) []problems.Solution {
+func (s *ZDT4Solution) crossover(rng *rand.Rand, other problems.Solution) []problems.Solution {
 	otherSol, ok := other.(*ZDT4Solution)
 	if !ok || s.Dimensions != otherSol.Dimensions {
 		return []problems.Solution{s}
@@ -80,7 +80,7 @@ func (s *ZDT4Solution) crossover(other problems.Solution) []problems.Solution {
 	child1 := make([]float64, s.Dimensions)
 	child2 := make([]float64, s.Dimensions)
 	for i := range s.Dimensions {
-		if rand.Float64() < 0.5 {
+		if rng.Float64() < 0.5 {
 			child1[i] = s.X[i]
 			child2[i] = otherSol.X[i]
 		} else {
@@ -94,11 +94,11 @@ func (s *ZDT4Solution) crossover(other problems.Solution) []problems.Solution {
 	}
 }
 
-func (s *ZDT4Solution) mutate() problems.Solution {
+func (s *ZDT4Solution) mutate(rng *rand.Rand) problems.Solution {
 	mutant := make([]float64, s.Dimensions)
 	copy(mutant, s.X)
 	// Mutate first variable (in [0,1])
-	delta := rand.NormFloat64() * 0.1
+	delta := rng.NormFloat64() * 0.1
 	mutant[0] += delta
 	if mutant[0] < 0 {
 		mutant[0] = 0
@@ -108,7 +108,7 @@ func (s *ZDT4Solution) mutate() problems.Solution {
 	}
 	// Mutate remaining variables (in [-5,5])
 	for i := 1; i < s.Dimensions; i++ {
-		delta := rand.NormFloat64() * 0.1 * 10
+		delta := rng.NormFloat64() * 0.1 * 10
 		mutant[i] += delta
 		if mutant[i] < -5 {
 			mutant[i] = -5
@@ -17,10 +17,10 @@ type ZDT6Solution struct {
 	CachedObjectives []float64 `json:"objectives"`
 }
 
-func RandomZDT6Solution(dimensions int) problems.Solution {
+func RandomZDT6Solution(rng *rand.Rand, dimensions int) problems.Solution {
 	x := make([]float64, dimensions)
 	for i := range x {
-		x[i] = rand.Float64()
+		x[i] = rng.Float64()
 	}
 	return &ZDT6Solution{Dimensions: dimensions, X: x}
 }
@@ -48,27 +48,27 @@ func (s *ZDT6Solution) Fitness() float64 {
 }
 
 func ZDT6CrossoverFunc() problems.CrossoverFunc {
-	return func(parentA, parentB problems.Solution) []problems.Solution {
+	return func(rng *rand.Rand, parentA, parentB problems.Solution) []problems.Solution {
 		a, aOk := parentA.(*ZDT6Solution)
 		b, bOk := parentB.(*ZDT6Solution)
 		if !aOk || !bOk {
 			panic("invalid parents")
 		}
-		return a.crossover(b)
+		return a.crossover(rng, b)
 	}
 }
 
 func ZDT6MutationFunc() problems.MutationFunc {
-	return func(individual problems.Solution) problems.Solution {
+	return func(rng *rand.Rand, individual problems.Solution) problems.Solution {
 		s, ok := individual.(*ZDT6Solution)
 		if !ok {
 			panic("invalid individual")
 		}
-		return s.mutate()
+		return s.mutate(rng)
 	}
 }
 
-func (s *ZDT6Solution) crossover(other problems.Solution) []problems.Solution {
+func (s *ZDT6Solution) crossover(rng *rand.Rand, other problems.Solution) []problems.Solution {
 	otherSol, ok := other.(*ZDT6Solution)
 	if !ok || s.Dimensions != otherSol.Dimensions {
 		return []problems.Solution{s}
@@ -76,7 +76,7 @@ func (s *ZDT6Solution) crossover(other problems.Solution) []problems.Solution {
 	child1 := make([]float64, s.Dimensions)
 	child2 := make([]float64, s.Dimensions)
 	for i := range s.Dimensions {
-		if rand.Float64() < 0.5 {
+		if rng.Float64() < 0.5 {
 			child1[i] = s.X[i]
 			child2[i] = otherSol.X[i]
 		} else {
@@ -90,11 +90,11 @@ func (s *ZDT6Solution) crossover(other problems.Solution) []problems.Solution {
 	}
 }
 
-func (s *ZDT6Solution) mutate() problems.Solution {
+func (s *ZDT6Solution) mutate(rng *rand.Rand) problems.Solution {
 	mutant := make([]float64, s.Dimensions)
 	copy(mutant, s.X)
 	for i := range s.Dimensions {
-		delta := rand.NormFloat64() * 0.1
+		delta := rng.NormFloat64() * 0.1
 		mutant[i] += delta
 		if mutant[i] < 0 {
 			mutant[i] = 0
@@ -0,0 +1,218 @@
+// Package replay reads progress logs written by algos.ProgressLogger back into
+// a typed problem and a stream of steps with fully usable solutions.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/algos"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+)
+
+// maxLineSize bounds a single log record; Pareto fronts of large populations make long lines.
+const maxLineSize = 64 << 20
+
+// Step is a logged algos.GAStep with its solution rehydrated.
+type Step struct {
+	algos.GAStep
+	// Stage names the pipeline stage that logged the step, if any.
+	Stage string
+	// Line is the 1-based line of the record in the log.
+	Line int
+}
+
+type rawStep struct {
+	Elapsed     time.Duration         `json:"elapsed"`
+	Step        int                   `json:"step"`
+	Seed        uint64                `json:"seed"`
+	Solution    json.RawMessage       `json:"solution"`
+	ParetoFront [][]float64           `json:"pareto_front"`
+	StopReason  string                `json:"stop_reason"`
+	Indicators  map[string]float64    `json:"indicators"`
+	Operators   []algos.OperatorStats `json:"operators"`
+	Stage       string                `json:"stage"`
+}
+
+// Reader parses a log record by record.
+type Reader struct {
+	// Problem is the decoded log header.
+	Problem problems.Problem
+	decoder problems.SolutionDecoder
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewReader reads the log header and decodes the problem by the name stored in it.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := newReader(r)
+	header, err := reader.next()
+	if err != nil {
+		return nil, fmt.Errorf("reading log header: %w", err)
+	}
+	var named struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(header, &named); err != nil {
+		return nil, fmt.Errorf("reading log header: %w", err)
+	}
+	if named.Name == "" {
+		return nil, errors.New("log header has no problem name, use NewReaderFor")
+	}
+	problem, err := problems.DecodeProblem(named.Name, header)
+	if err != nil {
+		return nil, err
+	}
+	return reader, reader.setProblem(problem)
+}
+
+// NewReaderFor reads a log of the given problem, e.g. one written before headers
+// carried the problem name. The header line is skipped.
+func NewReaderFor(r io.Reader, problem problems.Problem) (*Reader, error) {
+	reader := newReader(r)
+	if _, err := reader.next(); err != nil {
+		return nil, fmt.Errorf("reading log header: %w", err)
+	}
+	return reader, reader.setProblem(problem)
+}
+
+func newReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineSize)
+	return &Reader{scanner: scanner}
+}
+
+func (r *Reader) setProblem(problem problems.Problem) error {
+	decoder, ok := problem.(problems.SolutionDecoder)
+	if !ok {
+		return fmt.Errorf("problem %s cannot decode solutions", problem.Name())
+	}
+	r.Problem, r.decoder = problem, decoder
+	return nil
+}
+
+func (r *Reader) next() ([]byte, error) {
+	for r.scanner.Scan() {
+		r.line++
+		if len(r.scanner.Bytes()) > 0 {
+			return r.scanner.Bytes(), nil
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Next returns the next step, or io.EOF after the last one.
+func (r *Reader) Next() (Step, error) {
+	data, err := r.next()
+	if err != nil {
+		return Step{}, err
+	}
+	var raw rawStep
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Step{}, fmt.Errorf("line %d: %w", r.line, err)
+	}
+	step := Step{
+		GAStep: algos.GAStep{
+			Elapsed:     raw.Elapsed,
+			Step:        raw.Step,
+			Seed:        raw.Seed,
+			ParetoFront: raw.ParetoFront,
+			StopReason:  raw.StopReason,
+			Indicators:  raw.Indicators,
+			Operators:   raw.Operators,
+		},
+		Stage: raw.Stage,
+		Line:  r.line,
+	}
+	if len(raw.Solution) > 0 && string(raw.Solution) != "null" {
+		if step.Solution, err = r.decoder.DecodeSolution(raw.Solution); err != nil {
+			return Step{}, fmt.Errorf("line %d: %w", r.line, err)
+		}
+	}
+	return step, nil
+}
+
+// Log is a fully read progress log.
+type Log struct {
+	Problem problems.Problem
+	Steps   []Step
+}
+
+// Read reads every step of a log.
+func Read(r io.Reader) (*Log, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return reader.ReadAll()
+}
+
+// ReadFile reads the log at path.
+func ReadFile(path string) (*Log, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// ReadAll reads the remaining steps.
+func (r *Reader) ReadAll() (*Log, error) {
+	log := &Log{Problem: r.Problem}
+	for {
+		step, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return log, nil
+		}
+		if err != nil {
+			return log, err
+		}
+		log.Steps = append(log.Steps, step)
+	}
+}
+
+// Stage returns the steps logged by the named pipeline stage.
+func (l *Log) Stage(name string) []Step {
+	var steps []Step
+	for _, s := range l.Steps {
+		if s.Stage == name {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// At returns the last step logged at or before generation, which is the state
+// of the run at that generation since algorithms only log changes.
+// Steps of pipeline logs restart with every stage, so filter them with Stage first.
+func At(steps []Step, generation int) (Step, bool) {
+	var found Step
+	ok := false
+	for _, s := range steps {
+		if s.Step > generation {
+			break
+		}
+		found, ok = s, true
+	}
+	return found, ok
+}
+
+// Resume seeds alg with the best solution logged at or before generation,
+// so a run can be continued from any point of its log.
+func Resume(alg algos.Algorithm, steps []Step, generation int) error {
+	step, ok := At(steps, generation)
+	if !ok || step.Solution == nil {
+		return fmt.Errorf("no solution logged up to generation %d", generation)
+	}
+	alg.Seed(step.Solution)
+	return nil
+}
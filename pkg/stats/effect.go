@@ -0,0 +1,40 @@
+package stats
+
+// A12 is the Vargha–Delaney effect size: the probability that a value drawn
+// from a is greater than one drawn from b, counting ties as one half.
+// For minimized fitness, A12 < 0.5 means a tends to be better.
+func A12(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0.5
+	}
+	wins := 0.0
+	for _, x := range a {
+		for _, y := range b {
+			switch {
+			case x > y:
+				wins++
+			case x == y:
+				wins += 0.5
+			}
+		}
+	}
+	return wins / float64(len(a)*len(b))
+}
+
+// Magnitude classifies an A12 value with the thresholds of Vargha and Delaney (2000):
+// "negligible", "small", "medium" or "large".
+func Magnitude(a12 float64) string {
+	d := a12 - 0.5
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d < 0.06:
+		return "negligible"
+	case d < 0.14:
+		return "small"
+	case d < 0.21:
+		return "medium"
+	}
+	return "large"
+}
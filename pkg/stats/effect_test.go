@@ -0,0 +1,44 @@
+package stats
+
+import "testing"
+
+func TestA12(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"always greater", []float64{3, 4}, []float64{1, 2}, 1},
+		{"always smaller", []float64{1, 2}, []float64{3, 4}, 0},
+		{"equal samples", []float64{1, 2}, []float64{1, 2}, 0.5},
+		// pairs: tie, loss, win, loss
+		{"mixed", []float64{1, 2}, []float64{1, 3}, 0.375},
+		{"empty sample", nil, []float64{1}, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := A12(tt.a, tt.b); got != tt.want {
+				t.Errorf("A12() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	tests := []struct {
+		a12  float64
+		want string
+	}{
+		{0.5, "negligible"},
+		{0.55, "negligible"},
+		{0.375, "small"},
+		{0.7, "medium"},
+		{0.2, "large"},
+		{1, "large"},
+	}
+	for _, tt := range tests {
+		if got := Magnitude(tt.a12); got != tt.want {
+			t.Errorf("Magnitude(%v) = %q, want %q", tt.a12, got, tt.want)
+		}
+	}
+}
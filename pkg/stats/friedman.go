@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// FriedmanResult is the outcome of a Friedman test over k treatments on N blocks.
+type FriedmanResult struct {
+	ChiSquare float64
+	P         float64
+	// MeanRanks holds the average rank of every treatment (1 is the smallest value).
+	MeanRanks []float64
+	Blocks    int
+}
+
+// Friedman tests whether k treatments (e.g. algorithms) differ, given data[block][treatment]
+// values measured on N blocks (e.g. problem instances). Ranks are taken within every block
+// and the statistic is corrected for ties.
+func Friedman(data [][]float64) (FriedmanResult, error) {
+	n := len(data)
+	if n == 0 {
+		return FriedmanResult{}, errors.New("friedman test needs at least one block")
+	}
+	k := len(data[0])
+	if k < 2 {
+		return FriedmanResult{}, errors.New("friedman test needs at least two treatments")
+	}
+
+	rankSums := make([]float64, k)
+	ties := 0.0
+	for i, block := range data {
+		if len(block) != k {
+			return FriedmanResult{}, fmt.Errorf("block %d has %d values, expected %d", i, len(block), k)
+		}
+		r, t := ranks(block)
+		for j, v := range r {
+			rankSums[j] += v
+		}
+		ties += t
+	}
+
+	fn, fk := float64(n), float64(k)
+	sum := 0.0
+	for _, r := range rankSums {
+		sum += r * r
+	}
+	chi := 12/(fn*fk*(fk+1))*sum - 3*fn*(fk+1)
+	if denominator := 1 - ties/(fn*(fk*fk*fk-fk)); denominator > 0 {
+		chi /= denominator
+	}
+
+	result := FriedmanResult{ChiSquare: chi, Blocks: n, MeanRanks: make([]float64, k)}
+	for j, r := range rankSums {
+		result.MeanRanks[j] = r / fn
+	}
+	result.P = distuv.ChiSquared{K: fk - 1}.Survival(math.Max(chi, 0))
+	return result, nil
+}
+
+// qAlpha holds the critical values of the Nemenyi test (studentized range divided
+// by √2) for k = 2…10 treatments, from Demšar (2006).
+var qAlpha = map[float64][]float64{
+	0.05: {1.960, 2.343, 2.569, 2.728, 2.850, 2.949, 3.031, 3.102, 3.164},
+	0.10: {1.645, 2.052, 2.291, 2.459, 2.589, 2.693, 2.780, 2.855, 2.920},
+}
+
+// CriticalDifference returns the Nemenyi critical difference of mean ranks for
+// k treatments on n blocks at significance level alpha (0.05 or 0.10, k ≤ 10).
+// Two treatments differ significantly if their mean ranks differ by at least this value.
+func CriticalDifference(k, n int, alpha float64) (float64, error) {
+	table, ok := qAlpha[alpha]
+	if !ok {
+		return 0, fmt.Errorf("no Nemenyi critical values for alpha %v, use 0.05 or 0.10", alpha)
+	}
+	if k < 2 || k-2 >= len(table) {
+		return 0, fmt.Errorf("no Nemenyi critical values for %d treatments", k)
+	}
+	if n < 1 {
+		return 0, errors.New("critical difference needs at least one block")
+	}
+	fk := float64(k)
+	return table[k-2] * math.Sqrt(fk*(fk+1)/(6*float64(n))), nil
+}
+
+// NemenyiPair is the post-hoc comparison of treatments I and J.
+type NemenyiPair struct {
+	I, J        int
+	Difference  float64 // |mean rank I - mean rank J|
+	Significant bool
+}
+
+// Nemenyi compares every pair of treatments of a Friedman result against the critical difference.
+func Nemenyi(result FriedmanResult, alpha float64) ([]NemenyiPair, float64, error) {
+	cd, err := CriticalDifference(len(result.MeanRanks), result.Blocks, alpha)
+	if err != nil {
+		return nil, 0, err
+	}
+	var pairs []NemenyiPair
+	for i := range result.MeanRanks {
+		for j := i + 1; j < len(result.MeanRanks); j++ {
+			d := math.Abs(result.MeanRanks[i] - result.MeanRanks[j])
+			pairs = append(pairs, NemenyiPair{I: i, J: j, Difference: d, Significant: d >= cd})
+		}
+	}
+	return pairs, cd, nil
+}
+
+// ControlDifference returns the Bonferroni–Dunn critical difference of mean ranks
+// for comparing k-1 treatments against a single control on n blocks at significance
+// level alpha. Unlike CriticalDifference it works for any k and alpha, which racing
+// procedures need as candidates come and go.
+func ControlDifference(k, n int, alpha float64) (float64, error) {
+	if k < 2 {
+		return 0, fmt.Errorf("control comparison needs at least two treatments, got %d", k)
+	}
+	if n < 1 {
+		return 0, errors.New("critical difference needs at least one block")
+	}
+	if alpha <= 0 || alpha >= 1 {
+		return 0, fmt.Errorf("alpha %v is not in (0, 1)", alpha)
+	}
+	fk := float64(k)
+	q := distuv.UnitNormal.Quantile(1 - alpha/(2*(fk-1)))
+	return q * math.Sqrt(fk*(fk+1)/(6*float64(n))), nil
+}
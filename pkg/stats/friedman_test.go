@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFriedman(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      [][]float64
+		chi, p    float64
+		meanRanks []float64
+	}{
+		// rank sums 3, 6 and 9: χ² = 12/36·126 - 36, and the χ² survival with 2 degrees of freedom is e^(-χ²/2)
+		{"consistent order", [][]float64{{1, 2, 3}, {10, 20, 30}, {0.1, 0.2, 0.3}}, 6, math.Exp(-3), []float64{1, 2, 3}},
+		{"no preference", [][]float64{{1, 2, 3}, {3, 1, 2}, {2, 3, 1}}, 0, 1, []float64{2, 2, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Friedman(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(result.ChiSquare-tt.chi) > 1e-9 || math.Abs(result.P-tt.p) > 1e-9 {
+				t.Errorf("Friedman() = χ² %v, p %v, want %v, %v", result.ChiSquare, result.P, tt.chi, tt.p)
+			}
+			for i, r := range tt.meanRanks {
+				if math.Abs(result.MeanRanks[i]-r) > 1e-9 {
+					t.Errorf("mean ranks %v, want %v", result.MeanRanks, tt.meanRanks)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestFriedmanErrors(t *testing.T) {
+	for _, data := range [][][]float64{nil, {{1}}, {{1, 2}, {1, 2, 3}}} {
+		if _, err := Friedman(data); err == nil {
+			t.Errorf("Friedman(%v) succeeded", data)
+		}
+	}
+}
+
+func TestCriticalDifferences(t *testing.T) {
+	// the worked example of Demšar (2006): four classifiers on 14 data sets
+	tests := []struct {
+		name string
+		cd   func(k, n int, alpha float64) (float64, error)
+		want float64
+	}{
+		{"Nemenyi", CriticalDifference, 2.569 * math.Sqrt(20.0/84)},
+		{"Bonferroni–Dunn", ControlDifference, 2.394 * math.Sqrt(20.0/84)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cd, err := tt.cd(4, 14, 0.05)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(cd-tt.want) > 1e-3 {
+				t.Errorf("critical difference = %v, want %v", cd, tt.want)
+			}
+		})
+	}
+	if _, err := CriticalDifference(11, 14, 0.05); err == nil {
+		t.Error("CriticalDifference() beyond the table succeeded")
+	}
+}
+
+func TestNemenyi(t *testing.T) {
+	result := FriedmanResult{MeanRanks: []float64{1, 1.5, 3}, Blocks: 14}
+	pairs, cd, err := Nemenyi(result, 0.05)
+	if err != nil {
+		t.Fatal(err)
+	}
+	significant := map[[2]int]bool{{0, 1}: false, {0, 2}: true, {1, 2}: true}
+	if len(pairs) != len(significant) {
+		t.Fatalf("Nemenyi() = %v, want 3 pairs", pairs)
+	}
+	for _, pair := range pairs {
+		if want := significant[[2]int{pair.I, pair.J}]; pair.Significant != want {
+			t.Errorf("pair %d-%d differs by %v against %v, significant = %v", pair.I, pair.J, pair.Difference, cd, pair.Significant)
+		}
+	}
+}
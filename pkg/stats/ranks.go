@@ -0,0 +1,37 @@
+// Package stats provides non-parametric tests for comparing stochastic optimizers:
+// the Wilcoxon rank-sum test, the Friedman test with the Nemenyi post-hoc test
+// and the Vargha–Delaney A12 effect size.
+package stats
+
+import (
+	"sort"
+)
+
+// ranks returns the 1-based ranks of values, giving tied values their average rank,
+// and the tie correction term Σ(t³ - t) over groups of t tied values.
+func ranks(values []float64) ([]float64, float64) {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+
+	r := make([]float64, len(values))
+	ties := 0.0
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && values[order[j]] == values[order[i]] {
+			j++
+		}
+		// positions i..j-1 share ranks i+1..j
+		avg := float64(i+1+j) / 2
+		for k := i; k < j; k++ {
+			r[order[k]] = avg
+		}
+		if t := float64(j - i); t > 1 {
+			ties += t*t*t - t
+		}
+		i = j
+	}
+	return r, ties
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// exactLimit is the largest total sample size for which the exact null
+// distribution of the rank-sum statistic is computed.
+const exactLimit = 50
+
+// RankSumResult is the outcome of a two-sided Wilcoxon rank-sum (Mann–Whitney U) test.
+type RankSumResult struct {
+	U     float64 // Mann–Whitney U of the first sample
+	Z     float64 // normal approximation score, 0 for exact tests
+	P     float64 // two-sided p-value
+	Exact bool
+}
+
+// RankSum tests whether samples a and b come from the same distribution.
+// Without ties and for at most 50 observations the p-value is exact, otherwise
+// it uses the normal approximation with tie and continuity corrections.
+func RankSum(a, b []float64) (RankSumResult, error) {
+	n1, n2 := len(a), len(b)
+	if n1 == 0 || n2 == 0 {
+		return RankSumResult{}, errors.New("rank-sum test needs two non-empty samples")
+	}
+	all := append(append(make([]float64, 0, n1+n2), a...), b...)
+	r, ties := ranks(all)
+	r1 := 0.0
+	for _, v := range r[:n1] {
+		r1 += v
+	}
+	u := r1 - float64(n1*(n1+1))/2
+	result := RankSumResult{U: u}
+
+	if ties == 0 && n1+n2 <= exactLimit {
+		result.Exact = true
+		result.P = exactRankSumP(n1, n2, u)
+		return result, nil
+	}
+
+	fn1, fn2 := float64(n1), float64(n2)
+	n := fn1 + fn2
+	mean := fn1 * fn2 / 2
+	variance := fn1 * fn2 / 12 * ((n + 1) - ties/(n*(n-1)))
+	if variance == 0 {
+		// all observations are equal
+		result.P = 1
+		return result, nil
+	}
+	diff := u - mean
+	correction := 0.5 * math.Copysign(1, diff)
+	if math.Abs(diff) < 0.5 {
+		correction = diff
+	}
+	result.Z = (diff - correction) / math.Sqrt(variance)
+	result.P = math.Min(1, 2*distuv.UnitNormal.Survival(math.Abs(result.Z)))
+	return result, nil
+}
+
+// exactRankSumP returns the two-sided p-value of U under the null hypothesis,
+// counting the ways to choose n1 of n1+n2 ranks with every rank sum.
+func exactRankSumP(n1, n2 int, u float64) float64 {
+	maxU := n1 * n2
+	// counts[k][s]: number of k-subsets of the ranks seen so far with U-sum s
+	counts := make([][]float64, n1+1)
+	for k := range counts {
+		counts[k] = make([]float64, maxU+1)
+	}
+	counts[0][0] = 1
+	for i := range n1 + n2 {
+		for k := min(i+1, n1); k >= 1; k-- {
+			// choosing rank i+1 as the k-th element adds i-(k-1) to U
+			shift := i - (k - 1)
+			if shift < 0 || shift > n2 {
+				continue
+			}
+			for s := maxU; s >= shift; s-- {
+				counts[k][s] += counts[k-1][s-shift]
+			}
+		}
+	}
+
+	total := 0.0
+	for _, c := range counts[n1] {
+		total += c
+	}
+	// the distribution is symmetric around n1*n2/2
+	low := math.Min(u, float64(maxU)-u)
+	tail := 0.0
+	for s := 0; float64(s) <= low; s++ {
+		tail += counts[n1][s]
+	}
+	return math.Min(1, 2*tail/total)
+}
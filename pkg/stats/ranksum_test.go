@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRankSumExact(t *testing.T) {
+	// p-values from the exact null distribution of U, as in tables of the
+	// Mann–Whitney test and R's wilcox.test
+	tests := []struct {
+		name string
+		a, b []float64
+		u, p float64
+	}{
+		{"3 and 3 separated", []float64{1, 2, 3}, []float64{4, 5, 6}, 0, 2.0 / 20},
+		{"4 and 4 separated", []float64{1, 2, 3, 4}, []float64{5, 6, 7, 8}, 0, 2.0 / 70},
+		{"5 and 5 separated", []float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 0, 2.0 / 252},
+		{"reversed samples", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 25, 2.0 / 252},
+		// U ≤ 1 in 2 of the 35 splits
+		{"3 and 4 with one swap", []float64{1, 2, 4}, []float64{3, 5, 6, 7}, 1, 4.0 / 35},
+		{"interleaved", []float64{1, 4, 5}, []float64{2, 3, 6}, 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RankSum(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Exact || result.U != tt.u || math.Abs(result.P-tt.p) > 1e-12 {
+				t.Errorf("RankSum() = %+v, want exact U %v, p %v", result, tt.u, tt.p)
+			}
+		})
+	}
+}
+
+func TestRankSumNormal(t *testing.T) {
+	// ties force the normal approximation; ranks of a are 1, 3, 3 and 5.5, so
+	// U = 2.5 against a mean of 8, and the tie groups of 3 and 2 give
+	// a variance of 16/12·(9 - 30/56)
+	result, err := RankSum([]float64{1, 2, 2, 3}, []float64{2, 3, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := -5 / math.Sqrt(16.0/12*(9-30.0/56))
+	p := math.Erfc(math.Abs(z) / math.Sqrt2)
+	if result.Exact || result.U != 2.5 || math.Abs(result.Z-z) > 1e-9 || math.Abs(result.P-p) > 1e-9 {
+		t.Errorf("RankSum() = %+v, want U 2.5, z %v, p %v", result, z, p)
+	}
+	if math.Abs(result.P-0.1367) > 1e-4 {
+		t.Errorf("p = %v, want 0.1367", result.P)
+	}
+}
+
+func TestRankSumDegenerate(t *testing.T) {
+	if _, err := RankSum(nil, []float64{1}); err == nil {
+		t.Error("RankSum() of an empty sample succeeded")
+	}
+	result, err := RankSum([]float64{1, 1}, []float64{1, 1, 1})
+	if err != nil || result.P != 1 {
+		t.Errorf("RankSum() of equal values = %+v, %v, want p 1", result, err)
+	}
+}
+
+func TestRanks(t *testing.T) {
+	r, ties := ranks([]float64{3, 1, 3, 2, 3})
+	want := []float64{4, 1, 4, 2, 4}
+	for i := range want {
+		if r[i] != want[i] {
+			t.Fatalf("ranks() = %v, want %v", r, want)
+		}
+	}
+	if ties != 24 {
+		t.Errorf("tie term = %v, want 24", ties)
+	}
+}
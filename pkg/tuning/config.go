@@ -0,0 +1,165 @@
+// Package tuning searches for good algorithm parameters. A tuning file declares
+// a method in the format of benchmark experiments, the ranges of some of its
+// parameters, a distribution of problem instances and a budget of runs; the tuner
+// races sampled configurations against each other (as irace does) or evaluates
+// them by plain random search, and returns the best one as a ready-to-use method.
+package tuning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/bench"
+)
+
+// Tuning describes a tuning task.
+type Tuning struct {
+	Name string `json:"name"`
+	Seed uint64 `json:"seed"`
+	// Budget is the total number of runs the tuner may spend.
+	Budget int `json:"budget"`
+	// Parallel is the number of runs executed at once; 0 uses one per CPU.
+	Parallel int `json:"parallel"`
+	// Strategy is "race" (the default) or "random".
+	Strategy string `json:"strategy"`
+	// Alpha is the significance level used to eliminate candidates, 0.05 by default.
+	Alpha float64 `json:"alpha"`
+	// FirstTest is the number of instances every candidate of a race is run on
+	// before the first elimination test, 5 by default.
+	FirstTest int `json:"first_test"`
+	// Instances is the number of instances every random search candidate is run on, 10 by default.
+	Instances int `json:"instances"`
+	// Problems is the instance distribution: every instance draws a problem and
+	// one of its sizes uniformly, and a fresh seed.
+	Problems []bench.ProblemSpec `json:"problems"`
+	// Method is the method to tune; Parameters overwrite parts of it.
+	Method     bench.MethodSpec `json:"method"`
+	Parameters []Parameter      `json:"parameters"`
+}
+
+// Parameter declares the range of one value of the method.
+type Parameter struct {
+	// Name labels the parameter in reports, defaults to Path.
+	Name string `json:"name"`
+	// Path locates the value in the method, as dot-separated keys and stage
+	// indexes, e.g. "stages.1.params.population_size" or "stages.1.params.mutation.k".
+	// A mutation or crossover given by name is turned into an object on the way.
+	Path string `json:"path"`
+	// Type is "int", "float" or "categorical".
+	Type string  `json:"type"`
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	// Log samples numeric values uniformly on a logarithmic scale.
+	Log    bool  `json:"log"`
+	Values []any `json:"values"`
+}
+
+// Load reads and validates a tuning file.
+func Load(path string) (*Tuning, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	t := &Tuning{}
+	if err := decoder.Decode(t); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+// Validate checks the tuning task without running it.
+func (t *Tuning) Validate() error {
+	if t.Budget < 1 {
+		return errors.New("tuning needs a budget of at least one run")
+	}
+	if len(t.Parameters) == 0 {
+		return errors.New("tuning needs at least one parameter")
+	}
+	switch t.Strategy {
+	case "", "race", "random":
+	default:
+		return fmt.Errorf("unknown strategy %q", t.Strategy)
+	}
+	if t.Alpha < 0 || t.Alpha >= 1 {
+		return fmt.Errorf("alpha %v is not in [0, 1)", t.Alpha)
+	}
+
+	names := make(map[string]bool)
+	for _, p := range t.Parameters {
+		if err := p.validate(); err != nil {
+			return err
+		}
+		if names[p.name()] {
+			return fmt.Errorf("parameter %s declared twice", p.name())
+		}
+		names[p.name()] = true
+	}
+
+	// every corner of the space has to produce a method the benchmark accepts
+	for _, config := range []Configuration{t.corner(false), t.corner(true)} {
+		method, err := t.Apply(config)
+		if err != nil {
+			return err
+		}
+		exp := &bench.Experiment{Repeats: 1, Problems: t.Problems, Methods: []bench.MethodSpec{method}}
+		if err := exp.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p Parameter) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.Path
+}
+
+func (p Parameter) validate() error {
+	if p.Path == "" {
+		return errors.New("every parameter needs a path")
+	}
+	switch p.Type {
+	case "int", "float":
+		if p.Min > p.Max {
+			return fmt.Errorf("parameter %s: min %v is above max %v", p.name(), p.Min, p.Max)
+		}
+		if p.Log && p.Min <= 0 {
+			return fmt.Errorf("parameter %s: a logarithmic range must be positive", p.name())
+		}
+	case "categorical":
+		if len(p.Values) == 0 {
+			return fmt.Errorf("parameter %s has no values", p.name())
+		}
+	default:
+		return fmt.Errorf("parameter %s: unknown type %q", p.name(), p.Type)
+	}
+	return nil
+}
+
+// corner returns the configuration with every parameter at its lower or upper end.
+func (t *Tuning) corner(upper bool) Configuration {
+	config := make(Configuration, len(t.Parameters))
+	for _, p := range t.Parameters {
+		switch {
+		case p.Type == "categorical" && upper:
+			config[p.name()] = p.Values[len(p.Values)-1]
+		case p.Type == "categorical":
+			config[p.name()] = p.Values[0]
+		case upper:
+			config[p.name()] = p.clamp(p.Max)
+		default:
+			config[p.name()] = p.clamp(p.Min)
+		}
+	}
+	return config
+}
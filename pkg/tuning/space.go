@@ -0,0 +1,147 @@
+package tuning
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/bench"
+)
+
+// Configuration maps parameter names to values.
+type Configuration map[string]any
+
+// Apply returns the tuned method with the values of config written into it.
+func (t *Tuning) Apply(config Configuration) (bench.MethodSpec, error) {
+	data, err := json.Marshal(t.Method)
+	if err != nil {
+		return bench.MethodSpec{}, err
+	}
+	var tree any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return bench.MethodSpec{}, err
+	}
+	for _, p := range t.Parameters {
+		value, ok := config[p.name()]
+		if !ok {
+			return bench.MethodSpec{}, fmt.Errorf("configuration has no value for %s", p.name())
+		}
+		if tree, err = set(tree, strings.Split(p.Path, "."), value); err != nil {
+			return bench.MethodSpec{}, fmt.Errorf("parameter %s: %w", p.name(), err)
+		}
+	}
+	if data, err = json.Marshal(tree); err != nil {
+		return bench.MethodSpec{}, err
+	}
+	var method bench.MethodSpec
+	err = json.Unmarshal(data, &method)
+	return method, err
+}
+
+// set writes value at path in a decoded JSON tree, creating missing objects.
+func set(node any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	key := path[0]
+	switch n := node.(type) {
+	case nil:
+		return set(map[string]any{}, path, value)
+	case string:
+		// an operator given by name becomes an object so its parameters can be set
+		return set(map[string]any{"name": n}, path, value)
+	case map[string]any:
+		child, err := set(n[key], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[key] = child
+		return n, nil
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(n) {
+			return nil, fmt.Errorf("no element %q in a list of %d", key, len(n))
+		}
+		if n[i], err = set(n[i], path[1:], value); err != nil {
+			return nil, err
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("cannot set %q inside a %T", key, node)
+}
+
+// sample draws a configuration uniformly from the declared ranges.
+func (t *Tuning) sample(rng *rand.Rand) Configuration {
+	config := make(Configuration, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if p.Type == "categorical" {
+			config[p.name()] = p.Values[rng.IntN(len(p.Values))]
+			continue
+		}
+		lo, hi := p.scaled()
+		config[p.name()] = p.clamp(p.unscale(lo + rng.Float64()*(hi-lo)))
+	}
+	return config
+}
+
+// perturb draws a configuration around parent. The spread shrinks as iteration
+// grows, so later iterations refine the elites instead of exploring.
+func (t *Tuning) perturb(rng *rand.Rand, parent Configuration, iteration int) Configuration {
+	shrink := math.Pow(0.5, float64(iteration))
+	config := make(Configuration, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if p.Type == "categorical" {
+			if rng.Float64() < shrink {
+				config[p.name()] = p.Values[rng.IntN(len(p.Values))]
+			} else {
+				config[p.name()] = parent[p.name()]
+			}
+			continue
+		}
+		lo, hi := p.scaled()
+		x := p.scale(toFloat(parent[p.name()])) + rng.NormFloat64()*(hi-lo)*shrink
+		config[p.name()] = p.clamp(p.unscale(min(max(x, lo), hi)))
+	}
+	return config
+}
+
+// scaled returns the range in the space values are sampled in.
+func (p Parameter) scaled() (float64, float64) {
+	return p.scale(p.Min), p.scale(p.Max)
+}
+
+func (p Parameter) scale(x float64) float64 {
+	if p.Log {
+		return math.Log(x)
+	}
+	return x
+}
+
+func (p Parameter) unscale(x float64) float64 {
+	if p.Log {
+		return math.Exp(x)
+	}
+	return x
+}
+
+// clamp keeps x in range and rounds integer parameters.
+func (p Parameter) clamp(x float64) any {
+	x = min(max(x, p.Min), p.Max)
+	if p.Type == "int" {
+		return int(math.Round(x))
+	}
+	return x
+}
+
+func toFloat(v any) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return math.NaN()
+}
@@ -0,0 +1,351 @@
+package tuning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/GregoryKogan/genetic-algorithms/pkg/bench"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/problems"
+	"github.com/GregoryKogan/genetic-algorithms/pkg/stats"
+)
+
+// Candidate is a configuration together with the fitness it reached on the
+// instances it has run on so far. Instances are shared by all candidates, so
+// Fitness[i] of two candidates is measured on the same problem instance.
+type Candidate struct {
+	ID        int
+	Iteration int // race the candidate was sampled in
+	Config    Configuration
+	Fitness   []float64
+}
+
+// Mean returns the mean fitness of the candidate.
+func (c *Candidate) Mean() float64 {
+	sum := 0.0
+	for _, f := range c.Fitness {
+		sum += f
+	}
+	return sum / float64(len(c.Fitness))
+}
+
+// Result is the outcome of a tuning task.
+type Result struct {
+	Best *Candidate
+	// Method is the tuned method with the configuration of Best.
+	Method bench.MethodSpec
+	// Elites are the final candidates, best first.
+	Elites []*Candidate
+	Runs   int
+}
+
+type instance struct {
+	problem bench.ProblemSpec
+	size    int
+	seed    uint64
+}
+
+type tuner struct {
+	*Tuning
+	ctx       context.Context
+	rng       *rand.Rand
+	instances []instance
+	runs      int
+	nextID    int
+	progress  io.Writer
+}
+
+// Tune runs the tuning task. Progress, if not nil, receives a line per race.
+func Tune(ctx context.Context, t *Tuning, progress io.Writer) (Result, error) {
+	if err := t.Validate(); err != nil {
+		return Result{}, err
+	}
+	tu := &tuner{Tuning: t, ctx: ctx, rng: problems.NewRand(t.Seed), progress: progress}
+
+	var elites []*Candidate
+	var err error
+	if t.Strategy == "random" {
+		elites, err = tu.randomSearch()
+	} else {
+		elites, err = tu.iteratedRace()
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	if len(elites) == 0 {
+		return Result{}, errors.New("budget too small to evaluate any configuration")
+	}
+
+	method, err := t.Apply(elites[0].Config)
+	if err != nil {
+		return Result{}, err
+	}
+	if t.Name != "" {
+		method.Name = t.Name
+	}
+	return Result{Best: elites[0], Method: method, Elites: elites, Runs: tu.runs}, nil
+}
+
+// randomSearch runs every sampled configuration on the same instances.
+func (tu *tuner) randomSearch() ([]*Candidate, error) {
+	per := tu.Instances
+	if per <= 0 {
+		per = 10
+	}
+	per = min(per, tu.Budget)
+	candidates := make([]*Candidate, tu.Budget/per)
+	for i := range candidates {
+		candidates[i] = tu.candidate(tu.sample(tu.rng), 0)
+	}
+	if err := tu.evaluate(candidates, per); err != nil {
+		return nil, err
+	}
+	return tu.rank(candidates), nil
+}
+
+// iteratedRace follows irace: every iteration samples new candidates around the
+// elites of the previous one and races them, dropping candidates as soon as a
+// Friedman test shows they are worse than the best one.
+func (tu *tuner) iteratedRace() ([]*Candidate, error) {
+	params := float64(len(tu.Parameters))
+	iterations := 2 + int(math.Log2(params))
+	survivors := 2 + int(math.Log2(params))
+	firstTest := tu.FirstTest
+	if firstTest <= 0 {
+		firstTest = 5
+	}
+
+	var elites []*Candidate
+	for it := range iterations {
+		budget := (tu.Budget - tu.runs) / (iterations - it)
+		count := budget/(firstTest+min(5, it)) - len(elites)
+		// every new candidate has to afford at least the instances the elites have seen
+		if it > 0 && len(elites) > 0 {
+			count = min(count, budget/max(len(elites[0].Fitness), 1))
+		}
+		count = min(count, budget)
+		if count < 1 {
+			// leave the budget to the following iterations
+			continue
+		}
+
+		candidates := append([]*Candidate(nil), elites...)
+		for range count {
+			if it == 0 {
+				candidates = append(candidates, tu.candidate(tu.sample(tu.rng), it))
+			} else {
+				candidates = append(candidates, tu.candidate(tu.perturb(tu.rng, tu.parent(elites), it), it))
+			}
+		}
+		alive, err := tu.race(candidates, budget, survivors, firstTest)
+		if err != nil {
+			return nil, err
+		}
+		elites = alive[:min(survivors, len(alive))]
+		if tu.progress != nil {
+			fmt.Fprintf(tu.progress, "race %d: %d candidates, %d runs in total, best #%d with mean fitness %.4g on %d instances\n",
+				it+1, len(candidates), tu.runs, elites[0].ID, elites[0].Mean(), len(elites[0].Fitness))
+		}
+	}
+	return elites, nil
+}
+
+// race evaluates the candidates instance by instance within budget runs and
+// returns the ones that were not eliminated, best first.
+func (tu *tuner) race(candidates []*Candidate, budget, survivors, firstTest int) ([]*Candidate, error) {
+	alpha := tu.Alpha
+	if alpha == 0 {
+		alpha = 0.05
+	}
+	alive := candidates
+	spent := 0
+	for n := 1; len(alive) > survivors || n <= firstTest; n++ {
+		cost := cost(alive, n)
+		if cost > budget-spent {
+			break
+		}
+		if err := tu.evaluate(alive, n); err != nil {
+			return nil, err
+		}
+		spent += cost
+		if n < firstTest || len(alive) < 2 {
+			continue
+		}
+		var err error
+		if alive, err = eliminate(alive, n, alpha); err != nil {
+			return nil, err
+		}
+	}
+	return tu.rank(alive), nil
+}
+
+// eliminate drops the candidates whose mean rank over the first n instances is
+// significantly worse than the best one.
+func eliminate(alive []*Candidate, n int, alpha float64) ([]*Candidate, error) {
+	result, err := friedman(alive, n)
+	if err != nil || result.P >= alpha {
+		return alive, err
+	}
+	cd, err := stats.ControlDifference(len(alive), n, alpha)
+	if err != nil {
+		return nil, err
+	}
+	best := math.Inf(1)
+	for _, r := range result.MeanRanks {
+		best = min(best, r)
+	}
+	var kept []*Candidate
+	for i, c := range alive {
+		if result.MeanRanks[i]-best < cd {
+			kept = append(kept, c)
+		}
+	}
+	return kept, nil
+}
+
+// rank orders candidates by their mean rank on the instances they all ran on.
+func (tu *tuner) rank(candidates []*Candidate) []*Candidate {
+	ranked := append([]*Candidate(nil), candidates...)
+	if len(ranked) < 2 {
+		return ranked
+	}
+	n := math.MaxInt
+	for _, c := range ranked {
+		n = min(n, len(c.Fitness))
+	}
+	meanRank := make(map[*Candidate]float64, len(ranked))
+	if result, err := friedman(ranked, n); err == nil {
+		for i, c := range ranked {
+			meanRank[c] = result.MeanRanks[i]
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if meanRank[a] != meanRank[b] {
+			return meanRank[a] < meanRank[b]
+		}
+		return a.Mean() < b.Mean()
+	})
+	return ranked
+}
+
+func friedman(candidates []*Candidate, n int) (stats.FriedmanResult, error) {
+	data := make([][]float64, n)
+	for b := range data {
+		data[b] = make([]float64, len(candidates))
+		for j, c := range candidates {
+			data[b][j] = c.Fitness[b]
+		}
+	}
+	return stats.Friedman(data)
+}
+
+// parent picks an elite, the better ones more often.
+func (tu *tuner) parent(elites []*Candidate) Configuration {
+	total := len(elites) * (len(elites) + 1) / 2
+	pick := tu.rng.IntN(total)
+	for i, e := range elites {
+		pick -= len(elites) - i
+		if pick < 0 {
+			return e.Config
+		}
+	}
+	return elites[0].Config
+}
+
+func (tu *tuner) candidate(config Configuration, iteration int) *Candidate {
+	tu.nextID++
+	return &Candidate{ID: tu.nextID, Iteration: iteration, Config: config}
+}
+
+// instance returns the i-th instance of the stream, drawing new ones as needed.
+func (tu *tuner) instance(i int) instance {
+	for len(tu.instances) <= i {
+		p := tu.Problems[tu.rng.IntN(len(tu.Problems))]
+		tu.instances = append(tu.instances, instance{
+			problem: p,
+			size:    p.Sizes[tu.rng.IntN(len(p.Sizes))],
+			// keep seeds exactly representable in JSON logs and parameter maps
+			seed: tu.rng.Uint64()>>12 | 1,
+		})
+	}
+	return tu.instances[i]
+}
+
+// cost is the number of runs needed to evaluate candidates on the first n instances.
+func cost(candidates []*Candidate, n int) int {
+	runs := 0
+	for _, c := range candidates {
+		runs += max(n-len(c.Fitness), 0)
+	}
+	return runs
+}
+
+// evaluate runs the candidates on the first n instances they have not run on yet.
+func (tu *tuner) evaluate(candidates []*Candidate, n int) error {
+	type job struct {
+		c *Candidate
+		i int
+	}
+	var jobs []job
+	for _, c := range candidates {
+		for i := len(c.Fitness); i < n; i++ {
+			jobs = append(jobs, job{c, i})
+		}
+		c.Fitness = append(c.Fitness, make([]float64, max(n-len(c.Fitness), 0))...)
+	}
+	for i := range n {
+		tu.instance(i)
+	}
+
+	workers := tu.Parallel
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	ctx, cancel := context.WithCancel(tu.ctx)
+	defer cancel()
+
+	errs := make([]error, len(jobs))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range queue {
+				j := jobs[k]
+				method, err := tu.Apply(j.c.Config)
+				if err == nil {
+					in := tu.instances[j.i]
+					var result bench.RunResult
+					result, err = bench.RunOnce(ctx, in.problem, in.size, method, in.seed)
+					j.c.Fitness[j.i] = result.Fitness
+				}
+				if err != nil {
+					errs[k] = fmt.Errorf("candidate #%d: %w", j.c.ID, err)
+					cancel()
+				}
+			}
+		}()
+	}
+	for k := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- k
+	}
+	close(queue)
+	wg.Wait()
+
+	tu.runs += len(jobs)
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return ctx.Err()
+}